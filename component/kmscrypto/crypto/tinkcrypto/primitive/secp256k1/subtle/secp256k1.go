@@ -29,6 +29,22 @@ func NewSecp256K1Signature(r, s *big.Int) *Secp256k1Signature {
 	return &Secp256k1Signature{R: r, S: s}
 }
 
+// IsLowS returns true if S of the signature is not greater than half of the order of the curve, i.e. the signature
+// is in the low-S form of BIP-62 accepted by other secp256k1 libraries.
+func (sig *Secp256k1Signature) IsLowS(curve elliptic.Curve) bool {
+	halfOrder := new(big.Int).Rsh(curve.Params().N, 1)
+
+	return sig.S.Cmp(halfOrder) <= 0
+}
+
+// NormalizeS converts the signature to the low-S form by replacing S with N-S if S is greater than half of the
+// order N of the curve. Both forms are valid ECDSA signatures of the same message.
+func (sig *Secp256k1Signature) NormalizeS(curve elliptic.Curve) {
+	if !sig.IsLowS(curve) {
+		sig.S = new(big.Int).Sub(curve.Params().N, sig.S)
+	}
+}
+
 // EncodeSecp256K1Signature converts the signature to the given encoding format.
 func (sig *Secp256k1Signature) EncodeSecp256K1Signature(encoding, curveName string) ([]byte, error) {
 	var (
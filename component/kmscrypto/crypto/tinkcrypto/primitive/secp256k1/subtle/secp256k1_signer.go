@@ -72,8 +72,9 @@ func (e *Secp256K1Signer) Sign(data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("secp256k1_signer: signing failed: %w", err)
 	}
 
-	// format the signature
+	// format the signature in low-S form
 	sig := NewSecp256K1Signature(r, s)
+	sig.NormalizeS(e.privateKey.Curve)
 
 	ret, err := sig.EncodeSecp256K1Signature(e.encoding, e.privateKey.PublicKey.Curve.Params().Name)
 	if err != nil {
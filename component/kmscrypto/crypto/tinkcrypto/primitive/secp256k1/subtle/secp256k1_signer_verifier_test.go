@@ -9,6 +9,7 @@ package subtle_test
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/google/tink/go/subtle/random"
@@ -54,3 +55,44 @@ func TestSignVerify(t *testing.T) {
 		require.NoError(t, err, "unexpected error when verifying")
 	}
 }
+
+func TestSignVerify_LowS(t *testing.T) {
+	data := random.GetRandomBytes(20)
+	hash := "SHA256"
+	curve := subtleSignature.GetCurve("SECP256K1")
+	halfOrder := new(big.Int).Rsh(curve.Params().N, 1)
+	encodings := []string{"Bitcoin_DER", "Bitcoin_IEEE_P1363"}
+
+	for _, encoding := range encodings {
+		priv, err := ecdsa.GenerateKey(curve, rand.Reader)
+		require.NoError(t, err)
+
+		signer, err := subtleSignature.NewSecp256K1SignerFromPrivateKey(hash, encoding, priv)
+		require.NoError(t, err)
+
+		verifier, err := subtleSignature.NewSecp256K1VerifierFromPublicKey(hash, encoding, &priv.PublicKey)
+		require.NoError(t, err)
+
+		for i := 0; i < 20; i++ {
+			signature, err := signer.Sign(data)
+			require.NoError(t, err)
+
+			sig, err := subtleSignature.DecodeSecp256K1Signature(signature, encoding)
+			require.NoError(t, err)
+			require.True(t, sig.S.Cmp(halfOrder) <= 0, "signature is not in low-S form")
+			require.True(t, sig.IsLowS(curve))
+
+			// the high-S form of a valid signature is still accepted, e.g. for the signatures issued before
+			highS := subtleSignature.NewSecp256K1Signature(sig.R, new(big.Int).Sub(curve.Params().N, sig.S))
+			require.False(t, highS.IsLowS(curve))
+
+			highSBytes, err := highS.EncodeSecp256K1Signature(encoding, curve.Params().Name)
+			require.NoError(t, err)
+			require.NoError(t, verifier.Verify(highSBytes, data))
+
+			// normalization restores the signature
+			highS.NormalizeS(curve)
+			require.Equal(t, sig.S, highS.S)
+		}
+	}
+}
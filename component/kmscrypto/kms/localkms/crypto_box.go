@@ -9,6 +9,7 @@ package localkms
 import (
 	"bytes"
 	"crypto/ed25519"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -43,17 +44,35 @@ import (
 //	for encryption/decryption, so clients do not need to see
 //	the secrets themselves.
 type CryptoBox struct {
-	km *LocalKMS
+	km         *LocalKMS
+	randSource io.Reader
+}
+
+// CryptoBoxOpt is an option of CryptoBox.
+type CryptoBoxOpt func(b *CryptoBox)
+
+// WithCryptoBoxRandSource sets the randomness source of the CryptoBox, used by Seal when no source is passed to it.
+// It defaults to crypto/rand, and is meant for tests which need to reproduce exact ciphertexts.
+func WithCryptoBoxRandSource(randSource io.Reader) CryptoBoxOpt {
+	return func(b *CryptoBox) {
+		b.randSource = randSource
+	}
 }
 
 // NewCryptoBox creates a CryptoBox which provides crypto box encryption using the given KMS's key.
-func NewCryptoBox(w kms.KeyManager) (*CryptoBox, error) {
+func NewCryptoBox(w kms.KeyManager, opts ...CryptoBoxOpt) (*CryptoBox, error) {
 	lkms, ok := w.(*LocalKMS)
 	if !ok {
 		return nil, fmt.Errorf("cannot use parameter argument as KMS")
 	}
 
-	return &CryptoBox{km: lkms}, nil
+	b := &CryptoBox{km: lkms, randSource: rand.Reader}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b, nil
 }
 
 // Easy seals a message with a provided nonce
@@ -119,7 +138,12 @@ func (b *CryptoBox) EasyOpen(cipherText, nonce, theirPub, myPub []byte) ([]byte,
 //
 // Generates an ephemeral keypair to use for the sender, and includes
 // the ephemeral sender public key in the message.
+// If randSource is nil, the randomness source of the CryptoBox is used.
 func (b *CryptoBox) Seal(payload, theirEncPub []byte, randSource io.Reader) ([]byte, error) {
+	if randSource == nil {
+		randSource = b.randSource
+	}
+
 	// generate ephemeral curve25519 asymmetric keys
 	epk, esk, err := box.GenerateKey(randSource)
 	if err != nil {
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package localkms
 
 import (
+	"bytes"
 	"crypto/rand"
 	"testing"
 
@@ -107,6 +108,42 @@ func TestBoxSeal(t *testing.T) {
 	})
 }
 
+func TestBoxSeal_RandSource(t *testing.T) {
+	k := newKMS(t)
+	_, recPubKey, err := k.CreateAndExportPubKeyBytes(kms.ED25519)
+	require.NoError(t, err)
+
+	recEncPubKey, err := cryptoutil.PublicEd25519toCurve25519(recPubKey)
+	require.NoError(t, err)
+
+	msg := []byte("lorem ipsum dolor sit amet consectetur adipiscing elit ")
+	seed := bytes.Repeat([]byte{7}, 32)
+
+	seal := func() []byte {
+		b, err := NewCryptoBox(k, WithCryptoBoxRandSource(bytes.NewReader(seed)))
+		require.NoError(t, err)
+
+		enc, err := b.Seal(msg, recEncPubKey, nil)
+		require.NoError(t, err)
+
+		return enc
+	}
+
+	enc := seal()
+	require.Equal(t, enc, seal())
+
+	b, err := NewCryptoBox(k)
+	require.NoError(t, err)
+
+	dec, err := b.SealOpen(enc, recPubKey)
+	require.NoError(t, err)
+	require.Equal(t, msg, dec)
+
+	other, err := b.Seal(msg, recEncPubKey, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, enc, other)
+}
+
 /* Cannot convert X25519 keys to ED25519 keys, this test assumes fixed X25519 keys values. The KMS cannot store
 	encryption X25519 keys. The new KMS supports storing only ED25519 keys. For the sake of LegacyPacker,
     Crypto_Box.go converts from Ed25519 to X25519 only.
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package webkms
 
 import (
+	"crypto/rand"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -72,17 +73,35 @@ const (
 //	for encryption/decryption, so clients do not need to see
 //	the secrets themselves.
 type CryptoBox struct {
-	km *RemoteKMS
+	km         *RemoteKMS
+	randSource io.Reader
+}
+
+// CryptoBoxOpt is an option of CryptoBox.
+type CryptoBoxOpt func(b *CryptoBox)
+
+// WithCryptoBoxRandSource sets the randomness source of the CryptoBox, used by Seal when no source is passed to it.
+// It defaults to crypto/rand, and is meant for tests which need to reproduce exact ciphertexts.
+func WithCryptoBoxRandSource(randSource io.Reader) CryptoBoxOpt {
+	return func(b *CryptoBox) {
+		b.randSource = randSource
+	}
 }
 
 // NewCryptoBox creates a CryptoBox which provides remote crypto box encryption using the given KMS's key.
-func NewCryptoBox(w kms.KeyManager) (*CryptoBox, error) {
+func NewCryptoBox(w kms.KeyManager, opts ...CryptoBoxOpt) (*CryptoBox, error) {
 	lkms, ok := w.(*RemoteKMS)
 	if !ok {
 		return nil, fmt.Errorf("cannot use parameter argument as KMS")
 	}
 
-	return &CryptoBox{km: lkms}, nil
+	b := &CryptoBox{km: lkms, randSource: rand.Reader}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b, nil
 }
 
 // Easy remotely seals a message with a provided nonce
@@ -191,7 +210,12 @@ func (b *CryptoBox) EasyOpen(cipherText, nonce, theirPub, myPub []byte) ([]byte,
 //
 // Generates an ephemeral keypair to use for the sender, and includes
 // the ephemeral sender public key in the message.
+// If randSource is nil, the randomness source of the CryptoBox is used.
 func (b *CryptoBox) Seal(payload, theirEncPub []byte, randSource io.Reader) ([]byte, error) {
+	if randSource == nil {
+		randSource = b.randSource
+	}
+
 	sealStart := time.Now()
 	// generate ephemeral curve25519 asymmetric keys
 	epk, esk, err := box.GenerateKey(randSource)
@@ -7,22 +7,20 @@ SPDX-License-Identifier: Apache-2.0
 package ecdsa2019
 
 import (
-	"crypto"
 	"crypto/sha256"
 	"crypto/sha512"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
 
-	"github.com/multiformats/go-multibase"
+	"github.com/gowebpki/jcs"
 	"github.com/piprate/json-gold/ld"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
-	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/internal/suiteutil"
 	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
 )
 
@@ -31,75 +29,86 @@ const (
 	// implementing ecdsa signatures with RDF canonicalization as per this
 	// spec:https://www.w3.org/TR/vc-di-ecdsa/#ecdsa-2019
 	SuiteType = "ecdsa-2019"
+
+	// SuiteTypeRDFC "ecdsa-rdfc-2019" is the data integrity Type identifier the
+	// current revision of the spec gives to the ecdsa suite with RDF
+	// canonicalization: https://www.w3.org/TR/vc-di-ecdsa/#ecdsa-rdfc-2019
+	SuiteTypeRDFC = "ecdsa-rdfc-2019"
+
+	// SuiteTypeJCS "ecdsa-jcs-2019" is the data integrity Type identifier for the
+	// suite implementing ecdsa signatures with JSON canonicalization (RFC 8785) as
+	// per this spec: https://www.w3.org/TR/vc-di-ecdsa/#ecdsa-jcs-2019
+	SuiteTypeJCS = "ecdsa-jcs-2019"
+)
+
+// Canonicalization selects the algorithm a Suite uses to canonicalize the
+// document and proof configuration before hashing.
+type Canonicalization int
+
+const (
+	// RDFC canonicalizes using RDF Dataset Canonicalization (URDNA2015), as
+	// used by the ecdsa-2019 cryptosuite. This is the default.
+	RDFC Canonicalization = iota
+	// JCS canonicalizes using the JSON Canonicalization Scheme, as used by the
+	// ecdsa-jcs-2019 cryptosuite.
+	JCS
 )
 
+// SuiteType returns the cryptosuite identifier for proofs made with this
+// Canonicalization.
+func (c Canonicalization) SuiteType() string {
+	if c == JCS {
+		return SuiteTypeJCS
+	}
+
+	return SuiteType
+}
+
+// suiteTypeOf returns the cryptosuite identifier for the given Canonicalization,
+// using rdfcSuiteType (if set) as the identifier of RDFC proofs.
+func suiteTypeOf(c Canonicalization, rdfcSuiteType string) string {
+	if c == RDFC && rdfcSuiteType != "" {
+		return rdfcSuiteType
+	}
+
+	return c.SuiteType()
+}
+
 // SignerGetter returns a Signer, which must sign with the private key matching
 // the public key provided in models.ProofOptions.VerificationMethod.
-type SignerGetter func(pub *jwk.JWK) (Signer, error)
+type SignerGetter = suiteutil.SignerGetter
 
 // WithStaticSigner sets the Suite to use a fixed Signer, with externally-chosen signing key.
 //
 // Use when a signing Suite is initialized for a single signature, then thrown away.
 func WithStaticSigner(signer Signer) SignerGetter {
-	return func(*jwk.JWK) (Signer, error) {
-		return signer, nil
-	}
+	return suiteutil.WithStaticSigner(signer)
 }
 
 // WithLocalKMSSigner returns a SignerGetter that will sign using the given localkms, using the private key matching
 // the given public key.
 func WithLocalKMSSigner(kms models.KeyManager, kmsSigner KMSSigner) SignerGetter {
-	return func(pub *jwk.JWK) (Signer, error) {
-		kid, err := kmsKID(pub)
-		if err != nil {
-			return nil, err
-		}
-
-		kh, err := kms.Get(kid)
-		if err != nil {
-			return nil, err
-		}
-
-		return &wrapSigner{
-			kmsSigner: kmsSigner,
-			kh:        kh,
-		}, nil
-	}
+	return suiteutil.WithLocalKMSSigner(kms, kmsSigner)
 }
 
 // A KMSSigner is able to sign messages.
-type KMSSigner interface {
-	// Sign will sign msg using a matching signature primitive in kh key handle of a private key
-	// returns:
-	// 		signature in []byte
-	//		error in case of errors
-	Sign(msg []byte, kh interface{}) ([]byte, error)
-}
+type KMSSigner = suiteutil.KMSSigner
 
 // A Signer is able to sign messages.
-type Signer interface {
-	// Sign will sign msg using a private key internal to the Signer.
-	// returns:
-	// 		signature in []byte
-	//		error in case of errors
-	Sign(msg []byte) ([]byte, error)
-}
+type Signer = suiteutil.Signer
 
 // A Verifier is able to verify messages.
-type Verifier interface {
-	// Verify will verify a signature for the given msg using a matching signature primitive in kh key handle of
-	// a public key
-	// returns:
-	// 		error in case of errors or nil if signature verification was successful
-	Verify(pubKey *signatureverifier.PublicKey, msg, signature []byte) error
-}
+type Verifier = suiteutil.Verifier
 
-// Suite implements the ecdsa-2019 data integrity cryptographic suite.
+// Suite implements the ecdsa-2019 and ecdsa-jcs-2019 data integrity
+// cryptographic suites.
 type Suite struct {
-	ldLoader     ld.DocumentLoader
-	p256Verifier Verifier
-	p384Verifier Verifier
-	signerGetter SignerGetter
+	ldLoader         ld.DocumentLoader
+	p256Verifier     Verifier
+	p384Verifier     Verifier
+	signerGetter     SignerGetter
+	canonicalization Canonicalization
+	suiteType        string
 }
 
 // Options provides initialization options for Suite.
@@ -108,6 +117,10 @@ type Options struct {
 	P256Verifier     Verifier
 	P384Verifier     Verifier
 	SignerGetter     SignerGetter
+	Canonicalization Canonicalization
+	// SuiteType optionally sets the cryptosuite identifier of RDFC proofs:
+	// SuiteType (the default) or SuiteTypeRDFC. It is ignored for JCS.
+	SuiteType string
 }
 
 // SuiteInitializer is the initializer for Suite.
@@ -117,56 +130,51 @@ type SuiteInitializer func() (suite.Suite, error)
 func New(options *Options) SuiteInitializer {
 	return func() (suite.Suite, error) {
 		return &Suite{
-			ldLoader:     options.LDDocumentLoader,
-			p256Verifier: options.P256Verifier,
-			p384Verifier: options.P384Verifier,
-			signerGetter: options.SignerGetter,
+			ldLoader:         options.LDDocumentLoader,
+			p256Verifier:     options.P256Verifier,
+			p384Verifier:     options.P384Verifier,
+			signerGetter:     options.SignerGetter,
+			canonicalization: options.Canonicalization,
+			suiteType:        suiteTypeOf(options.Canonicalization, options.SuiteType),
 		}, nil
 	}
 }
 
-type initializer SuiteInitializer
-
-// Signer private, implements suite.SignerInitializer.
-func (i initializer) Signer() (suite.Signer, error) {
-	return i()
-}
-
-// Verifier private, implements suite.VerifierInitializer.
-func (i initializer) Verifier() (suite.Verifier, error) {
-	return i()
-}
-
-// Type private, implements suite.SignerInitializer and
-// suite.VerifierInitializer.
-func (i initializer) Type() string {
-	return SuiteType
-}
-
 // SignerInitializerOptions provides options for a SignerInitializer.
 type SignerInitializerOptions struct {
-	LDDocumentLoader ld.DocumentLoader
+	LDDocumentLoader ld.DocumentLoader // required for RDFC
 	SignerGetter     SignerGetter
+	Canonicalization Canonicalization // optional, defaults to RDFC
+	SuiteType        string           // optional, SuiteType (default) or SuiteTypeRDFC for RDFC
 }
 
 // NewSignerInitializer returns a suite.SignerInitializer that initializes an ecdsa-2019
-// signing Suite with the given SignerInitializerOptions.
+// (or ecdsa-jcs-2019, with JCS Canonicalization) signing Suite with the given
+// SignerInitializerOptions.
 func NewSignerInitializer(options *SignerInitializerOptions) suite.SignerInitializer {
-	return initializer(New(&Options{
-		LDDocumentLoader: options.LDDocumentLoader,
-		SignerGetter:     options.SignerGetter,
-	}))
+	return suiteutil.Initializer{
+		Init: New(&Options{
+			LDDocumentLoader: options.LDDocumentLoader,
+			SignerGetter:     options.SignerGetter,
+			Canonicalization: options.Canonicalization,
+			SuiteType:        options.SuiteType,
+		}),
+		SuiteType: suiteTypeOf(options.Canonicalization, options.SuiteType),
+	}
 }
 
 // VerifierInitializerOptions provides options for a VerifierInitializer.
 type VerifierInitializerOptions struct {
-	LDDocumentLoader ld.DocumentLoader // required
+	LDDocumentLoader ld.DocumentLoader // required for RDFC
 	P256Verifier     Verifier          // optional
 	P384Verifier     Verifier          // optional
+	Canonicalization Canonicalization  // optional, defaults to RDFC
+	SuiteType        string            // optional, SuiteType (default) or SuiteTypeRDFC for RDFC
 }
 
 // NewVerifierInitializer returns a suite.VerifierInitializer that initializes an
-// ecdsa-2019 verification Suite with the given VerifierInitializerOptions.
+// ecdsa-2019 (or ecdsa-jcs-2019, with JCS Canonicalization) verification Suite
+// with the given VerifierInitializerOptions.
 func NewVerifierInitializer(options *VerifierInitializerOptions) suite.VerifierInitializer {
 	p256Verifier, p384Verifier := options.P256Verifier, options.P384Verifier
 
@@ -178,17 +186,18 @@ func NewVerifierInitializer(options *VerifierInitializerOptions) suite.VerifierI
 		p384Verifier = signatureverifier.NewECDSAES384SignatureVerifier()
 	}
 
-	return initializer(New(&Options{
-		LDDocumentLoader: options.LDDocumentLoader,
-		P256Verifier:     p256Verifier,
-		P384Verifier:     p384Verifier,
-	}))
+	return suiteutil.Initializer{
+		Init: New(&Options{
+			LDDocumentLoader: options.LDDocumentLoader,
+			P256Verifier:     p256Verifier,
+			P384Verifier:     p384Verifier,
+			Canonicalization: options.Canonicalization,
+			SuiteType:        options.SuiteType,
+		}),
+		SuiteType: suiteTypeOf(options.Canonicalization, options.SuiteType),
+	}
 }
 
-const (
-	ldCtxKey = "@context"
-)
-
 // CreateProof implements the ecdsa-2019 cryptographic suite for Add Proof:
 // https://www.w3.org/TR/vc-di-ecdsa/#add-proof-ecdsa-2019
 func (s *Suite) CreateProof(doc []byte, opts *models.ProofOptions) (*models.Proof, error) {
@@ -197,41 +206,18 @@ func (s *Suite) CreateProof(doc []byte, opts *models.ProofOptions) (*models.Proo
 		return nil, err
 	}
 
-	sig, err := sign(docHash, vmKey, s.signerGetter)
-	if err != nil {
-		return nil, err
-	}
-
-	sigStr, err := multibase.Encode(multibase.Base58BTC, sig)
+	sig, err := suiteutil.Sign(docHash, vmKey, s.signerGetter)
 	if err != nil {
 		return nil, err
 	}
 
-	p := &models.Proof{
-		Type:               models.DataIntegrityProof,
-		CryptoSuite:        SuiteType,
-		ProofPurpose:       opts.Purpose,
-		Domain:             opts.Domain,
-		Challenge:          opts.Challenge,
-		VerificationMethod: opts.VerificationMethod.ID,
-		ProofValue:         sigStr,
-		Created:            opts.Created.Format(models.DateTimeFormat),
-	}
-
-	return p, nil
+	return suiteutil.NewProof(sig, s.suiteType, opts)
 }
 
 func (s *Suite) transformAndHash(doc []byte, opts *models.ProofOptions) ([]byte, *jwk.JWK, Verifier, error) {
-	docData := make(map[string]interface{})
-
-	err := json.Unmarshal(doc, &docData)
+	docData, vmKey, err := suiteutil.ParseDocument(doc, s.suiteType, opts)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("ecdsa-2019 suite expects JSON-LD payload: %w", err)
-	}
-
-	vmKey := opts.VerificationMethod.JSONWebKey()
-	if vmKey == nil {
-		return nil, nil, nil, errors.New("verification method needs JWK")
+		return nil, nil, nil, err
 	}
 
 	var (
@@ -250,25 +236,21 @@ func (s *Suite) transformAndHash(doc []byte, opts *models.ProofOptions) ([]byte,
 		return nil, nil, nil, errors.New("unsupported ECDSA curve")
 	}
 
-	confData := proofConfig(docData[ldCtxKey], opts)
-
-	if opts.ProofType != "DataIntegrityProof" || opts.SuiteType != SuiteType {
-		return nil, nil, nil, suite.ErrProofTransformation
+	if err = suiteutil.CheckProofType(s.suiteType, opts); err != nil {
+		return nil, nil, nil, err
 	}
 
-	canonDoc, err := canonicalize(docData, s.ldLoader)
+	canonDoc, err := s.canonicalize(docData)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	canonConf, err := canonicalize(confData, s.ldLoader)
+	canonConf, err := s.canonicalize(suiteutil.ProofConfig(suiteutil.DocContext(docData), s.suiteType, opts))
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	docHash := hashData(canonDoc, canonConf, h)
-
-	return docHash, vmKey, verifier, nil
+	return s.hashData(canonDoc, canonConf, h), vmKey, verifier, nil
 }
 
 // VerifyProof implements the ecdsa-2019 cryptographic suite for Verify Proof:
@@ -279,17 +261,7 @@ func (s *Suite) VerifyProof(doc []byte, proof *models.Proof, opts *models.ProofO
 		return err
 	}
 
-	_, signature, err := multibase.Decode(proof.ProofValue)
-	if err != nil {
-		return fmt.Errorf("decoding proofValue: %w", err)
-	}
-
-	err = verifier.Verify(&signatureverifier.PublicKey{JWK: vmKey}, message, signature)
-	if err != nil {
-		return fmt.Errorf("failed to verify ecdsa-2019 DI proof: %w", err)
-	}
-
-	return nil
+	return suiteutil.VerifyProofValue(s.suiteType, proof.ProofValue, message, vmKey, verifier)
 }
 
 // RequiresCreated returns false, as the ecdsa-2019 cryptographic suite does not
@@ -298,8 +270,21 @@ func (s *Suite) RequiresCreated() bool {
 	return false
 }
 
-func canonicalize(data map[string]interface{}, loader ld.DocumentLoader) ([]byte, error) {
-	out, err := processor.Default().GetCanonicalDocument(data, processor.WithDocumentLoader(loader))
+func (s *Suite) canonicalize(data map[string]interface{}) ([]byte, error) {
+	if s.canonicalization == JCS {
+		return canonicalizeJCS(data)
+	}
+
+	return suiteutil.Canonicalize(data, s.ldLoader)
+}
+
+func canonicalizeJCS(data map[string]interface{}) ([]byte, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling signature base data: %w", err)
+	}
+
+	out, err := jcs.Transform(raw)
 	if err != nil {
 		return nil, fmt.Errorf("canonicalizing signature base data: %w", err)
 	}
@@ -307,7 +292,14 @@ func canonicalize(data map[string]interface{}, loader ld.DocumentLoader) ([]byte
 	return out, nil
 }
 
-func hashData(transformedDoc, confData []byte, h hash.Hash) []byte {
+// hashData hashes the canonical document and proof configuration. The ecdsa-rdfc-2019 and
+// ecdsa-jcs-2019 proofs start with the proof configuration hash as the spec requires, while the
+// ecdsa-2019 proofs keep the document hash first to stay verifiable with the earlier releases.
+func (s *Suite) hashData(transformedDoc, confData []byte, h hash.Hash) []byte {
+	if s.suiteType != SuiteType {
+		return suiteutil.HashData(transformedDoc, confData, h)
+	}
+
 	h.Write(transformedDoc)
 	docHash := h.Sum(nil)
 
@@ -317,48 +309,3 @@ func hashData(transformedDoc, confData []byte, h hash.Hash) []byte {
 
 	return result
 }
-
-func proofConfig(docCtx interface{}, opts *models.ProofOptions) map[string]interface{} {
-	return map[string]interface{}{
-		ldCtxKey:             docCtx,
-		"type":               models.DataIntegrityProof,
-		"cryptosuite":        SuiteType,
-		"verificationMethod": opts.VerificationMethodID,
-		"created":            opts.Created.Format(models.DateTimeFormat),
-		"proofPurpose":       opts.Purpose,
-	}
-}
-
-// TODO copied from kid_creator.go, should move there: https://github.com/hyperledger/aries-framework-go/issues/3614
-func kmsKID(key *jwk.JWK) (string, error) {
-	tp, err := key.Thumbprint(crypto.SHA256)
-	if err != nil {
-		return "", fmt.Errorf("computing thumbprint for kms kid: %w", err)
-	}
-
-	return base64.RawURLEncoding.EncodeToString(tp), nil
-}
-
-type wrapSigner struct {
-	kmsSigner KMSSigner
-	kh        interface{}
-}
-
-// Sign signs using wrapped kms and key handle.
-func (s *wrapSigner) Sign(msg []byte) ([]byte, error) {
-	return s.kmsSigner.Sign(msg, s.kh)
-}
-
-func sign(sigBase []byte, key *jwk.JWK, signerGetter SignerGetter) ([]byte, error) {
-	signer, err := signerGetter(key)
-	if err != nil {
-		return nil, err
-	}
-
-	sig, err := signer.Sign(sigBase)
-	if err != nil {
-		return nil, err
-	}
-
-	return sig, nil
-}
@@ -10,8 +10,11 @@ import (
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/sha256"
 	_ "embed"
+	"encoding/hex"
 	"errors"
+	"math/big"
 	"testing"
 	"time"
 
@@ -25,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/did"
+	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	mockldstore "github.com/hyperledger/aries-framework-go/component/models/ld/mock"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/store"
@@ -36,6 +40,12 @@ var (
 	validCredential []byte
 	//go:embed testdata/invalid_jsonld.jsonld
 	invalidJSONLD []byte
+	//go:embed testdata/spec_credential.jsonld
+	specCredential []byte
+	//go:embed testdata/credentials_v2_context.jsonld
+	credentialsV2Context []byte
+	//go:embed testdata/credentials_examples_v2_context.jsonld
+	credentialsExamplesV2Context []byte
 )
 
 const (
@@ -387,6 +397,101 @@ func getP384VM(t *testing.T) *models.VerificationMethod {
 	return mockVM
 }
 
+// TestSpecVector checks the ecdsa-rdfc-2019 suite against the P-256 test vector of
+// https://www.w3.org/TR/vc-di-ecdsa/#representation-ecdsa-rdfc-2019-with-curve-p-256. ECDSA signatures
+// are randomized, so the proof is created with the key of the vector and verified. The credentials v2
+// contexts are replaced by fixtures defining the terms used by the vector.
+func TestSpecVector(t *testing.T) {
+	const (
+		publicKeyMultibase = "zDnaepBuvsQ8cpsWrVKw8fbpGpvPeNSjVPTWoq6cRqaYzBKVP"
+		secretKeyMultibase = "z42twTcNeSYcnqg1FLuSFs2bsGH3ZqbRHFmvS9XMsYhjxvHN"
+		proofConfigHash    = "3a8a522f689025727fb9d1f0fa99a618da023e8494ac74f51015d009d35abc2e"
+		documentHash       = "517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+	)
+
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider(),
+		documentloader.WithExtraContexts(
+			ldcontext.Document{URL: "https://www.w3.org/ns/credentials/v2", Content: credentialsV2Context},
+			ldcontext.Document{
+				URL:     "https://www.w3.org/ns/credentials/examples/v2",
+				Content: credentialsExamplesV2Context,
+			},
+		))
+	require.NoError(t, err)
+
+	// multicodec p256-priv and p256-pub prefixes are 2 bytes long.
+	_, secretKey, err := multibase.Decode(secretKeyMultibase)
+	require.NoError(t, err)
+
+	_, publicKey, err := multibase.Decode(publicKeyMultibase)
+	require.NoError(t, err)
+
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), publicKey[2:])
+	require.NotNil(t, x)
+
+	privateKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y},
+		D:         new(big.Int).SetBytes(secretKey[2:]),
+	}
+
+	pubJWK, err := jwksupport.JWKFromKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	controller := "did:key:" + publicKeyMultibase
+
+	vm, err := did.NewVerificationMethodFromJWK(controller+"#"+publicKeyMultibase, "JsonWebKey2020", controller, pubJWK)
+	require.NoError(t, err)
+
+	created, err := time.Parse(time.RFC3339, "2023-02-24T23:36:38Z")
+	require.NoError(t, err)
+
+	opts := &models.ProofOptions{
+		VerificationMethod:   vm,
+		VerificationMethodID: vm.ID,
+		SuiteType:            SuiteTypeRDFC,
+		Purpose:              "assertionMethod",
+		ProofType:            models.DataIntegrityProof,
+		Created:              created,
+	}
+
+	s, err := New(&Options{
+		LDDocumentLoader: docLoader,
+		P256Verifier:     signatureverifier.NewECDSAES256SignatureVerifier(),
+		SignerGetter:     WithStaticSigner(&p256Signer{key: privateKey}),
+		SuiteType:        SuiteTypeRDFC,
+	})()
+	require.NoError(t, err)
+
+	hashData, _, _, err := s.(*Suite).transformAndHash(specCredential, opts)
+	require.NoError(t, err)
+	require.Equal(t, proofConfigHash+documentHash, hex.EncodeToString(hashData))
+
+	proof, err := s.CreateProof(specCredential, opts)
+	require.NoError(t, err)
+	require.Equal(t, SuiteTypeRDFC, proof.CryptoSuite)
+
+	require.NoError(t, s.VerifyProof(specCredential, proof, opts))
+}
+
+type p256Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+func (s *p256Signer) Sign(msg []byte) ([]byte, error) {
+	digest := sha256.Sum256(msg)
+
+	r, sig, err := ecdsa.Sign(rand.Reader, s.key, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 64)
+	r.FillBytes(out[:32])
+	sig.FillBytes(out[32:])
+
+	return out, nil
+}
+
 type provider struct {
 	ContextStore        store.ContextStore
 	RemoteProviderStore store.RemoteProviderStore
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package ecdsa2019
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/did"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
@@ -110,6 +112,115 @@ func TestIntegration(t *testing.T) {
 		})
 	})
 
+	t.Run("canonicalization per suite", func(t *testing.T) {
+		jcsSignerInit := NewSignerInitializer(&SignerInitializerOptions{
+			SignerGetter:     WithLocalKMSSigner(kms, cr),
+			Canonicalization: JCS,
+		})
+		require.Equal(t, SuiteTypeJCS, jcsSignerInit.Type())
+		require.Equal(t, SuiteType, signerInit.Type())
+
+		jcsSigner, err := jcsSignerInit.Signer()
+		require.NoError(t, err)
+
+		jcsVerifierInit := NewVerifierInitializer(&VerifierInitializerOptions{
+			Canonicalization: JCS,
+		})
+		require.Equal(t, SuiteTypeJCS, jcsVerifierInit.Type())
+
+		jcsVerifier, err := jcsVerifierInit.Verifier()
+		require.NoError(t, err)
+
+		proofOpts := func(suiteType string) *models.ProofOptions {
+			return &models.ProofOptions{
+				VerificationMethod:   p256VM,
+				VerificationMethodID: p256VM.ID,
+				SuiteType:            suiteType,
+				Purpose:              "assertionMethod",
+				ProofType:            models.DataIntegrityProof,
+				Created:              time.Now(),
+				MaxAge:               100,
+			}
+		}
+
+		t.Run("JCS proof", func(t *testing.T) {
+			opts := proofOpts(SuiteTypeJCS)
+
+			proof, err := jcsSigner.CreateProof(validCredential, opts)
+			require.NoError(t, err)
+			require.Equal(t, SuiteTypeJCS, proof.CryptoSuite)
+
+			err = jcsVerifier.VerifyProof(validCredential, proof, opts)
+			require.NoError(t, err)
+
+			// the n-quads suite does not accept an ecdsa-jcs-2019 proof
+			err = verifier.VerifyProof(validCredential, proof, opts)
+			require.ErrorIs(t, err, suite.ErrProofTransformation)
+		})
+
+		t.Run("n-quads proof", func(t *testing.T) {
+			opts := proofOpts(SuiteType)
+
+			proof, err := signer.CreateProof(validCredential, opts)
+			require.NoError(t, err)
+			require.Equal(t, SuiteType, proof.CryptoSuite)
+
+			err = verifier.VerifyProof(validCredential, proof, opts)
+			require.NoError(t, err)
+
+			err = jcsVerifier.VerifyProof(validCredential, proof, opts)
+			require.ErrorIs(t, err, suite.ErrProofTransformation)
+		})
+
+		t.Run("ecdsa-rdfc-2019 proof", func(t *testing.T) {
+			rdfcSignerInit := NewSignerInitializer(&SignerInitializerOptions{
+				LDDocumentLoader: docLoader,
+				SignerGetter:     WithLocalKMSSigner(kms, cr),
+				SuiteType:        SuiteTypeRDFC,
+			})
+			require.Equal(t, SuiteTypeRDFC, rdfcSignerInit.Type())
+
+			rdfcSigner, err := rdfcSignerInit.Signer()
+			require.NoError(t, err)
+
+			rdfcVerifierInit := NewVerifierInitializer(&VerifierInitializerOptions{
+				LDDocumentLoader: docLoader,
+				SuiteType:        SuiteTypeRDFC,
+			})
+			require.Equal(t, SuiteTypeRDFC, rdfcVerifierInit.Type())
+
+			rdfcVerifier, err := rdfcVerifierInit.Verifier()
+			require.NoError(t, err)
+
+			opts := proofOpts(SuiteTypeRDFC)
+
+			proof, err := rdfcSigner.CreateProof(validCredential, opts)
+			require.NoError(t, err)
+			require.Equal(t, SuiteTypeRDFC, proof.CryptoSuite)
+
+			err = rdfcVerifier.VerifyProof(validCredential, proof, opts)
+			require.NoError(t, err)
+
+			// the ecdsa-2019 suite does not accept an ecdsa-rdfc-2019 proof
+			err = verifier.VerifyProof(validCredential, proof, opts)
+			require.ErrorIs(t, err, suite.ErrProofTransformation)
+		})
+
+		t.Run("JCS proof over a modified document", func(t *testing.T) {
+			opts := proofOpts(SuiteTypeJCS)
+
+			proof, err := jcsSigner.CreateProof(validCredential, opts)
+			require.NoError(t, err)
+
+			modified := bytes.Replace(validCredential, []byte("Example University"), []byte("Example College"), 1)
+			require.NotEqual(t, validCredential, modified)
+
+			err = jcsVerifier.VerifyProof(modified, proof, opts)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "failed to verify ecdsa-jcs-2019 DI proof")
+		})
+	})
+
 	t.Run("failure", func(t *testing.T) {
 		t.Run("wrong key", func(t *testing.T) {
 			signOpts := &models.ProofOptions{
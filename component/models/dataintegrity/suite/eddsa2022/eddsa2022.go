@@ -0,0 +1,184 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsa2022
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/internal/suiteutil"
+	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+const (
+	// SuiteType "eddsa-rdfc-2022" is the data integrity Type identifier for the suite
+	// implementing EdDSA signatures with RDF canonicalization as per this
+	// spec: https://www.w3.org/TR/vc-di-eddsa/#eddsa-rdfc-2022
+	SuiteType = "eddsa-rdfc-2022"
+)
+
+// SignerGetter returns a Signer, which must sign with the private key matching
+// the public key provided in models.ProofOptions.VerificationMethod.
+type SignerGetter = suiteutil.SignerGetter
+
+// WithStaticSigner sets the Suite to use a fixed Signer, with externally-chosen signing key.
+//
+// Use when a signing Suite is initialized for a single signature, then thrown away.
+func WithStaticSigner(signer Signer) SignerGetter {
+	return suiteutil.WithStaticSigner(signer)
+}
+
+// WithLocalKMSSigner returns a SignerGetter that will sign using the given localkms, using the private key matching
+// the given public key.
+func WithLocalKMSSigner(kms models.KeyManager, kmsSigner KMSSigner) SignerGetter {
+	return suiteutil.WithLocalKMSSigner(kms, kmsSigner)
+}
+
+// A KMSSigner is able to sign messages.
+type KMSSigner = suiteutil.KMSSigner
+
+// A Signer is able to sign messages.
+type Signer = suiteutil.Signer
+
+// A Verifier is able to verify messages.
+type Verifier = suiteutil.Verifier
+
+// Suite implements the eddsa-rdfc-2022 data integrity cryptographic suite.
+type Suite struct {
+	ldLoader     ld.DocumentLoader
+	verifier     Verifier
+	signerGetter SignerGetter
+}
+
+// Options provides initialization options for Suite.
+type Options struct {
+	LDDocumentLoader ld.DocumentLoader
+	Verifier         Verifier
+	SignerGetter     SignerGetter
+}
+
+// SuiteInitializer is the initializer for Suite.
+type SuiteInitializer func() (suite.Suite, error)
+
+// New constructs an initializer for Suite.
+func New(options *Options) SuiteInitializer {
+	return func() (suite.Suite, error) {
+		return &Suite{
+			ldLoader:     options.LDDocumentLoader,
+			verifier:     options.Verifier,
+			signerGetter: options.SignerGetter,
+		}, nil
+	}
+}
+
+// SignerInitializerOptions provides options for a SignerInitializer.
+type SignerInitializerOptions struct {
+	LDDocumentLoader ld.DocumentLoader
+	SignerGetter     SignerGetter
+}
+
+// NewSignerInitializer returns a suite.SignerInitializer that initializes an eddsa-rdfc-2022
+// signing Suite with the given SignerInitializerOptions.
+func NewSignerInitializer(options *SignerInitializerOptions) suite.SignerInitializer {
+	return suiteutil.Initializer{
+		Init: New(&Options{
+			LDDocumentLoader: options.LDDocumentLoader,
+			SignerGetter:     options.SignerGetter,
+		}),
+		SuiteType: SuiteType,
+	}
+}
+
+// VerifierInitializerOptions provides options for a VerifierInitializer.
+type VerifierInitializerOptions struct {
+	LDDocumentLoader ld.DocumentLoader
+	Verifier         Verifier // optional
+}
+
+// NewVerifierInitializer returns a suite.VerifierInitializer that initializes an
+// eddsa-rdfc-2022 verification Suite with the given VerifierInitializerOptions.
+func NewVerifierInitializer(options *VerifierInitializerOptions) suite.VerifierInitializer {
+	verifier := options.Verifier
+
+	if verifier == nil {
+		verifier = signatureverifier.NewEd25519SignatureVerifier()
+	}
+
+	return suiteutil.Initializer{
+		Init: New(&Options{
+			LDDocumentLoader: options.LDDocumentLoader,
+			Verifier:         verifier,
+		}),
+		SuiteType: SuiteType,
+	}
+}
+
+// CreateProof implements the eddsa-rdfc-2022 cryptographic suite for Add Proof:
+// https://www.w3.org/TR/vc-di-eddsa/#create-proof-eddsa-rdfc-2022
+func (s *Suite) CreateProof(doc []byte, opts *models.ProofOptions) (*models.Proof, error) {
+	hashData, vmKey, err := s.transformAndHash(doc, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := suiteutil.Sign(hashData, vmKey, s.signerGetter)
+	if err != nil {
+		return nil, err
+	}
+
+	return suiteutil.NewProof(sig, SuiteType, opts)
+}
+
+func (s *Suite) transformAndHash(doc []byte, opts *models.ProofOptions) ([]byte, *jwk.JWK, error) {
+	docData, vmKey, err := suiteutil.ParseDocument(doc, SuiteType, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if vmKey.Kty != "OKP" || vmKey.Crv != "Ed25519" {
+		return nil, nil, errors.New("unsupported EdDSA key, expected Ed25519")
+	}
+
+	if err = suiteutil.CheckProofType(SuiteType, opts); err != nil {
+		return nil, nil, err
+	}
+
+	canonDoc, err := suiteutil.Canonicalize(docData, s.ldLoader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	canonConf, err := suiteutil.Canonicalize(
+		suiteutil.ProofConfig(suiteutil.DocContext(docData), SuiteType, opts), s.ldLoader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return suiteutil.HashData(canonDoc, canonConf, sha256.New()), vmKey, nil
+}
+
+// VerifyProof implements the eddsa-rdfc-2022 cryptographic suite for Verify Proof:
+// https://www.w3.org/TR/vc-di-eddsa/#verify-proof-eddsa-rdfc-2022
+func (s *Suite) VerifyProof(doc []byte, proof *models.Proof, opts *models.ProofOptions) error {
+	message, vmKey, err := s.transformAndHash(doc, opts)
+	if err != nil {
+		return err
+	}
+
+	return suiteutil.VerifyProofValue(SuiteType, proof.ProofValue, message, vmKey, s.verifier)
+}
+
+// RequiresCreated returns false, as the eddsa-rdfc-2022 cryptographic suite does not
+// require the use of the models.Proof.Created field.
+func (s *Suite) RequiresCreated() bool {
+	return false
+}
@@ -0,0 +1,292 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package eddsa2022
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
+	mockkms "github.com/hyperledger/aries-framework-go/component/kmscrypto/mock/kms"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+	mockldstore "github.com/hyperledger/aries-framework-go/component/models/ld/mock"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/store"
+	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	mockstorage "github.com/hyperledger/aries-framework-go/component/storageutil/mock/storage"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+var (
+	//go:embed testdata/valid_credential.jsonld
+	validCredential []byte
+	//go:embed testdata/spec_credential.jsonld
+	specCredential []byte
+	//go:embed testdata/credentials_v2_context.jsonld
+	credentialsV2Context []byte
+	//go:embed testdata/credentials_examples_v2_context.jsonld
+	credentialsExamplesV2Context []byte
+)
+
+func TestSuite(t *testing.T) {
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider())
+	require.NoError(t, err)
+
+	kmsProv, err := mockkms.NewProviderForKMS(mockstorage.NewMockStoreProvider(), &noop.NoLock{})
+	require.NoError(t, err)
+
+	kms, err := localkms.New("local-lock://custom/master/key/", kmsProv)
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	signerInit := NewSignerInitializer(&SignerInitializerOptions{
+		LDDocumentLoader: docLoader,
+		SignerGetter:     WithLocalKMSSigner(kms, cr),
+	})
+	require.Equal(t, SuiteType, signerInit.Type())
+
+	signer, err := signerInit.Signer()
+	require.NoError(t, err)
+	require.False(t, signer.RequiresCreated())
+
+	verifierInit := NewVerifierInitializer(&VerifierInitializerOptions{
+		LDDocumentLoader: docLoader,
+	})
+	require.Equal(t, SuiteType, verifierInit.Type())
+
+	verifier, err := verifierInit.Verifier()
+	require.NoError(t, err)
+	require.False(t, verifier.RequiresCreated())
+
+	_, edBytes, err := kms.CreateAndExportPubKeyBytes(kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	edJWK, err := jwkkid.BuildJWK(edBytes, kmsapi.ED25519Type)
+	require.NoError(t, err)
+
+	edVM, err := did.NewVerificationMethodFromJWK("#key-1", "JsonWebKey2020", "did:foo:bar", edJWK)
+	require.NoError(t, err)
+
+	proofOptions := func(vm *models.VerificationMethod) *models.ProofOptions {
+		return &models.ProofOptions{
+			VerificationMethod:   vm,
+			VerificationMethodID: vm.ID,
+			SuiteType:            SuiteType,
+			Purpose:              "assertionMethod",
+			ProofType:            models.DataIntegrityProof,
+			Created:              time.Now(),
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		opts := proofOptions(edVM)
+
+		proof, err := signer.CreateProof(validCredential, opts)
+		require.NoError(t, err)
+		require.Equal(t, models.DataIntegrityProof, proof.Type)
+		require.Equal(t, SuiteType, proof.CryptoSuite)
+		require.Equal(t, edVM.ID, proof.VerificationMethod)
+
+		err = verifier.VerifyProof(validCredential, proof, opts)
+		require.NoError(t, err)
+	})
+
+	t.Run("modified document", func(t *testing.T) {
+		opts := proofOptions(edVM)
+
+		proof, err := signer.CreateProof(validCredential, opts)
+		require.NoError(t, err)
+
+		modified := bytes.Replace(validCredential, []byte("2010-01-01T19:23:24Z"), []byte("2011-01-01T19:23:24Z"), 1)
+		require.NotEqual(t, validCredential, modified)
+
+		err = verifier.VerifyProof(modified, proof, opts)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to verify eddsa-rdfc-2022 DI proof")
+	})
+
+	t.Run("other cryptosuite", func(t *testing.T) {
+		opts := proofOptions(edVM)
+		opts.SuiteType = "ecdsa-rdfc-2019"
+
+		_, err := signer.CreateProof(validCredential, opts)
+		require.ErrorIs(t, err, suite.ErrProofTransformation)
+
+		opts = proofOptions(edVM)
+		opts.ProofType = "Ed25519Signature2020"
+
+		err = verifier.VerifyProof(validCredential, &models.Proof{}, opts)
+		require.ErrorIs(t, err, suite.ErrProofTransformation)
+	})
+
+	t.Run("non-Ed25519 key", func(t *testing.T) {
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		p256JWK, err := jwksupport.JWKFromKey(&priv.PublicKey)
+		require.NoError(t, err)
+
+		p256VM, err := did.NewVerificationMethodFromJWK("#key-2", "JsonWebKey2020", "did:foo:bar", p256JWK)
+		require.NoError(t, err)
+
+		_, err = signer.CreateProof(validCredential, proofOptions(p256VM))
+		require.EqualError(t, err, "unsupported EdDSA key, expected Ed25519")
+	})
+
+	t.Run("verification method without JWK", func(t *testing.T) {
+		vm := did.NewVerificationMethodFromBytes("#key-3", "Ed25519VerificationKey2018", "did:foo:bar", edBytes)
+
+		_, err := signer.CreateProof(validCredential, proofOptions(vm))
+		require.EqualError(t, err, "verification method needs JWK")
+	})
+
+	t.Run("invalid JSON document", func(t *testing.T) {
+		_, err := signer.CreateProof([]byte("not JSON"), proofOptions(edVM))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "eddsa-rdfc-2022 suite expects JSON-LD payload")
+	})
+
+	t.Run("invalid proof value", func(t *testing.T) {
+		err := verifier.VerifyProof(validCredential, &models.Proof{ProofValue: "!"}, proofOptions(edVM))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decoding proofValue")
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		errSigner := NewSignerInitializer(&SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter: func(*jwk.JWK) (Signer, error) {
+				return nil, errors.New("signer not found")
+			},
+		})
+
+		s, err := errSigner.Signer()
+		require.NoError(t, err)
+
+		_, err = s.CreateProof(validCredential, proofOptions(edVM))
+		require.EqualError(t, err, "signer not found")
+	})
+}
+
+// TestSpecVector checks the suite against the eddsa-rdfc-2022 test vector of
+// https://www.w3.org/TR/vc-di-eddsa/#representation-eddsa-rdfc-2022. The credentials v2 contexts
+// are replaced by fixtures defining the terms used by the vector.
+func TestSpecVector(t *testing.T) {
+	const (
+		publicKeyMultibase = "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+		secretKeyMultibase = "z3u2en7t5LR2WtQH5PfFqMqwVHBeXouLzo6haApm8XHqvjxq"
+		proofConfigHash    = "bea7b7acfbad0126b135104024a5f1733e705108f42d59668b05c0c50004c6b0"
+		documentHash       = "517744132ae165a5349155bef0bb0cf2258fff99dfe1dbd914b938d775a36017"
+		proofValue         = "z2YwC8z3ap7yx1nZYCg4L3j3ApHsF8kgPdSb5xoS1VR7vPG3F561B52hYnQF9iseabecm3ijx4K1FBTQsCZahKZme"
+	)
+
+	docLoader, err := documentloader.NewDocumentLoader(createMockProvider(),
+		documentloader.WithExtraContexts(
+			ldcontext.Document{URL: "https://www.w3.org/ns/credentials/v2", Content: credentialsV2Context},
+			ldcontext.Document{
+				URL:     "https://www.w3.org/ns/credentials/examples/v2",
+				Content: credentialsExamplesV2Context,
+			},
+		))
+	require.NoError(t, err)
+
+	// multicodec ed25519-priv and ed25519-pub prefixes are 2 bytes long.
+	_, secretKey, err := multibase.Decode(secretKeyMultibase)
+	require.NoError(t, err)
+
+	_, publicKey, err := multibase.Decode(publicKeyMultibase)
+	require.NoError(t, err)
+
+	privateKey := ed25519.NewKeyFromSeed(secretKey[2:])
+	require.Equal(t, ed25519.PublicKey(publicKey[2:]), privateKey.Public())
+
+	pubJWK, err := jwksupport.JWKFromKey(privateKey.Public())
+	require.NoError(t, err)
+
+	controller := "did:key:" + publicKeyMultibase
+
+	vm, err := did.NewVerificationMethodFromJWK(controller+"#"+publicKeyMultibase, "JsonWebKey2020", controller, pubJWK)
+	require.NoError(t, err)
+
+	created, err := time.Parse(time.RFC3339, "2023-02-24T23:36:38Z")
+	require.NoError(t, err)
+
+	opts := &models.ProofOptions{
+		VerificationMethod:   vm,
+		VerificationMethodID: vm.ID,
+		SuiteType:            SuiteType,
+		Purpose:              "assertionMethod",
+		ProofType:            models.DataIntegrityProof,
+		Created:              created,
+	}
+
+	s, err := New(&Options{
+		LDDocumentLoader: docLoader,
+		Verifier:         signatureverifier.NewEd25519SignatureVerifier(),
+		SignerGetter:     WithStaticSigner(&ed25519Signer{key: privateKey}),
+	})()
+	require.NoError(t, err)
+
+	hashData, _, err := s.(*Suite).transformAndHash(specCredential, opts)
+	require.NoError(t, err)
+	require.Equal(t, proofConfigHash+documentHash, hex.EncodeToString(hashData))
+
+	proof, err := s.CreateProof(specCredential, opts)
+	require.NoError(t, err)
+	require.Equal(t, proofValue, proof.ProofValue)
+
+	require.NoError(t, s.VerifyProof(specCredential, &models.Proof{ProofValue: proofValue}, opts))
+}
+
+type ed25519Signer struct {
+	key ed25519.PrivateKey
+}
+
+func (s *ed25519Signer) Sign(msg []byte) ([]byte, error) {
+	return ed25519.Sign(s.key, msg), nil
+}
+
+type provider struct {
+	ContextStore        store.ContextStore
+	RemoteProviderStore store.RemoteProviderStore
+}
+
+func (p *provider) JSONLDContextStore() store.ContextStore {
+	return p.ContextStore
+}
+
+func (p *provider) JSONLDRemoteProviderStore() store.RemoteProviderStore {
+	return p.RemoteProviderStore
+}
+
+func createMockProvider() *provider {
+	return &provider{
+		ContextStore:        mockldstore.NewMockContextStore(),
+		RemoteProviderStore: mockldstore.NewMockRemoteProviderStore(),
+	}
+}
@@ -0,0 +1,244 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package suiteutil holds the code shared by the data integrity cryptographic suites.
+package suiteutil
+
+import (
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/multiformats/go-multibase"
+	"github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	signatureverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+const ldCtxKey = "@context"
+
+// SignerGetter returns a Signer, which must sign with the private key matching
+// the public key provided in models.ProofOptions.VerificationMethod.
+type SignerGetter func(pub *jwk.JWK) (Signer, error)
+
+// WithStaticSigner sets the Suite to use a fixed Signer, with externally-chosen signing key.
+func WithStaticSigner(signer Signer) SignerGetter {
+	return func(*jwk.JWK) (Signer, error) {
+		return signer, nil
+	}
+}
+
+// WithLocalKMSSigner returns a SignerGetter that will sign using the given localkms, using the private key matching
+// the given public key.
+func WithLocalKMSSigner(kms models.KeyManager, kmsSigner KMSSigner) SignerGetter {
+	return func(pub *jwk.JWK) (Signer, error) {
+		kid, err := kmsKID(pub)
+		if err != nil {
+			return nil, err
+		}
+
+		kh, err := kms.Get(kid)
+		if err != nil {
+			return nil, err
+		}
+
+		return &wrapSigner{
+			kmsSigner: kmsSigner,
+			kh:        kh,
+		}, nil
+	}
+}
+
+// A KMSSigner is able to sign messages.
+type KMSSigner interface {
+	// Sign will sign msg using a matching signature primitive in kh key handle of a private key
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	Sign(msg []byte, kh interface{}) ([]byte, error)
+}
+
+// A Signer is able to sign messages.
+type Signer interface {
+	// Sign will sign msg using a private key internal to the Signer.
+	// returns:
+	// 		signature in []byte
+	//		error in case of errors
+	Sign(msg []byte) ([]byte, error)
+}
+
+// A Verifier is able to verify messages.
+type Verifier interface {
+	// Verify will verify a signature for the given msg using a matching signature primitive in kh key handle of
+	// a public key
+	// returns:
+	// 		error in case of errors or nil if signature verification was successful
+	Verify(pubKey *signatureverifier.PublicKey, msg, signature []byte) error
+}
+
+// Initializer implements suite.SignerInitializer and suite.VerifierInitializer for a suite of type SuiteType.
+type Initializer struct {
+	Init      func() (suite.Suite, error)
+	SuiteType string
+}
+
+// Signer implements suite.SignerInitializer.
+func (i Initializer) Signer() (suite.Signer, error) {
+	return i.Init()
+}
+
+// Verifier implements suite.VerifierInitializer.
+func (i Initializer) Verifier() (suite.Verifier, error) {
+	return i.Init()
+}
+
+// Type implements suite.SignerInitializer and suite.VerifierInitializer.
+func (i Initializer) Type() string {
+	return i.SuiteType
+}
+
+// ParseDocument parses the JSON-LD document doc to be secured by a suiteType proof, and returns it
+// along with the JWK of the verification method of opts.
+func ParseDocument(doc []byte, suiteType string, opts *models.ProofOptions) (map[string]interface{}, *jwk.JWK, error) {
+	docData := make(map[string]interface{})
+
+	err := json.Unmarshal(doc, &docData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s suite expects JSON-LD payload: %w", suiteType, err)
+	}
+
+	vmKey := opts.VerificationMethod.JSONWebKey()
+	if vmKey == nil {
+		return nil, nil, errors.New("verification method needs JWK")
+	}
+
+	return docData, vmKey, nil
+}
+
+// CheckProofType returns suite.ErrProofTransformation unless opts are for a DataIntegrityProof of suiteType.
+func CheckProofType(suiteType string, opts *models.ProofOptions) error {
+	if opts.ProofType != models.DataIntegrityProof || opts.SuiteType != suiteType {
+		return suite.ErrProofTransformation
+	}
+
+	return nil
+}
+
+// ProofConfig returns the proof configuration of a suiteType proof of the document with docCtx context.
+func ProofConfig(docCtx interface{}, suiteType string, opts *models.ProofOptions) map[string]interface{} {
+	return map[string]interface{}{
+		ldCtxKey:             docCtx,
+		"type":               models.DataIntegrityProof,
+		"cryptosuite":        suiteType,
+		"verificationMethod": opts.VerificationMethodID,
+		"created":            opts.Created.Format(models.DateTimeFormat),
+		"proofPurpose":       opts.Purpose,
+	}
+}
+
+// DocContext returns the @context of the parsed document.
+func DocContext(docData map[string]interface{}) interface{} {
+	return docData[ldCtxKey]
+}
+
+// Canonicalize canonicalizes data using RDF Dataset Canonicalization.
+func Canonicalize(data map[string]interface{}, loader ld.DocumentLoader) ([]byte, error) {
+	out, err := processor.Default().GetCanonicalDocument(data, processor.WithDocumentLoader(loader))
+	if err != nil {
+		return nil, fmt.Errorf("canonicalizing signature base data: %w", err)
+	}
+
+	return out, nil
+}
+
+// HashData concatenates the hash of the canonical proof configuration and the hash of the
+// canonical document, in that order, as the data integrity cryptosuites require.
+func HashData(transformedDoc, confData []byte, h hash.Hash) []byte {
+	h.Write(confData)
+	confHash := h.Sum(nil)
+
+	h.Reset()
+	h.Write(transformedDoc)
+
+	return h.Sum(confHash)
+}
+
+// Sign signs sigBase with the Signer that signerGetter returns for key.
+func Sign(sigBase []byte, key *jwk.JWK, signerGetter SignerGetter) ([]byte, error) {
+	signer, err := signerGetter(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.Sign(sigBase)
+	if err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// NewProof returns a suiteType proof with the signature sig, made with opts.
+func NewProof(sig []byte, suiteType string, opts *models.ProofOptions) (*models.Proof, error) {
+	sigStr, err := multibase.Encode(multibase.Base58BTC, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Proof{
+		Type:               models.DataIntegrityProof,
+		CryptoSuite:        suiteType,
+		ProofPurpose:       opts.Purpose,
+		Domain:             opts.Domain,
+		Challenge:          opts.Challenge,
+		VerificationMethod: opts.VerificationMethod.ID,
+		ProofValue:         sigStr,
+		Created:            opts.Created.Format(models.DateTimeFormat),
+	}, nil
+}
+
+// VerifyProofValue verifies the multibase encoded signature of a suiteType proof over message
+// with verifier and vmKey.
+func VerifyProofValue(suiteType, proofValue string, message []byte, vmKey *jwk.JWK, verifier Verifier) error {
+	_, signature, err := multibase.Decode(proofValue)
+	if err != nil {
+		return fmt.Errorf("decoding proofValue: %w", err)
+	}
+
+	err = verifier.Verify(&signatureverifier.PublicKey{JWK: vmKey}, message, signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify %s DI proof: %w", suiteType, err)
+	}
+
+	return nil
+}
+
+// TODO copied from kid_creator.go, should move there: https://github.com/hyperledger/aries-framework-go/issues/3614
+func kmsKID(key *jwk.JWK) (string, error) {
+	tp, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("computing thumbprint for kms kid: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(tp), nil
+}
+
+type wrapSigner struct {
+	kmsSigner KMSSigner
+	kh        interface{}
+}
+
+// Sign signs using wrapped kms and key handle.
+func (s *wrapSigner) Sign(msg []byte) ([]byte, error) {
+	return s.kmsSigner.Sign(msg, s.kh)
+}
@@ -9,6 +9,7 @@ package jwt
 import (
 	"bytes"
 	"encoding/base64"
+	stdjson "encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
@@ -329,7 +330,10 @@ func PayloadToMap(i interface{}) (map[string]interface{}, error) {
 	case string:
 		b = []byte(cv)
 	default:
-		b, err = json.Marshal(i)
+		// encoding/json writes json.Number values (e.g. custom fields of a credential) as numbers and
+		// doesn't use the exponent form for integral floats, unlike the go-jose fork. The fork is still
+		// used for decoding as it matches claim names case-sensitively.
+		b, err = stdjson.Marshal(i)
 		if err != nil {
 			return nil, fmt.Errorf("marshal interface[%T]: %w", i, err)
 		}
@@ -237,6 +237,15 @@ func TestJSONWebToken_DecodeClaims(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, Claims{Issuer: "Albert"}, claims)
 
+	// claim names are matched case-sensitively
+	token = &JSONWebToken{Payload: map[string]interface{}{"ISS": "Mallory", "Exp": 1}}
+
+	claims = Claims{}
+
+	err = token.DecodeClaims(&claims)
+	require.NoError(t, err)
+	require.Equal(t, Claims{}, claims)
+
 	token, err = getJSONWebTokenWithInvalidPayload()
 	require.NoError(t, err)
 
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-jose/go-jose/v3/json"
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
@@ -27,6 +28,10 @@ const (
 
 	// signatureRS256 defines RS256 alg.
 	signatureRS256 = "RS256"
+
+	// signatureES512 and signatureES521 define the standard and the legacy name of ECDSA P-521 alg.
+	signatureES512 = "ES512"
+	signatureES521 = "ES521"
 )
 
 // KeyResolver resolves public key based on what and kid.
@@ -66,10 +71,12 @@ func NewVerifier(resolver KeyResolver) *BasicVerifier {
 
 	algVerifiers := make([]jose.AlgSignatureVerifier, 0, len(verifiers))
 	for _, v := range verifiers {
-		algVerifiers = append(algVerifiers, jose.AlgSignatureVerifier{
-			Alg:      v.Algorithm(),
-			Verifier: getVerifier(resolver, v.Verify),
-		})
+		for _, alg := range algorithms(v) {
+			algVerifiers = append(algVerifiers, jose.AlgSignatureVerifier{
+				Alg:      alg,
+				Verifier: getVerifier(resolver, v.Verify),
+			})
+		}
 	}
 
 	compositeVerifier := jose.NewCompositeAlgSigVerifier(algVerifiers[0], algVerifiers[1:]...)
@@ -108,16 +115,31 @@ func GetVerifier(publicKey *verifier.PublicKey) (*BasicVerifier, error) {
 		return nil, errors.New("unsupported key type")
 	}
 
-	compositeVerifier := jose.NewCompositeAlgSigVerifier(
-		jose.AlgSignatureVerifier{
-			Alg:      v.Algorithm(),
+	algs := algorithms(v)
+	algVerifiers := make([]jose.AlgSignatureVerifier, len(algs))
+
+	for i, alg := range algs {
+		algVerifiers[i] = jose.AlgSignatureVerifier{
+			Alg:      alg,
 			Verifier: getPublicKeyVerifier(publicKey, v),
-		},
-	)
+		}
+	}
+
+	compositeVerifier := jose.NewCompositeAlgSigVerifier(algVerifiers[0], algVerifiers[1:]...)
 
 	return &BasicVerifier{compositeVerifier: compositeVerifier}, nil
 }
 
+// algorithms returns JWS algorithms verified by v. P-521 signatures are accepted with both the standard ES512
+// and the legacy ES521 alg.
+func algorithms(v verifier.SignatureVerifier) []string {
+	if v.Algorithm() == signatureES521 {
+		return []string{signatureES512, signatureES521}
+	}
+
+	return []string{v.Algorithm()}
+}
+
 type signatureVerifier func(pubKey *verifier.PublicKey, message, signature []byte) error
 
 func getVerifier(resolver KeyResolver, signatureVerifier signatureVerifier) jose.SignatureVerifier {
@@ -132,7 +154,7 @@ func getPublicKeyVerifier(publicKey *verifier.PublicKey, v verifier.SignatureVer
 		if !ok {
 			return errors.New("'alg' JOSE header is not present")
 		}
-		if alg != v.Algorithm() {
+		if !containsAlg(algorithms(v), alg) {
 			return fmt.Errorf("alg is not %s", v.Algorithm())
 		}
 
@@ -141,14 +163,23 @@ func getPublicKeyVerifier(publicKey *verifier.PublicKey, v verifier.SignatureVer
 }
 
 func verifySignature(resolver KeyResolver, signatureVerifier signatureVerifier,
-	joseHeaders jose.Headers, _, signingInput, signature []byte) error {
+	joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
 	kid, _ := joseHeaders.KeyID()
 
-	if !strings.HasPrefix(kid, "did:") {
-		return fmt.Errorf("kid %s is not DID", kid)
+	var what, keyID string
+
+	switch {
+	case strings.HasPrefix(kid, "did:"):
+		what, keyID, _ = strings.Cut(kid, "#")
+	case isKeyURL(kid):
+		// kid is a URL of the key location (e.g. of a key registry). It is resolved along with the issuer,
+		// so that the resolver can check whether the issuer is trusted to publish keys at this location.
+		what, keyID = issuer(payload), kid
+	default:
+		return fmt.Errorf("kid %s is neither DID nor URL", kid)
 	}
 
-	pubKey, err := resolver.Resolve(strings.Split(kid, "#")[0], strings.Split(kid, "#")[1])
+	pubKey, err := resolver.Resolve(what, keyID)
 	if err != nil {
 		return err
 	}
@@ -156,6 +187,23 @@ func verifySignature(resolver KeyResolver, signatureVerifier signatureVerifier,
 	return signatureVerifier(pubKey, signingInput, signature)
 }
 
+// issuer returns "iss" claim of JWT payload or empty string if it's not present.
+func issuer(payload []byte) string {
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+
+	return claims.Issuer
+}
+
+func isKeyURL(kid string) bool {
+	return strings.HasPrefix(kid, "https://") || strings.HasPrefix(kid, "http://")
+}
+
 // Verify verifies JSON Web Token. Public key is fetched using Issuer Claim and Key ID JOSE Header.
 func (v BasicVerifier) Verify(joseHeaders jose.Headers, payload, signingInput, signature []byte) error {
 	return v.compositeVerifier.Verify(joseHeaders, payload, signingInput, signature)
@@ -196,3 +244,13 @@ func VerifyRS256(pubKey *verifier.PublicKey, message, signature []byte) error {
 
 	return rsa.VerifyPKCS1v15(pubKeyRsa, crypto.SHA256, hashed, signature)
 }
+
+func containsAlg(algs []string, alg string) bool {
+	for _, a := range algs {
+		if a == alg {
+			return true
+		}
+	}
+
+	return false
+}
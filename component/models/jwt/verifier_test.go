@@ -101,6 +101,28 @@ func TestBasicVerifier_Verify(t *testing.T) { // error corner cases
 	err = v.Verify(validHeaders, validClaims, nil, nil)
 	r.Error(err)
 	r.Contains(err.Error(), "failed to resolve public key")
+
+	// kid is neither DID nor URL
+	err = v.Verify(map[string]interface{}{"alg": "EdDSA", "kid": "key1"}, validClaims, nil, nil)
+	r.EqualError(err, "kid key1 is neither DID nor URL")
+
+	// kid is a URL of the key location
+	var resolved []string
+
+	v = NewVerifier(KeyResolverFunc(func(what, kid string) (*verifier.PublicKey, error) {
+		resolved = append(resolved, what, kid)
+
+		return nil, errors.New("failed to resolve public key")
+	}))
+
+	err = v.Verify(map[string]interface{}{"alg": "EdDSA", "kid": "https://issuer.example/keys/1"},
+		validClaims, nil, nil)
+	r.Error(err)
+
+	err = v.Verify(map[string]interface{}{"alg": "EdDSA", "kid": "https://issuer.example/jwks#key1"},
+		validClaims, nil, nil)
+	r.Error(err)
+	r.Equal([]string{"Bob", "https://issuer.example/keys/1", "Bob", "https://issuer.example/jwks#key1"}, resolved)
 }
 
 func TestVerifyEdDSA(t *testing.T) {
@@ -52,6 +52,8 @@ var (
 	ed255192020 []byte
 	//go:embed third_party/w3c-ccg.github.io/revocationList2021.jsonld
 	revocationList2021 []byte
+	//go:embed third_party/w3.org/credentials-examples_v1.jsonld
+	credentialExamples []byte
 )
 
 // Contexts contains JSON-LD contexts embedded into a Go binary.
@@ -162,3 +164,12 @@ var Contexts = []ldcontext.Document{ //nolint:gochecknoglobals
 		Content:     ed255192020,
 	},
 }
+
+// ExampleContexts contains the JSON-LD contexts of the examples of the Verifiable Credentials Data Model, embedded into
+// a Go binary. They are not preloaded by default.
+var ExampleContexts = []ldcontext.Document{ //nolint:gochecknoglobals
+	{
+		URL:     "https://www.w3.org/2018/credentials/examples/v1",
+		Content: credentialExamples,
+	},
+}
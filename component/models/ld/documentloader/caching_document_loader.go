@@ -0,0 +1,190 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package documentloader
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	jsonld "github.com/piprate/json-gold/ld"
+
+	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/context/embed"
+)
+
+// CachingDocumentLoader is an implementation of ld.DocumentLoader which memoizes documents resolved by another
+// loader, keyed by URL. It is safe for concurrent use.
+type CachingDocumentLoader struct {
+	loader     jsonld.DocumentLoader
+	ttl        time.Duration
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is the most recently used entry
+	pinned  map[string]*jsonld.RemoteDocument
+}
+
+type cacheEntry struct {
+	url     string
+	doc     *jsonld.RemoteDocument
+	expires time.Time
+}
+
+// NewCachingDocumentLoader returns a new CachingDocumentLoader resolving documents missing in the cache with loader.
+//
+// By default, cached documents never expire and the number of entries is not limited. Use WithCacheTTL() and
+// WithCacheMaxEntries() options to change this, and WithPreloadedContexts() to pre-warm the cache.
+func NewCachingDocumentLoader(loader jsonld.DocumentLoader, opts ...CachingOpts) (*CachingDocumentLoader, error) {
+	cacheOpts := &cachingLoaderOpts{}
+
+	for i := range opts {
+		opts[i](cacheOpts)
+	}
+
+	l := &CachingDocumentLoader{
+		loader:     loader,
+		ttl:        cacheOpts.ttl,
+		maxEntries: cacheOpts.maxEntries,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		pinned:     make(map[string]*jsonld.RemoteDocument),
+	}
+
+	for _, c := range cacheOpts.preloadedContexts {
+		rd, err := remoteDocument(c)
+		if err != nil {
+			return nil, fmt.Errorf("preload context %s: %w", c.URL, err)
+		}
+
+		l.pinned[c.URL] = rd
+	}
+
+	return l, nil
+}
+
+// LoadDocument returns the cached document for URL u, or resolves it with the underlying loader and caches it.
+func (l *CachingDocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	if rd, ok := l.get(u); ok {
+		return rd, nil
+	}
+
+	rd, err := l.loader.LoadDocument(u)
+	if err != nil {
+		return nil, err
+	}
+
+	l.put(u, rd)
+
+	return rd, nil
+}
+
+func (l *CachingDocumentLoader) get(u string) (*jsonld.RemoteDocument, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if rd, ok := l.pinned[u]; ok {
+		return rd, true
+	}
+
+	el, ok := l.entries[u]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*cacheEntry)
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		l.lru.Remove(el)
+		delete(l.entries, u)
+
+		return nil, false
+	}
+
+	l.lru.MoveToFront(el)
+
+	return entry.doc, true
+}
+
+func (l *CachingDocumentLoader) put(u string, rd *jsonld.RemoteDocument) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := &cacheEntry{url: u, doc: rd}
+	if l.ttl > 0 {
+		entry.expires = time.Now().Add(l.ttl)
+	}
+
+	if el, ok := l.entries[u]; ok {
+		el.Value = entry
+		l.lru.MoveToFront(el)
+
+		return
+	}
+
+	l.entries[u] = l.lru.PushFront(entry)
+
+	if l.maxEntries > 0 && l.lru.Len() > l.maxEntries {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.entries, oldest.Value.(*cacheEntry).url)
+	}
+}
+
+func remoteDocument(c ldcontext.Document) (*jsonld.RemoteDocument, error) {
+	document, err := jsonld.DocumentFromReader(bytes.NewReader(c.Content))
+	if err != nil {
+		return nil, fmt.Errorf("document from reader: %w", err)
+	}
+
+	return &jsonld.RemoteDocument{
+		DocumentURL: c.DocumentURL,
+		Document:    document,
+	}, nil
+}
+
+type cachingLoaderOpts struct {
+	ttl               time.Duration
+	maxEntries        int
+	preloadedContexts []ldcontext.Document
+}
+
+// CachingOpts configures CachingDocumentLoader during creation.
+type CachingOpts func(opts *cachingLoaderOpts)
+
+// WithCacheTTL sets how long a resolved document is kept in the cache. Zero (default) means forever.
+func WithCacheTTL(ttl time.Duration) CachingOpts {
+	return func(opts *cachingLoaderOpts) {
+		opts.ttl = ttl
+	}
+}
+
+// WithCacheMaxEntries limits the number of resolved documents kept in the cache, the least recently used one
+// is evicted first. Zero (default) means no limit.
+func WithCacheMaxEntries(n int) CachingOpts {
+	return func(opts *cachingLoaderOpts) {
+		opts.maxEntries = n
+	}
+}
+
+// WithPreloadedContexts pre-warms the cache with the given contexts. Preloaded contexts are neither expired
+// nor evicted.
+func WithPreloadedContexts(contexts ...ldcontext.Document) CachingOpts {
+	return func(opts *cachingLoaderOpts) {
+		opts.preloadedContexts = append(opts.preloadedContexts, contexts...)
+	}
+}
+
+// WithStandardContexts pre-warms the cache with the embedded contexts (`ldcontext/embed`), including the Verifiable
+// Credentials data model and examples contexts, so that the first verification doesn't pay the fetch cost.
+func WithStandardContexts() CachingOpts {
+	return WithPreloadedContexts(append(append([]ldcontext.Document{}, embed.Contexts...),
+		embed.ExampleContexts...)...)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package documentloader_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	jsonld "github.com/piprate/json-gold/ld"
+	"github.com/stretchr/testify/require"
+
+	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+)
+
+// countingLoader resolves every URL to a new document and counts the calls.
+type countingLoader struct {
+	calls int32
+	err   error
+}
+
+func (l *countingLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	atomic.AddInt32(&l.calls, 1)
+
+	if l.err != nil {
+		return nil, l.err
+	}
+
+	return &jsonld.RemoteDocument{DocumentURL: u, Document: map[string]interface{}{}}, nil
+}
+
+func (l *countingLoader) count() int {
+	return int(atomic.LoadInt32(&l.calls))
+}
+
+func TestCachingDocumentLoader(t *testing.T) {
+	t.Run("memoizes documents by URL", func(t *testing.T) {
+		loader := &countingLoader{}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader)
+		require.NoError(t, err)
+
+		rd, err := cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+
+		cached, err := cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+		require.Same(t, rd, cached)
+		require.Equal(t, 1, loader.count())
+
+		_, err = cachingLoader.LoadDocument("https://example.com/context/v2")
+		require.NoError(t, err)
+		require.Equal(t, 2, loader.count())
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		loader := &countingLoader{err: documentloader.ErrContextNotFound}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = cachingLoader.LoadDocument("https://example.com/context/v1")
+			require.True(t, errors.Is(err, documentloader.ErrContextNotFound))
+		}
+
+		require.Equal(t, 2, loader.count())
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		loader := &countingLoader{}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader,
+			documentloader.WithCacheTTL(20*time.Millisecond))
+		require.NoError(t, err)
+
+		_, err = cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+
+		_, err = cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+		require.Equal(t, 1, loader.count())
+
+		time.Sleep(30 * time.Millisecond)
+
+		_, err = cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+		require.Equal(t, 2, loader.count())
+	})
+
+	t.Run("max entries", func(t *testing.T) {
+		loader := &countingLoader{}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader,
+			documentloader.WithCacheMaxEntries(2))
+		require.NoError(t, err)
+
+		for _, u := range []string{"https://example.com/1", "https://example.com/2", "https://example.com/1",
+			"https://example.com/3"} {
+			_, err = cachingLoader.LoadDocument(u)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, 3, loader.count())
+
+		// https://example.com/2 is the least recently used one, so it is evicted
+		_, err = cachingLoader.LoadDocument("https://example.com/1")
+		require.NoError(t, err)
+		require.Equal(t, 3, loader.count())
+
+		_, err = cachingLoader.LoadDocument("https://example.com/2")
+		require.NoError(t, err)
+		require.Equal(t, 4, loader.count())
+	})
+
+	t.Run("preloaded contexts", func(t *testing.T) {
+		loader := &countingLoader{}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader,
+			documentloader.WithStandardContexts(),
+			documentloader.WithPreloadedContexts(ldcontext.Document{
+				URL:     "https://example.com/context/v1",
+				Content: []byte(sampleJSONLDContext),
+			}),
+			documentloader.WithCacheMaxEntries(1), documentloader.WithCacheTTL(time.Nanosecond))
+		require.NoError(t, err)
+
+		for _, u := range []string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+			"https://example.com/context/v1",
+		} {
+			rd, err := cachingLoader.LoadDocument(u)
+			require.NoError(t, err)
+			require.NotNil(t, rd.Document)
+		}
+
+		_, err = cachingLoader.LoadDocument("https://example.com/other/v1")
+		require.NoError(t, err)
+
+		time.Sleep(time.Millisecond)
+
+		_, err = cachingLoader.LoadDocument("https://example.com/context/v1")
+		require.NoError(t, err)
+		require.Equal(t, 1, loader.count())
+
+		_, err = documentloader.NewCachingDocumentLoader(loader, documentloader.WithPreloadedContexts(
+			ldcontext.Document{URL: "https://example.com/invalid/v1", Content: []byte("invalid")}))
+		require.ErrorContains(t, err, "preload context https://example.com/invalid/v1")
+	})
+
+	t.Run("concurrent use", func(t *testing.T) {
+		loader := &countingLoader{}
+
+		cachingLoader, err := documentloader.NewCachingDocumentLoader(loader,
+			documentloader.WithCacheMaxEntries(5), documentloader.WithCacheTTL(time.Millisecond))
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				for j := 0; j < 100; j++ {
+					_, e := cachingLoader.LoadDocument(fmt.Sprintf("https://example.com/%d", (i+j)%10))
+					require.NoError(t, e)
+				}
+			}(i)
+		}
+
+		wg.Wait()
+	})
+}
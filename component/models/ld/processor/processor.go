@@ -56,7 +56,9 @@ func WithFrameBlankNodes() Opts {
 	}
 }
 
-// WithDocumentLoader option is for passing custom JSON-LD document loader.
+// WithDocumentLoader option is for passing custom JSON-LD document loader, e.g. an in-memory loader preloaded with
+// the contexts in use. Errors of the loader, like documentloader.ErrContextNotFound, are returned by the operations
+// as is. If not defined (or nil), @context documents are fetched from the network.
 func WithDocumentLoader(loader ld.DocumentLoader) Opts {
 	return func(opts *processorOpts) {
 		opts.documentLoader = loader
@@ -433,6 +435,10 @@ func prepareOpts(opts []Opts) *processorOpts {
 		opt(procOpts)
 	}
 
+	if procOpts.documentLoader == nil {
+		procOpts.documentLoader = ld.NewDefaultDocumentLoader(nil)
+	}
+
 	return procOpts
 }
 
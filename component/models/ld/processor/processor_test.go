@@ -10,11 +10,14 @@ import (
 	_ "embed"
 	"encoding/json"
 	"log"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
 	"github.com/hyperledger/aries-framework-go/component/models/ld/testutil"
 )
@@ -563,3 +566,40 @@ var (
 	//go:embed testdata/vc_with_proper_contexts_2.jsonld
 	vcWithProperContexts2 string
 )
+
+func TestWithDocumentLoader(t *testing.T) {
+	const contextDoc = `{"@context": {"name": "http://schema.org/name"}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/ld+json")
+		_, _ = w.Write([]byte(contextDoc)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	newDoc := func() map[string]interface{} {
+		return map[string]interface{}{
+			"@context": server.URL + "/context.jsonld",
+			"@id":      "http://example.org/test#person",
+			"name":     "Jayden Doe",
+		}
+	}
+
+	t.Run("contexts are fetched from the network by default", func(t *testing.T) {
+		canonicalDoc, err := processor.Default().GetCanonicalDocument(newDoc())
+		require.NoError(t, err)
+		require.Equal(t, "<http://example.org/test#person> <http://schema.org/name> \"Jayden Doe\" .\n",
+			string(canonicalDoc))
+	})
+
+	t.Run("context not found by the loader is not fetched from the network", func(t *testing.T) {
+		loader, err := testutil.DocumentLoader()
+		require.NoError(t, err)
+
+		_, err = processor.Default().GetCanonicalDocument(newDoc(), processor.WithDocumentLoader(loader))
+		require.Error(t, err)
+		require.ErrorIs(t, err, documentloader.ErrContextNotFound)
+
+		_, err = processor.Default().Compact(newDoc(), nil, processor.WithDocumentLoader(loader))
+		require.ErrorIs(t, err, documentloader.ErrContextNotFound)
+	})
+}
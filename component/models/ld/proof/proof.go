@@ -9,6 +9,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/multiformats/go-multibase"
 
@@ -40,6 +41,10 @@ const (
 	jsonldCapabilityChain = "capabilityChain"
 
 	ed25519Signature2020 = "Ed25519Signature2020"
+	bbsBlsSignature2020  = "BbsBlsSignature2020"
+
+	// multibaseBase58BTCPrefix is the multibase prefix of base58btc encoding.
+	multibaseBase58BTCPrefix = "z"
 )
 
 // Proof is cryptographic proof of the integrity of the DID Document.
@@ -158,6 +163,17 @@ func DecodeProofValue(s, proofType string) ([]byte, error) {
 		return nil, errors.New("unsupported encoding")
 	}
 
+	// BBS+ signature starts with a compressed G1 point having the compression flag set, hence its base64 encoding
+	// starts with one of "g".."v" and never with "z", so a multibase base58btc value can't be taken for base64.
+	if proofType == bbsBlsSignature2020 && strings.HasPrefix(s, multibaseBase58BTCPrefix) {
+		_, value, err := multibase.Decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode multibase proofValue: %w", err)
+		}
+
+		return value, nil
+	}
+
 	return decodeBase64(s)
 }
 
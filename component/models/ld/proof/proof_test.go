@@ -355,6 +355,28 @@ func TestInvalidProofValue(t *testing.T) {
 	require.Contains(t, err.Error(), "signature is not defined")
 }
 
+func TestDecodeProofValue_BBS(t *testing.T) {
+	//nolint:lll
+	bbsSignatureBase64 := "uBlesrb_p6VIl-DrJ4Kj7DJ2S45uDqq6cJSgwdw_tVXWazl1XnjQxKsIzrY1RqffBqqT1oFTPi5Nwb_3IGMTWvXeGU7xwZOP8K1jybjknN0ADhp3i8JjTDeuUWH_sixv8ydcx4Qpqq-mMOX7nEm7Dg"
+
+	signature, err := base64.RawURLEncoding.DecodeString(bbsSignatureBase64)
+	require.NoError(t, err)
+
+	value, err := DecodeProofValue(bbsSignatureBase64, "BbsBlsSignature2020")
+	require.NoError(t, err)
+	require.Equal(t, signature, value)
+
+	bbsSignatureMultibase, err := multibase.Encode(multibase.Base58BTC, signature)
+	require.NoError(t, err)
+
+	value, err = DecodeProofValue(bbsSignatureMultibase, "BbsBlsSignature2020")
+	require.NoError(t, err)
+	require.Equal(t, signature, value)
+
+	_, err = DecodeProofValue("z0OIl", "BbsBlsSignature2020")
+	require.ErrorContains(t, err, "decode multibase proofValue")
+}
+
 func TestInvalidNonce(t *testing.T) {
 	p, err := NewProof(map[string]interface{}{
 		"type":       "Ed25519Signature2018",
@@ -1209,6 +1209,7 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 			SignatureRepresentation: verifiable.SignatureProofValue,
 			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
 			VerificationMethod:      "did:example:123456#key1",
+			AllowIssuerMismatch:     true,
 		}, ldprocessor.WithDocumentLoader(createTestJSONLDDocumentLoader(t))))
 
 		vp, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl,
@@ -1326,6 +1327,7 @@ func TestPresentationDefinition_CreateVP(t *testing.T) {
 			SignatureRepresentation: verifiable.SignatureProofValue,
 			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
 			VerificationMethod:      "did:example:123456#key1",
+			AllowIssuerMismatch:     true,
 		}, ldprocessor.WithDocumentLoader(createTestJSONLDDocumentLoader(t))))
 
 		vp, err := pd.CreateVP([]*verifiable.Credential{vc}, lddl,
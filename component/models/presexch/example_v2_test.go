@@ -1013,6 +1013,7 @@ func signVCWithBBS(privKey *bbs12381g2pub.PrivateKey, vc *verifiable.Credential,
 		SignatureRepresentation: verifiable.SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	err = vc.AddLinkedDataProof(ldpContext, ldprocessor.WithDocumentLoader(documentLoader))
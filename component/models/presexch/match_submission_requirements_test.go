@@ -224,6 +224,7 @@ func TestInstance_GetSubmissionRequirements(t *testing.T) {
 			SignatureRepresentation: verifiable.SignatureProofValue,
 			Suite:                   bbsblssignature2020.New(suite.WithSigner(signer)),
 			VerificationMethod:      "did:example:123456#key1",
+			AllowIssuerMismatch:     true,
 		}, ldprocessor.WithDocumentLoader(lddl)))
 
 		matched, err := pd.MatchSubmissionRequirement([]*verifiable.Credential{vc}, lddl,
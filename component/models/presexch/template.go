@@ -0,0 +1,149 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+// defaultTemplateSubjectFields is the number of credentialSubject fields picked by InputDescriptorFromCredential
+// when no fields are requested explicitly.
+const defaultTemplateSubjectFields = 2
+
+// InputDescriptorFromCredential generates an InputDescriptor matching the given sample credential, to be used as
+// a starting template when building a PresentationDefinition.
+//
+// The descriptor requires the most specific type of the credential and the presence of the given credentialSubject
+// fields. If no subjectFields are given, the first two fields of the credential subject (in lexical order,
+// excluding "id") are used.
+func InputDescriptorFromCredential(vc *verifiable.Credential, subjectFields ...string) (*InputDescriptor, error) {
+	if vc == nil {
+		return nil, errors.New("input descriptor from credential: credential is not defined")
+	}
+
+	if len(vc.Types) == 0 {
+		return nil, errors.New("input descriptor from credential: credential has no type")
+	}
+
+	subjectPath, subject, err := credentialSubjectOf(vc)
+	if err != nil {
+		return nil, fmt.Errorf("input descriptor from credential: %w", err)
+	}
+
+	if len(subjectFields) == 0 {
+		subjectFields = templateSubjectFields(subject)
+	}
+
+	vcType := vc.Types[len(vc.Types)-1]
+
+	fields := []*Field{typeField(vc.Types)}
+
+	for _, name := range subjectFields {
+		if _, ok := subject[name]; !ok {
+			return nil, fmt.Errorf("input descriptor from credential: credential subject has no field %q", name)
+		}
+
+		fields = append(fields, &Field{
+			Path: []string{subjectPath + "." + name},
+		})
+	}
+
+	return &InputDescriptor{
+		ID:   uuid.NewString(),
+		Name: vcType,
+		Constraints: &Constraints{
+			Fields: fields,
+		},
+	}, nil
+}
+
+func typeField(types []string) *Field {
+	vcType := types[len(types)-1]
+
+	if len(types) == 1 {
+		strType := "string"
+
+		return &Field{
+			Path:   []string{"$.type"},
+			Filter: &Filter{Type: &strType, Const: vcType},
+		}
+	}
+
+	arrType := "array"
+
+	return &Field{
+		Path: []string{"$.type"},
+		Filter: &Filter{
+			Type: &arrType,
+			Contains: map[string]interface{}{
+				"type":  "string",
+				"const": vcType,
+			},
+		},
+	}
+}
+
+// credentialSubjectOf returns the JSONPath of the (first) credential subject and its fields.
+func credentialSubjectOf(vc *verifiable.Credential) (string, map[string]interface{}, error) {
+	// JWT credentials marshal to the compact JWS, the JSON form is needed here.
+	vcCopy := *vc
+	vcCopy.JWT = ""
+
+	raw, err := json.Marshal(&vcCopy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var vcMap struct {
+		Subject interface{} `json:"credentialSubject"`
+	}
+
+	if err = json.Unmarshal(raw, &vcMap); err != nil {
+		return "", nil, err
+	}
+
+	switch subject := vcMap.Subject.(type) {
+	case map[string]interface{}:
+		return "$.credentialSubject", subject, nil
+	case []interface{}:
+		if len(subject) > 0 {
+			if first, ok := subject[0].(map[string]interface{}); ok {
+				return "$.credentialSubject[0]", first, nil
+			}
+		}
+	}
+
+	return "", nil, errors.New("credential subject has no fields")
+}
+
+func templateSubjectFields(subject map[string]interface{}) []string {
+	var names []string
+
+	for name := range subject {
+		if name == "id" || strings.HasPrefix(name, "_sd") {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	if len(names) > defaultTemplateSubjectFields {
+		names = names[:defaultTemplateSubjectFields]
+	}
+
+	return names
+}
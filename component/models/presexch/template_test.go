@@ -0,0 +1,92 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package presexch_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/hyperledger/aries-framework-go/component/models/presexch"
+	"github.com/hyperledger/aries-framework-go/component/models/verifiable"
+)
+
+func TestInputDescriptorFromCredential(t *testing.T) {
+	lddl := createTestJSONLDDocumentLoader(t)
+
+	degreeVC := getTestVCWithContext([]string{"https://www.w3.org/2018/credentials/examples/v1"})
+	degreeVC.Types = append(degreeVC.Types, "UniversityDegreeCredential")
+
+	t.Run("descriptor matches the credential", func(t *testing.T) {
+		desc, err := InputDescriptorFromCredential(degreeVC)
+		require.NoError(t, err)
+		require.NotEmpty(t, desc.ID)
+		require.Equal(t, "UniversityDegreeCredential", desc.Name)
+
+		require.Len(t, desc.Constraints.Fields, 3)
+		require.Equal(t, []string{"$.type"}, desc.Constraints.Fields[0].Path)
+		require.Equal(t, "UniversityDegreeCredential", desc.Constraints.Fields[0].Filter.Contains["const"])
+		require.Equal(t, []string{"$.credentialSubject.address"}, desc.Constraints.Fields[1].Path)
+		require.Equal(t, []string{"$.credentialSubject.birthdate"}, desc.Constraints.Fields[2].Path)
+
+		pd := &PresentationDefinition{
+			ID:               uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{desc},
+		}
+
+		vp, err := pd.CreateVP([]*verifiable.Credential{degreeVC}, lddl)
+		require.NoError(t, err)
+		require.Len(t, vp.Credentials(), 1)
+
+		// a credential of another type is not matched by the template
+		_, err = pd.CreateVP([]*verifiable.Credential{getTestVC()}, lddl)
+		require.EqualError(t, err, errMsgSchema)
+	})
+
+	t.Run("explicit subject fields", func(t *testing.T) {
+		desc, err := InputDescriptorFromCredential(getTestVC(), "given_name", "family_name")
+		require.NoError(t, err)
+		require.Equal(t, verifiable.VCType, desc.Name)
+
+		require.Len(t, desc.Constraints.Fields, 3)
+		require.Equal(t, verifiable.VCType, desc.Constraints.Fields[0].Filter.Const)
+		require.Equal(t, []string{"$.credentialSubject.given_name"}, desc.Constraints.Fields[1].Path)
+		require.Equal(t, []string{"$.credentialSubject.family_name"}, desc.Constraints.Fields[2].Path)
+
+		pd := &PresentationDefinition{
+			ID:               uuid.New().String(),
+			InputDescriptors: []*InputDescriptor{desc},
+		}
+
+		_, err = pd.CreateVP([]*verifiable.Credential{getTestVC()}, lddl)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown subject field", func(t *testing.T) {
+		desc, err := InputDescriptorFromCredential(getTestVC(), "nickname")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `credential subject has no field "nickname"`)
+		require.Nil(t, desc)
+	})
+
+	t.Run("credential is not defined", func(t *testing.T) {
+		desc, err := InputDescriptorFromCredential(nil)
+		require.EqualError(t, err, "input descriptor from credential: credential is not defined")
+		require.Nil(t, desc)
+	})
+
+	t.Run("credential without subject fields", func(t *testing.T) {
+		vc := getTestVC()
+		vc.Subject = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+		desc, err := InputDescriptorFromCredential(vc)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential subject has no fields")
+		require.Nil(t, desc)
+	})
+}
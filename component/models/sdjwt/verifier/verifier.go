@@ -312,6 +312,27 @@ func getDisclosedClaims(
 	return disclosedClaims, nil
 }
 
+// VerifyHolderVerification verifies the Holder Verification (Holder/Key Binding) JWT presented along with an SD-JWT,
+// using the holder public key held in the "cnf" claim of the SD-JWT.
+//
+// Unlike Parse, it does not check the SD-JWT itself: sdJWTClaims are the claims of an SD-JWT that has already been
+// verified by the caller. Holder related options (e.g. WithHolderSigningAlgorithms,
+// WithExpectedNonceForHolderVerification) apply, issuer related ones are ignored.
+func VerifyHolderVerification(sdJWTClaims map[string]interface{}, holderVerificationJWT string, opts ...ParseOpt) error {
+	defaultSigningAlgorithms := []string{"EdDSA", "RS256"}
+	pOpts := &parseOpts{
+		holderSigningAlgorithms:    defaultSigningAlgorithms,
+		leewayForClaimsValidation:  jwt.DefaultLeeway,
+		holderVerificationRequired: true,
+	}
+
+	for _, opt := range opts {
+		opt(pOpts)
+	}
+
+	return runHolderVerification(&afgjwt.JSONWebToken{Payload: sdJWTClaims}, holderVerificationJWT, pOpts)
+}
+
 func runHolderVerification(sdJWT *afgjwt.JSONWebToken, holderVerificationJWT string, pOpts *parseOpts) error {
 	if pOpts.holderVerificationRequired && holderVerificationJWT == "" {
 		return fmt.Errorf("holder verification is required")
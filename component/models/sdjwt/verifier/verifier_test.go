@@ -763,6 +763,72 @@ func TestHolderVerification(t *testing.T) {
 	}
 }
 
+func TestVerifyHolderVerification(t *testing.T) {
+	_, issuerPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPublicJWK, err := jwksupport.JWKFromKey(holderPubKey)
+	require.NoError(t, err)
+
+	token, err := issuer.New(testIssuer, map[string]interface{}{"given_name": "Albert"}, nil,
+		afjwt.NewEd25519Signer(issuerPrivateKey), issuer.WithHolderPublicKey(holderPublicJWK))
+	require.NoError(t, err)
+
+	combinedFormatForIssuance, err := token.Serialize(false)
+	require.NoError(t, err)
+
+	createPresentation := func(holderSigner afjose.Signer) *common.CombinedFormatForPresentation {
+		combinedFormatForPresentation, e := holder.CreatePresentation(combinedFormatForIssuance, nil,
+			holder.WithHolderVerification(&holder.BindingInfo{
+				Payload: holder.BindingPayload{
+					Nonce:    testNonce,
+					Audience: testAudience,
+					IssuedAt: jwt.NewNumericDate(time.Now()),
+				},
+				Signer: holderSigner,
+			}))
+		require.NoError(t, e)
+
+		return common.ParseCombinedFormatForPresentation(combinedFormatForPresentation)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		cfp := createPresentation(afjwt.NewEd25519Signer(holderPrivKey))
+
+		err = VerifyHolderVerification(token.SignedJWT.Payload, cfp.HolderVerification,
+			WithExpectedNonceForHolderVerification(testNonce))
+		require.NoError(t, err)
+	})
+
+	t.Run("holder verification signed by another key", func(t *testing.T) {
+		_, otherPrivKey, e := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, e)
+
+		cfp := createPresentation(afjwt.NewEd25519Signer(otherPrivKey))
+
+		err = VerifyHolderVerification(token.SignedJWT.Payload, cfp.HolderVerification)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse holder verification JWT")
+	})
+
+	t.Run("unexpected nonce", func(t *testing.T) {
+		cfp := createPresentation(afjwt.NewEd25519Signer(holderPrivKey))
+
+		err = VerifyHolderVerification(token.SignedJWT.Payload, cfp.HolderVerification,
+			WithExpectedNonceForHolderVerification("other nonce"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match expected nonce value")
+	})
+
+	t.Run("holder verification is missing", func(t *testing.T) {
+		err = VerifyHolderVerification(token.SignedJWT.Payload, "")
+		require.EqualError(t, err, "holder verification is required")
+	})
+}
+
 func TestGetVerifiedPayload(t *testing.T) {
 	r := require.New(t)
 
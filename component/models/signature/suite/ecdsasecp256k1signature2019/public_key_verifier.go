@@ -17,3 +17,12 @@ func NewPublicKeyVerifier() *verifier.PublicKeyVerifier {
 		verifier.NewECDSASecp256k1SignatureVerifier(),
 		verifier.WithExactPublicKeyType(jwkType))
 }
+
+// NewLowSPublicKeyVerifier creates a signature verifier as NewPublicKeyVerifier does, which accepts only
+// signatures in the low-S form, as other secp256k1 libraries do. Signatures in the high-S form, e.g. the ones
+// issued before signers normalized them, are rejected.
+func NewLowSPublicKeyVerifier() *verifier.PublicKeyVerifier {
+	return verifier.NewPublicKeyVerifier(
+		verifier.NewECDSASecp256k1LowSSignatureVerifier(),
+		verifier.WithExactPublicKeyType(jwkType))
+}
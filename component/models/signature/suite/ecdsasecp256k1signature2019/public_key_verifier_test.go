@@ -7,8 +7,10 @@ SPDX-License-Identifier: Apache-2.0
 package ecdsasecp256k1signature2019
 
 import (
+	"math/big"
 	"testing"
 
+	"github.com/btcsuite/btcd/btcec"
 	gojose "github.com/go-jose/go-jose/v3"
 	"github.com/stretchr/testify/require"
 
@@ -57,6 +59,19 @@ func TestPublicKeyVerifier_Verify(t *testing.T) {
 
 	err = v.Verify(pubKey, msg, msgSig)
 	require.NoError(t, err)
+
+	t.Run("low-S", func(t *testing.T) {
+		highSSig := make([]byte, len(msgSig))
+		copy(highSSig, msgSig[:32])
+		new(big.Int).Sub(btcec.S256().N, new(big.Int).SetBytes(msgSig[32:])).FillBytes(highSSig[32:])
+
+		require.NoError(t, NewLowSPublicKeyVerifier().Verify(pubKey, msg, msgSig))
+		require.EqualError(t, NewLowSPublicKeyVerifier().Verify(pubKey, msg, highSSig),
+			"ecdsa: signature is not low-S normalized")
+
+		// the default verifier accepts the signatures in the high-S form
+		require.NoError(t, v.Verify(pubKey, msg, highSSig))
+	})
 }
 
 func newCryptoSigner(keyType kmsapi.KeyType) (signature.Signer, error) {
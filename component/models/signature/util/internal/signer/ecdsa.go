@@ -12,6 +12,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
+	"math/big"
 
 	"github.com/btcsuite/btcd/btcec"
 )
@@ -169,6 +170,14 @@ func signEcdsa(msg []byte, privateKey *ecdsa.PrivateKey, hash crypto.Hash) ([]by
 		return nil, err
 	}
 
+	// secp256k1 signatures are expected to be in the low-S form
+	if privateKey.Curve == btcec.S256() {
+		halfOrder := new(big.Int).Rsh(privateKey.Curve.Params().N, 1)
+		if s.Cmp(halfOrder) > 0 {
+			s = new(big.Int).Sub(privateKey.Curve.Params().N, s)
+		}
+	}
+
 	curveBits := privateKey.Curve.Params().BitSize
 
 	keyBytes := curveBits / 8
@@ -12,6 +12,7 @@ import (
 	"crypto/elliptic"
 	"crypto/rand"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -133,3 +134,19 @@ func TestECDSASigner_Sign(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, signature)
 }
+
+func TestECDSASigner_Sign_Secp256k1LowS(t *testing.T) {
+	signer, err := NewECDSASecp256k1Signer()
+	require.NoError(t, err)
+
+	halfOrder := new(big.Int).Rsh(btcec.S256().N, 1)
+
+	for i := 0; i < 20; i++ {
+		signature, err := signer.Sign([]byte("test message"))
+		require.NoError(t, err)
+		require.Len(t, signature, 64)
+
+		s := new(big.Int).SetBytes(signature[32:])
+		require.True(t, s.Cmp(halfOrder) <= 0, "signature is not in low-S form")
+	}
+}
@@ -320,6 +320,10 @@ func (sv *ECDSASignatureVerifier) Verify(pubKey *PublicKey, msg, signature []byt
 		s = esig.S
 	}
 
+	if ec.lowS && s.Cmp(new(big.Int).Rsh(ec.curve.Params().N, 1)) > 0 {
+		return errors.New("ecdsa: signature is not low-S normalized")
+	}
+
 	verified := ecdsa.Verify(ecdsaPubKey, hash, r, s)
 	if !verified {
 		return errors.New("ecdsa: invalid signature")
@@ -369,6 +373,16 @@ func NewECDSASecp256k1SignatureVerifier() *ECDSASignatureVerifier {
 	}
 }
 
+// NewECDSASecp256k1LowSSignatureVerifier creates a new signature verifier that verifies a ECDSA secp256k1 signature
+// as NewECDSASecp256k1SignatureVerifier does, but accepts only signatures in the low-S form (S is at most half
+// of the curve order), so that a valid signature can't be altered to another valid one.
+func NewECDSASecp256k1LowSSignatureVerifier() *ECDSASignatureVerifier {
+	sv := NewECDSASecp256k1SignatureVerifier()
+	sv.ec.lowS = true
+
+	return sv
+}
+
 // NewECDSAES256SignatureVerifier creates a new signature verifier that verifies a ECDSA P-256 signature
 // taking public key bytes and JSON Web Key as input.
 func NewECDSAES256SignatureVerifier() *ECDSASignatureVerifier {
@@ -521,4 +535,5 @@ type ellipticCurve struct {
 	curve   elliptic.Curve
 	keySize int
 	hash    crypto.Hash
+	lowS    bool
 }
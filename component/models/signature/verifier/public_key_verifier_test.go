@@ -8,10 +8,14 @@ package verifier
 
 import (
 	"crypto"
+	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
+	"math/big"
 	"testing"
 
 	"github.com/btcsuite/btcd/btcec"
@@ -408,6 +412,51 @@ func TestNewECDSAES256SignatureVerifier(t *testing.T) {
 	})
 }
 
+func TestNewECDSASecp256k1SignatureVerifier_LowS(t *testing.T) {
+	msg := []byte("test message")
+	curve := btcec.S256()
+
+	privKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	require.NoError(t, err)
+
+	pubKey := &PublicKey{
+		Type:  "EcdsaSecp256k1VerificationKey2019",
+		Value: elliptic.Marshal(curve, privKey.X, privKey.Y),
+	}
+
+	hash := sha256.Sum256(msg)
+
+	r, s, err := ecdsa.Sign(rand.Reader, privKey, hash[:])
+	require.NoError(t, err)
+
+	halfOrder := new(big.Int).Rsh(curve.Params().N, 1)
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(curve.Params().N, s)
+	}
+
+	sigBytes := func(r, s *big.Int) []byte {
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+
+		return sig
+	}
+
+	v := NewECDSASecp256k1LowSSignatureVerifier()
+
+	require.NoError(t, v.Verify(pubKey, msg, sigBytes(r, s)))
+
+	// the high-S form is a valid ECDSA signature too, but it is rejected
+	highS := new(big.Int).Sub(curve.Params().N, s)
+	require.True(t, ecdsa.Verify(&privKey.PublicKey, hash[:], r, highS))
+
+	err = v.Verify(pubKey, msg, sigBytes(r, highS))
+	require.EqualError(t, err, "ecdsa: signature is not low-S normalized")
+
+	// the default verifier accepts both forms
+	require.NoError(t, NewECDSASecp256k1SignatureVerifier().Verify(pubKey, msg, sigBytes(r, highS)))
+}
+
 func TestTransformFromBlankNodes(t *testing.T) {
 	const (
 		a  = "<urn:bnid:_:c14n0>"
@@ -68,38 +68,71 @@ func (dv *DocumentVerifier) VerifyObject(jsonLdObject map[string]interface{}, op
 	}
 
 	for _, p := range proofs {
-		publicKeyID, err := p.PublicKeyID()
+		err = dv.verifyProof(jsonLdObject, p, opts...)
 		if err != nil {
 			return err
 		}
+	}
 
-		publicKey, err := dv.pkResolver.Resolve(publicKeyID)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		suite, err := dv.getSignatureSuite(p.Type)
-		if err != nil {
-			return err
-		}
+// ProofResult holds the result of verification of a single document proof.
+type ProofResult struct {
+	Proof *proof.Proof
+	Err   error
+}
 
-		message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
-		if err != nil {
-			return err
-		}
+// VerifyObjectProofs verifies every proof of JSON LD object independently of the others.
+// The results are returned in the order the proofs are defined in the document. An error is returned
+// only when the proofs cannot be read from the document.
+func (dv *DocumentVerifier) VerifyObjectProofs(jsonLdObject map[string]interface{},
+	opts ...processor.Opts) ([]ProofResult, error) {
+	proofs, err := proof.GetProofs(jsonLdObject)
+	if err != nil {
+		return nil, err
+	}
 
-		signature, err := getProofVerifyValue(p)
-		if err != nil {
-			return err
-		}
+	results := make([]ProofResult, len(proofs))
 
-		err = suite.Verify(publicKey, message, signature)
-		if err != nil {
-			return err
+	for i, p := range proofs {
+		results[i] = ProofResult{
+			Proof: p,
+			Err:   dv.verifyProof(jsonLdObject, p, opts...),
 		}
 	}
 
-	return nil
+	return results, nil
+}
+
+func (dv *DocumentVerifier) verifyProof(jsonLdObject map[string]interface{}, p *proof.Proof,
+	opts ...processor.Opts) error {
+	publicKeyID, err := p.PublicKeyID()
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := dv.pkResolver.Resolve(publicKeyID)
+	if err != nil {
+		return err
+	}
+
+	suite, err := dv.getSignatureSuite(p.Type)
+	if err != nil {
+		return err
+	}
+
+	message, err := proof.CreateVerifyData(suite, jsonLdObject, p, opts...)
+	if err != nil {
+		return err
+	}
+
+	signature, err := getProofVerifyValue(p)
+	if err != nil {
+		return err
+	}
+
+	return suite.Verify(publicKey, message, signature)
 }
 
 // getSignatureSuite returns signature suite based on signature type.
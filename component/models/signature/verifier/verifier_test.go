@@ -113,6 +113,53 @@ func TestVerify(t *testing.T) {
 	require.Nil(t, v)
 }
 
+func TestVerifyObjectProofs(t *testing.T) {
+	var doc map[string]interface{}
+
+	err := json.Unmarshal([]byte(validDoc), &doc)
+	require.NoError(t, err)
+
+	validProof, ok := doc["proof"].(map[string]interface{})
+	require.True(t, ok)
+
+	proofWithoutKey := make(map[string]interface{})
+	for k, v := range validProof {
+		proofWithoutKey[k] = v
+	}
+
+	delete(proofWithoutKey, "verificationMethod")
+
+	doc["proof"] = []interface{}{validProof, proofWithoutKey}
+
+	v, err := New(&testKeyResolver{
+		publicKey: &api.PublicKey{
+			Type:  kms.ED25519,
+			Value: []byte("signature"),
+		},
+	}, &testSignatureSuite{accept: true})
+	require.NoError(t, err)
+
+	t.Run("result of every proof is returned", func(t *testing.T) {
+		results, err := v.VerifyObjectProofs(doc)
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		require.NoError(t, results[0].Err)
+		require.NotNil(t, results[0].Proof)
+		require.EqualError(t, results[1].Err, "no public key ID")
+
+		// VerifyObject stops at the first failed proof.
+		require.EqualError(t, v.VerifyObject(doc), "no public key ID")
+	})
+
+	t.Run("proof not found", func(t *testing.T) {
+		results, err := v.VerifyObjectProofs(map[string]interface{}{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "proof not found")
+		require.Nil(t, results)
+	})
+}
+
 func Test_getProofVerifyValue(t *testing.T) {
 	jwsSignature := base64.RawURLEncoding.EncodeToString([]byte("signature"))
 
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 )
 
@@ -24,6 +25,16 @@ func MarshalWithCustomFields(v interface{}, cf map[string]interface{}) ([]byte,
 // UnmarshalWithCustomFields unmarshals JSON into value v and puts all JSON fields which do not belong to value
 // into custom fields map cf.
 func UnmarshalWithCustomFields(data []byte, v interface{}, cf map[string]interface{}) error {
+	return unmarshalWithCustomFields(data, v, cf, false)
+}
+
+// UnmarshalWithCustomFieldsUseNumber is UnmarshalWithCustomFields which decodes JSON numbers of custom fields
+// as json.Number, so integers survive a round trip without precision loss.
+func UnmarshalWithCustomFieldsUseNumber(data []byte, v interface{}, cf map[string]interface{}) error {
+	return unmarshalWithCustomFields(data, v, cf, true)
+}
+
+func unmarshalWithCustomFields(data []byte, v interface{}, cf map[string]interface{}, useNumber bool) error {
 	err := json.Unmarshal(data, v)
 	if err != nil {
 		return err
@@ -45,7 +56,12 @@ func UnmarshalWithCustomFields(data []byte, v interface{}, cf map[string]interfa
 	// Collect all fields map.
 	var af map[string]interface{}
 
-	err = json.Unmarshal(data, &af)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if useNumber {
+		decoder.UseNumber()
+	}
+
+	err = decoder.Decode(&af)
 	if err != nil {
 		return err
 	}
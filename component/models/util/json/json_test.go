@@ -82,6 +82,24 @@ func Test_unmarshalJSON(t *testing.T) {
 		require.Equal(t, expectedEf, cf)
 	})
 
+	t.Run("JSON numbers of custom fields only are kept as json.Number on demand", func(t *testing.T) {
+		v := &struct {
+			Claims map[string]interface{} `json:"claims"`
+		}{}
+		cf := make(map[string]interface{})
+
+		err := UnmarshalWithCustomFieldsUseNumber([]byte(`{"claims":{"n":1},"big":9007199254740993}`), v, cf)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"n": float64(1)}, v.Claims)
+		require.Equal(t, map[string]interface{}{"big": json.Number("9007199254740993")}, cf)
+
+		cf = make(map[string]interface{})
+
+		err = UnmarshalWithCustomFields([]byte(`{"claims":{"n":1},"big":1}`), v, cf)
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"big": float64(1)}, cf)
+	})
+
 	t.Run("Failed JSON unmarshalling", func(t *testing.T) {
 		cf := make(map[string]interface{})
 
@@ -11,6 +11,9 @@ import (
 	"time"
 )
 
+// dateOnlyLayout is a layout of date-only values, e.g. "2024-01-01".
+const dateOnlyLayout = "2006-01-02"
+
 // TimeWrapper overrides marshalling of time.Time. If a TimeWrapper is created from a time string, or
 // unmarshalled from JSON, it saves the string literal, which it uses when marshalling.
 // If a TimeWrapper is created using NewTime or a struct literal, it marshals with the default
@@ -128,3 +131,27 @@ func ParseTimeWrapper(timeStr string) (*TimeWrapper, error) {
 
 	return &tm, nil
 }
+
+// ParseTimeWrapperOrDateOnly parses a formatted string the same way as ParseTimeWrapper does, additionally
+// accepting date-only values (e.g. "2024-01-01") which are interpreted as midnight UTC.
+// The source string value is saved, so a date-only value is marshalled back as the date only.
+func ParseTimeWrapperOrDateOnly(timeStr string) (*TimeWrapper, error) {
+	tm, err := ParseTimeWrapper(timeStr)
+	if err == nil {
+		return tm, nil
+	}
+
+	t, dateErr := time.Parse(dateOnlyLayout, timeStr)
+	if dateErr != nil {
+		return nil, err
+	}
+
+	return &TimeWrapper{Time: t, timeStr: timeStr}, nil
+}
+
+// IsDateOnly returns true if this TimeWrapper was parsed from a date-only value (e.g. "2024-01-01").
+func (tm *TimeWrapper) IsDateOnly() bool {
+	_, err := time.Parse(dateOnlyLayout, tm.timeStr)
+
+	return err == nil
+}
@@ -94,6 +94,35 @@ func TestParse(t *testing.T) {
 	require.Nil(t, timeMsec)
 }
 
+func TestParseTimeWrapperOrDateOnly(t *testing.T) {
+	tm, err := ParseTimeWrapperOrDateOnly("2024-01-01")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), tm.Time)
+	require.True(t, tm.IsDateOnly())
+	require.Equal(t, "2024-01-01", tm.FormatToString())
+
+	tmBytes, err := json.Marshal(tm)
+	require.NoError(t, err)
+	require.Equal(t, quote("2024-01-01"), string(tmBytes))
+
+	tm, err = ParseTimeWrapperOrDateOnly("2018-03-15T00:00:00.123Z")
+	require.NoError(t, err)
+	require.False(t, tm.IsDateOnly())
+	require.Equal(t, "2018-03-15T00:00:00.123Z", tm.FormatToString())
+
+	require.False(t, NewTime(time.Now()).IsDateOnly())
+
+	// error case
+	tm, err = ParseTimeWrapperOrDateOnly("2024-13-01")
+	require.Error(t, err)
+	require.Nil(t, tm)
+
+	// date-only is not accepted by ParseTimeWrapper
+	tm, err = ParseTimeWrapper("2024-01-01")
+	require.Error(t, err)
+	require.Nil(t, tm)
+}
+
 func quote(str string) string {
 	return `"` + str + `"`
 }
@@ -15,14 +15,20 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
 
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk"
 	"github.com/hyperledger/aries-framework-go/component/models/jwt/didsignjwt"
 
 	"github.com/hyperledger/aries-framework-go/component/models/did"
@@ -60,6 +66,20 @@ const (
 	ECDSASecp521r1
 )
 
+const (
+	// ES256 JWT Algorithm (ECDSA using P-256 and SHA-256).
+	ES256 = ECDSASecp256r1
+
+	// ES384 JWT Algorithm (ECDSA using P-384 and SHA-384).
+	ES384 = ECDSASecp384r1
+
+	// ES512 JWT Algorithm (ECDSA using P-521 and SHA-512).
+	ES512 = ECDSASecp521r1
+)
+
+// legacyP521AlgName is the name of ECDSA P-521 algorithm used by earlier versions instead of "ES512".
+const legacyP521AlgName = "ES521"
+
 // KeyTypeToJWSAlgo returns the JWSAlgorithm based on keyType.
 func KeyTypeToJWSAlgo(keyType kmsapi.KeyType) (JWSAlgorithm, error) {
 	switch keyType {
@@ -98,12 +118,27 @@ func (ja JWSAlgorithm) Name() (string, error) {
 	case ECDSASecp384r1:
 		return "ES384", nil
 	case ECDSASecp521r1:
-		return "ES521", nil
+		return "ES512", nil
 	default:
 		return "", fmt.Errorf("unsupported algorithm: %v", ja)
 	}
 }
 
+// ParseJWSAlgorithm returns the JWSAlgorithm of the JOSE "alg" name, e.g. "ES256" or "RS256".
+func ParseJWSAlgorithm(name string) (JWSAlgorithm, error) {
+	for _, ja := range []JWSAlgorithm{RS256, PS256, EdDSA, ECDSASecp256k1, ES256, ES384, ES512} {
+		if jaName, _ := ja.Name(); jaName == name { //nolint:errcheck
+			return ja, nil
+		}
+	}
+
+	if name == legacyP521AlgName {
+		return ES512, nil
+	}
+
+	return 0, fmt.Errorf("unsupported algorithm: %s", name)
+}
+
 type jsonldCredentialOpts struct {
 	jsonldDocumentLoader ld.DocumentLoader
 	externalContext      []string
@@ -125,20 +160,480 @@ func SingleKey(pubKey []byte, pubKeyType string) PublicKeyFetcher {
 	}
 }
 
+// JWKSFetcher defines the case when verification keys are published as JWK Set (e.g. by OIDC issuer)
+// at jwksURL. The JWK Set is fetched once and cached, it is fetched again only when a key with requested
+// Key ID is not found in the cached set (e.g. after keys rotation), but not more often than once per refresh
+// interval (see WithJWKSRefreshInterval). A failed fetch is not retried before the refresh interval elapses
+// either, its error is returned meanwhile. If client is nil, an HTTP client with a 10 seconds timeout is used.
+func JWKSFetcher(jwksURL string, client *http.Client, opts ...JWKSFetcherOpt) PublicKeyFetcher {
+	return newJWKSFetcher(jwksURL, client, opts...).fetch
+}
+
+func newJWKSFetcher(jwksURL string, client *http.Client, opts ...JWKSFetcherOpt) *jwksFetcher {
+	if client == nil {
+		client = &http.Client{Timeout: defaultJWKSTimeout}
+	}
+
+	f := &jwksFetcher{url: jwksURL, client: client, refreshInterval: defaultJWKSRefreshInterval}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f
+}
+
+const (
+	defaultJWKSRefreshInterval = time.Minute
+	defaultJWKSTimeout         = 10 * time.Second
+)
+
+// JWKSFetcherOpt configures JWKSFetcher.
+type JWKSFetcherOpt func(f *jwksFetcher)
+
+// WithJWKSRefreshInterval sets the minimum interval between fetches of the JWK Set caused by unknown
+// Key IDs or by a failure of the previous fetch (a minute by default).
+func WithJWKSRefreshInterval(interval time.Duration) JWKSFetcherOpt {
+	return func(f *jwksFetcher) {
+		f.refreshInterval = interval
+	}
+}
+
+type jwksFetcher struct {
+	url             string
+	client          *http.Client
+	refreshInterval time.Duration
+
+	mu        sync.Mutex
+	keys      []jwk.JWK
+	fetchedAt time.Time
+	fetchErr  error
+	loading   *jwksLoad
+}
+
+// jwksLoad is a fetch of the JWK Set in progress, which concurrent requests for unknown keys wait for.
+type jwksLoad struct {
+	done chan struct{}
+	keys []jwk.JWK
+	err  error
+}
+
+type jwkSet struct {
+	Keys []jwk.JWK `json:"keys"`
+}
+
+func (f *jwksFetcher) fetch(issuerID, keyID string) (*verifier.PublicKey, error) {
+	f.mu.Lock()
+
+	if key := findJWK(f.keys, issuerID, keyID); key != nil {
+		f.mu.Unlock()
+
+		return key, nil
+	}
+
+	load := f.loading
+
+	switch {
+	case load != nil:
+		f.mu.Unlock()
+
+		<-load.done
+	case !f.fetchedAt.IsZero() && time.Since(f.fetchedAt) < f.refreshInterval:
+		fetchErr := f.fetchErr
+		f.mu.Unlock()
+
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+
+		return nil, fmt.Errorf("public key with KID %s is not found in JWKS %s", keyID, f.url)
+	default:
+		load = &jwksLoad{done: make(chan struct{})}
+		f.loading = load
+		f.mu.Unlock()
+
+		// the JWK Set is fetched without holding the lock, so that the known keys are served meanwhile
+		load.keys, load.err = loadJWKS(f.url, f.client)
+
+		f.mu.Lock()
+
+		if load.err == nil {
+			f.keys = load.keys
+		}
+
+		f.fetchedAt = time.Now()
+		f.fetchErr = load.err
+		f.loading = nil
+		f.mu.Unlock()
+
+		close(load.done)
+	}
+
+	if load.err != nil {
+		return nil, load.err
+	}
+
+	if key := findJWK(load.keys, issuerID, keyID); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("public key with KID %s is not found in JWKS %s", keyID, f.url)
+}
+
+func findJWK(keys []jwk.JWK, issuerID, keyID string) *verifier.PublicKey {
+	for i := range keys {
+		if keys[i].KeyID != keyID && keys[i].KeyID != issuerID+"#"+keyID {
+			continue
+		}
+
+		pkBytes, err := keys[i].PublicKeyBytes()
+		if err != nil {
+			logger.Warnf("skipping JWK %s: get public key bytes: %v", keys[i].KeyID, err)
+
+			continue
+		}
+
+		return &verifier.PublicKey{
+			Type:  "JsonWebKey2020",
+			Value: pkBytes,
+			JWK:   &keys[i],
+		}
+	}
+
+	return nil
+}
+
+func loadJWKS(url string, client *http.Client) ([]jwk.JWK, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("load JWKS: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("JWKS endpoint HTTP failure [%v]", resp.StatusCode)
+	}
+
+	var set jwkSet
+
+	if err = json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("decode JWKS: %w", err)
+	}
+
+	return set.Keys, nil
+}
+
+// URLKeyFetcher defines the case when the key ID is a URL of the key location, e.g. of an external key registry
+// (https://issuer.example/keys/1). Such key ID is either passed as is by JWT verification or split into the URL and
+// the fragment. The key document fetched (with HTTP GET) at the URL is either a JWK or a JWK Set, in which case the
+// key is selected by the fragment. Keys are fetched only from the origin of the issuer (if issuer ID is a URL) and
+// from the origins allowed with WithAllowedKeyOrigins. Key documents are cached by URL for a limited time
+// (see WithKeyCache). If client is nil, http.DefaultClient is used.
+func URLKeyFetcher(client *http.Client, opts ...URLKeyFetcherOpt) PublicKeyFetcher {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	f := &urlKeyFetcher{
+		client:        client,
+		cacheSize:     defaultKeyCacheSize,
+		cacheTTL:      defaultKeyCacheTTL,
+		allowedOrigin: make(map[string]bool),
+		keys:          make(map[string]cachedKeys),
+	}
+
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	return f.fetch
+}
+
+const (
+	defaultKeyCacheSize = 100
+	defaultKeyCacheTTL  = time.Hour
+)
+
+// URLKeyFetcherOpt configures URLKeyFetcher.
+type URLKeyFetcherOpt func(f *urlKeyFetcher)
+
+// WithAllowedKeyOrigins allows URLKeyFetcher to fetch keys from the given origins (e.g. https://registry.example),
+// whatever the issuer is.
+func WithAllowedKeyOrigins(origins ...string) URLKeyFetcherOpt {
+	return func(f *urlKeyFetcher) {
+		for _, origin := range origins {
+			if o := urlOrigin(origin); o != "" {
+				f.allowedOrigin[o] = true
+			}
+		}
+	}
+}
+
+// WithKeyCache sets the maximum number of key documents cached by URLKeyFetcher and how long they are
+// cached (100 documents for an hour by default).
+func WithKeyCache(size int, ttl time.Duration) URLKeyFetcherOpt {
+	return func(f *urlKeyFetcher) {
+		f.cacheSize = size
+		f.cacheTTL = ttl
+	}
+}
+
+type urlKeyFetcher struct {
+	client        *http.Client
+	allowedOrigin map[string]bool
+	cacheSize     int
+	cacheTTL      time.Duration
+
+	mu   sync.Mutex
+	keys map[string]cachedKeys
+}
+
+type cachedKeys struct {
+	keys    []jwk.JWK
+	expires time.Time
+}
+
+func (f *urlKeyFetcher) fetch(issuerID, keyID string) (*verifier.PublicKey, error) {
+	keyURL, fragment, err := keyLocation(issuerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	keyOrigin := urlOrigin(keyURL)
+	if keyOrigin == "" || (keyOrigin != urlOrigin(issuerID) && !f.allowedOrigin[keyOrigin]) {
+		return nil, fmt.Errorf("key location %s is not allowed for issuer %s", keyURL, issuerID)
+	}
+
+	keys, ok := f.cachedKeys(keyURL)
+	if !ok {
+		keys, err = loadKeyDocument(keyURL, f.client)
+		if err != nil {
+			return nil, err
+		}
+
+		f.cacheKeys(keyURL, keys)
+	}
+
+	if fragment == "" && len(keys) == 1 {
+		return jwkPublicKey(&keys[0])
+	}
+
+	if key := findJWK(keys, keyURL, fragment); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("public key with KID %s is not found at %s", fragment, keyURL)
+}
+
+func (f *urlKeyFetcher) cachedKeys(keyURL string) ([]jwk.JWK, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	cached, ok := f.keys[keyURL]
+	if !ok || time.Now().After(cached.expires) {
+		return nil, false
+	}
+
+	return cached.keys, true
+}
+
+func (f *urlKeyFetcher) cacheKeys(keyURL string, keys []jwk.JWK) {
+	if f.cacheSize <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	now := time.Now()
+
+	if _, ok := f.keys[keyURL]; !ok && len(f.keys) >= f.cacheSize {
+		// drop the expired documents, or the one which expires first if the cache is still full
+		oldest := ""
+
+		for u, cached := range f.keys {
+			if now.After(cached.expires) {
+				delete(f.keys, u)
+			} else if oldest == "" || cached.expires.Before(f.keys[oldest].expires) {
+				oldest = u
+			}
+		}
+
+		if len(f.keys) >= f.cacheSize {
+			delete(f.keys, oldest)
+		}
+	}
+
+	f.keys[keyURL] = cachedKeys{keys: keys, expires: now.Add(f.cacheTTL)}
+}
+
+// urlOrigin returns the origin (scheme://host[:port]) of the http(s) URL or empty string if s is not such URL.
+func urlOrigin(s string) string {
+	if !isKeyURL(s) {
+		return ""
+	}
+
+	u, err := url.Parse(s)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// keyLocation returns the URL of the key document and the fragment selecting the key in it.
+func keyLocation(issuerID, keyID string) (string, string, error) {
+	kid := strings.TrimPrefix(keyID, "#")
+
+	switch {
+	case isKeyURL(kid):
+		keyURL, fragment, _ := strings.Cut(kid, "#")
+
+		return keyURL, fragment, nil
+	case isKeyURL(issuerID):
+		return issuerID, kid, nil
+	default:
+		return "", "", fmt.Errorf("key ID %s of %s is not a URL", keyID, issuerID)
+	}
+}
+
+func isKeyURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+func loadKeyDocument(keyURL string, client *http.Client) ([]jwk.JWK, error) {
+	resp, err := client.Get(keyURL)
+	if err != nil {
+		return nil, fmt.Errorf("load key document: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key document endpoint HTTP failure [%v]", resp.StatusCode)
+	}
+
+	var doc json.RawMessage
+
+	if err = json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode key document: %w", err)
+	}
+
+	var set jwkSet
+
+	if err = json.Unmarshal(doc, &set); err == nil && len(set.Keys) > 0 {
+		return set.Keys, nil
+	}
+
+	var key jwk.JWK
+
+	if err = json.Unmarshal(doc, &key); err != nil {
+		return nil, fmt.Errorf("decode key document: %w", err)
+	}
+
+	return []jwk.JWK{key}, nil
+}
+
+func jwkPublicKey(key *jwk.JWK) (*verifier.PublicKey, error) {
+	pkBytes, err := key.PublicKeyBytes()
+	if err != nil {
+		return nil, fmt.Errorf("get public key bytes: %w", err)
+	}
+
+	return &verifier.PublicKey{
+		Type:  "JsonWebKey2020",
+		Value: pkBytes,
+		JWK:   key,
+	}, nil
+}
+
+const didJWKPrefix = "did:jwk:"
+
+// DIDJWKFetcher defines the case of self-issued credentials whose issuer is a did:jwk DID. Such an issuer
+// usually also embeds its key in the issuer object (publicKeyJwk); the key is not taken from there, it is
+// decoded from the DID itself, which for did:jwk is the base64url encoded JWK. The only verification method
+// of a did:jwk DID has key ID "0".
+func DIDJWKFetcher() PublicKeyFetcher {
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if !strings.HasPrefix(issuerID, didJWKPrefix) {
+			return nil, fmt.Errorf("issuer %s is not a did:jwk DID", issuerID)
+		}
+
+		if keyID != "" && strings.TrimPrefix(keyID, "#") != "0" {
+			return nil, fmt.Errorf("public key with KID %s is not found in DID %s", keyID, issuerID)
+		}
+
+		jwkBytes, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(issuerID, didJWKPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("decode did:jwk: %w", err)
+		}
+
+		var key jwk.JWK
+
+		if err = key.UnmarshalJSON(jwkBytes); err != nil {
+			return nil, fmt.Errorf("decode did:jwk: %w", err)
+		}
+
+		pkBytes, err := key.PublicKeyBytes()
+		if err != nil {
+			return nil, fmt.Errorf("decode did:jwk: get public key bytes: %w", err)
+		}
+
+		return &verifier.PublicKey{
+			Type:  "JsonWebKey2020",
+			Value: pkBytes,
+			JWK:   &key,
+		}, nil
+	}
+}
+
 // VDRKeyResolver resolves DID in order to find public keys for VC verification using vdr.Registry.
 // A source of DID could be issuer of VC or holder of VP. It can be also obtained from
 // JWS "issuer" claim or "verificationMethod" of Linked Data Proof.
 type VDRKeyResolver struct {
-	vdr didResolver
+	vdr          Resolver
+	relationship did.VerificationRelationship
+	// exactKeyID makes the key ID match the fragment of the verification method id exactly.
+	exactKeyID bool
 }
 
-type didResolver interface {
+// VDRKeyResolverOpt configures VDRKeyResolver.
+type VDRKeyResolverOpt func(r *VDRKeyResolver)
+
+// WithRequiredKeyRelationship makes VDRKeyResolver accept only the keys listed under the given verification
+// relationship of the DID document, e.g. did.AssertionMethod for strict verification of credentials, rather than
+// any key present in verificationMethod.
+func WithRequiredKeyRelationship(relationship did.VerificationRelationship) VDRKeyResolverOpt {
+	return func(r *VDRKeyResolver) {
+		r.relationship = relationship
+	}
+}
+
+// Resolver resolves a DID to its DID document, e.g. vdr.Registry.
+type Resolver interface {
 	Resolve(did string, opts ...vdrapi.DIDMethodOption) (*did.DocResolution, error)
 }
 
 // NewVDRKeyResolver creates VDRKeyResolver.
-func NewVDRKeyResolver(vdr didResolver) *VDRKeyResolver {
-	return &VDRKeyResolver{vdr: vdr}
+func NewVDRKeyResolver(vdr Resolver, opts ...VDRKeyResolverOpt) *VDRKeyResolver {
+	r := &VDRKeyResolver{vdr: vdr}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
 func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.PublicKey, error) {
@@ -149,8 +644,7 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 
 	for _, verifications := range docResolution.DIDDocument.VerificationMethods() {
 		for _, verification := range verifications {
-			if strings.Contains(verification.VerificationMethod.ID, keyID) &&
-				verification.Relationship != did.KeyAgreement {
+			if r.matchesKeyID(verification.VerificationMethod.ID, keyID) && r.acceptsRelationship(verification) {
 				return &verifier.PublicKey{
 					Type:  verification.VerificationMethod.Type,
 					Value: verification.VerificationMethod.Value,
@@ -160,14 +654,73 @@ func (r *VDRKeyResolver) resolvePublicKey(issuerDID, keyID string) (*verifier.Pu
 		}
 	}
 
+	if r.relationship != did.VerificationRelationshipGeneral {
+		return nil, fmt.Errorf("public key with KID %s is not found in %s of DID %s", keyID,
+			relationshipName(r.relationship), issuerDID)
+	}
+
 	return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerDID)
 }
 
+func relationshipName(rel did.VerificationRelationship) string {
+	switch rel {
+	case did.Authentication:
+		return "authentication"
+	case did.AssertionMethod:
+		return "assertionMethod"
+	case did.CapabilityDelegation:
+		return "capabilityDelegation"
+	case did.CapabilityInvocation:
+		return "capabilityInvocation"
+	case did.KeyAgreement:
+		return "keyAgreement"
+	default:
+		return "verificationMethod"
+	}
+}
+
+func (r *VDRKeyResolver) matchesKeyID(verificationMethodID, keyID string) bool {
+	if r.exactKeyID {
+		return idFragment(verificationMethodID) == idFragment(keyID)
+	}
+
+	return strings.Contains(verificationMethodID, keyID)
+}
+
+func (r *VDRKeyResolver) acceptsRelationship(verification did.Verification) bool {
+	if r.relationship != did.VerificationRelationshipGeneral {
+		return verification.Relationship == r.relationship
+	}
+
+	return verification.Relationship != did.KeyAgreement
+}
+
 // PublicKeyFetcher returns Public Key Fetcher via DID resolution mechanism.
 func (r *VDRKeyResolver) PublicKeyFetcher() PublicKeyFetcher {
 	return r.resolvePublicKey
 }
 
+// NewDIDResolverFetcher creates a PublicKeyFetcher which resolves the issuer DID (e.g. did:web) with resolver
+// and takes the public key from the verification method whose id fragment equals the key ID. It is a
+// VDRKeyResolver, except that the key ID must match exactly; opts such as WithRequiredKeyRelationship apply.
+// Keys represented with publicKeyBase58 or publicKeyJwk are supported.
+func NewDIDResolverFetcher(resolver Resolver, opts ...VDRKeyResolverOpt) PublicKeyFetcher {
+	r := NewVDRKeyResolver(resolver, opts...)
+	r.exactKeyID = true
+
+	return r.PublicKeyFetcher()
+}
+
+// idFragment returns the fragment of DID URL, e.g. "key-1" of "did:example:123#key-1" or "#key-1",
+// or the id itself if it has no fragment.
+func idFragment(id string) string {
+	if i := strings.LastIndex(id, "#"); i >= 0 {
+		return id[i+1:]
+	}
+
+	return id
+}
+
 // Proof defines embedded proof of Verifiable Credential.
 type Proof map[string]interface{}
 
@@ -301,11 +854,9 @@ func decodeContext(c interface{}) ([]string, []interface{}, error) {
 }
 
 func safeStringValue(v interface{}) string {
-	if v == nil {
-		return ""
-	}
+	s, _ := v.(string) //nolint:errcheck
 
-	return v.(string)
+	return s
 }
 
 func proofsToRaw(proofs []Proof) ([]byte, error) {
@@ -328,6 +879,11 @@ func parseProof(proofBytes json.RawMessage) ([]Proof, error) {
 
 	err := json.Unmarshal(proofBytes, &singleProof)
 	if err == nil {
+		if len(singleProof) == 0 {
+			// "proof": {} is treated as absent proof.
+			return nil, nil
+		}
+
 		return []Proof{singleProof}, nil
 	}
 
@@ -335,6 +891,10 @@ func parseProof(proofBytes json.RawMessage) ([]Proof, error) {
 
 	err = json.Unmarshal(proofBytes, &composedProof)
 	if err == nil {
+		if len(composedProof) == 0 {
+			return nil, nil
+		}
+
 		return composedProof, nil
 	}
 
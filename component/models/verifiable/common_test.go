@@ -6,10 +6,26 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/vdr"
 )
 
 func TestJwtAlgorithm_Name(t *testing.T) {
@@ -193,6 +209,9 @@ func Test_safeStringValue(t *testing.T) {
 
 	i = nil
 	require.Equal(t, "", safeStringValue(i))
+
+	i = 42
+	require.Equal(t, "", safeStringValue(i))
 }
 
 func Test_proofsToRaw(t *testing.T) {
@@ -219,3 +238,618 @@ func Test_proofsToRaw(t *testing.T) {
 	err = json.Unmarshal(severalProofsBytes, &severalProofsMap)
 	require.NoError(t, err)
 }
+
+func TestJWKSFetcher(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	signerJWK, err := jwksupport.PubKeyBytesToJWK(signer.PublicKeyBytes(), kms.ED25519Type)
+	require.NoError(t, err)
+
+	signerJWK.KeyID = "key1"
+
+	otherJWK, err := jwksupport.PubKeyBytesToJWK(otherSigner.PublicKeyBytes(), kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherJWK.KeyID = "key2"
+
+	var requests int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []interface{}{otherJWK, signerJWK},
+		}))
+	}))
+	defer srv.Close()
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	jws, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#key1")
+	require.NoError(t, err)
+
+	t.Run("verify JWT VC with key selected by kid", func(t *testing.T) {
+		requests = 0
+
+		fetcher := JWKSFetcher(srv.URL, srv.Client())
+
+		vcFromJWS, err := parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+
+		// JWKS is cached
+		pubKey, err := fetcher(vc.Issuer.ID, "key2")
+		require.NoError(t, err)
+		require.Equal(t, otherSigner.PublicKeyBytes(), pubKey.Value)
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("key is not found", func(t *testing.T) {
+		requests = 0
+
+		pubKey, err := JWKSFetcher(srv.URL, nil)(vc.Issuer.ID, "key3")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key with KID key3 is not found in JWKS")
+		require.Nil(t, pubKey)
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("unknown keys don't cause fetches more often than refresh interval", func(t *testing.T) {
+		requests = 0
+
+		fetcher := JWKSFetcher(srv.URL, nil)
+
+		for i := 0; i < 3; i++ {
+			_, err := fetcher(vc.Issuer.ID, "key3")
+			require.EqualError(t, err, "public key with KID key3 is not found in JWKS "+srv.URL)
+		}
+
+		require.Equal(t, 1, requests)
+
+		fetcher = JWKSFetcher(srv.URL, nil, WithJWKSRefreshInterval(0))
+
+		for i := 0; i < 3; i++ {
+			_, err := fetcher(vc.Issuer.ID, "key3")
+			require.Error(t, err)
+		}
+
+		require.Equal(t, 4, requests)
+	})
+
+	t.Run("concurrent requests share the fetch", func(t *testing.T) {
+		var concurrentRequests int32
+
+		release := make(chan struct{})
+
+		slowSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&concurrentRequests, 1)
+			<-release
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []interface{}{otherJWK, signerJWK},
+			}))
+		}))
+		defer slowSrv.Close()
+
+		fetcher := JWKSFetcher(slowSrv.URL, nil)
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				pubKey, err := fetcher(vc.Issuer.ID, "key1")
+				require.NoError(t, err)
+				require.Equal(t, signer.PublicKeyBytes(), pubKey.Value)
+			}()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), atomic.LoadInt32(&concurrentRequests))
+	})
+
+	t.Run("JWKS endpoint failure", func(t *testing.T) {
+		var failedRequests int
+
+		failSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			failedRequests++
+
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer failSrv.Close()
+
+		fetcher := JWKSFetcher(failSrv.URL, nil)
+
+		for i := 0; i < 3; i++ {
+			pubKey, err := fetcher(vc.Issuer.ID, "key1")
+			require.EqualError(t, err, "JWKS endpoint HTTP failure [404]")
+			require.Nil(t, pubKey)
+		}
+
+		// failed fetches are not retried before the refresh interval elapses
+		require.Equal(t, 1, failedRequests)
+
+		fetcher = JWKSFetcher(failSrv.URL, nil, WithJWKSRefreshInterval(0))
+
+		_, err := fetcher(vc.Issuer.ID, "key1")
+		require.Error(t, err)
+
+		_, err = fetcher(vc.Issuer.ID, "key1")
+		require.Error(t, err)
+		require.Equal(t, 3, failedRequests)
+	})
+
+	t.Run("default client has a timeout", func(t *testing.T) {
+		require.Equal(t, defaultJWKSTimeout, newJWKSFetcher(srv.URL, nil).client.Timeout)
+		require.Equal(t, srv.Client(), newJWKSFetcher(srv.URL, srv.Client()).client)
+	})
+
+	t.Run("invalid JWKS", func(t *testing.T) {
+		invalidSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, err := w.Write([]byte("not JSON"))
+			require.NoError(t, err)
+		}))
+		defer invalidSrv.Close()
+
+		pubKey, err := JWKSFetcher(invalidSrv.URL, nil)(vc.Issuer.ID, "key1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode JWKS")
+		require.Nil(t, pubKey)
+	})
+}
+
+func TestURLKeyFetcher(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	signerJWK, err := jwksupport.PubKeyBytesToJWK(signer.PublicKeyBytes(), kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherJWK, err := jwksupport.PubKeyBytesToJWK(otherSigner.PublicKeyBytes(), kms.ED25519Type)
+	require.NoError(t, err)
+
+	otherJWK.KeyID = "key2"
+
+	requests := map[string]int{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/keys/1":
+			require.NoError(t, json.NewEncoder(w).Encode(signerJWK))
+		case "/jwks":
+			signerJWKWithKID := *signerJWK
+			signerJWKWithKID.KeyID = "key1"
+
+			require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []interface{}{otherJWK, &signerJWKWithKID},
+			}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	t.Run("verify JWT VC with key document at kid URL", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, srv.URL+"/keys/1")
+		require.NoError(t, err)
+
+		fetcher := URLKeyFetcher(srv.Client(), WithAllowedKeyOrigins(srv.URL))
+
+		for i := 0; i < 2; i++ {
+			vcFromJWS, err := parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(fetcher))
+			require.NoError(t, err)
+			require.Equal(t, vc.ID, vcFromJWS.ID)
+		}
+
+		// key document is cached
+		require.Equal(t, 1, requests["/keys/1"])
+	})
+
+	t.Run("verify JWT VC with key selected by kid URL fragment", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, srv.URL+"/jwks#key1")
+		require.NoError(t, err)
+
+		vcFromJWS, err := parseTestCredential(t, []byte(jws),
+			WithPublicKeyFetcher(URLKeyFetcher(nil, WithAllowedKeyOrigins(srv.URL+"/"))))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+	})
+
+	t.Run("key location of another origin than issuer is rejected", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(EdDSA, signer, srv.URL+"/keys/1")
+		require.NoError(t, err)
+
+		requestsBefore := requests["/keys/1"]
+
+		_, err = parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(URLKeyFetcher(nil)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"key location "+srv.URL+"/keys/1 is not allowed for issuer "+vc.Issuer.ID)
+
+		_, err = URLKeyFetcher(nil, WithAllowedKeyOrigins("https://registry.example"))(
+			"https://issuer.example", srv.URL+"/keys/1")
+		require.EqualError(t, err,
+			"key location "+srv.URL+"/keys/1 is not allowed for issuer https://issuer.example")
+
+		// no request is made
+		require.Equal(t, requestsBefore, requests["/keys/1"])
+	})
+
+	t.Run("key location of issuer origin", func(t *testing.T) {
+		pubKey, err := URLKeyFetcher(nil)(srv.URL+"/issuers/1", srv.URL+"/keys/1")
+		require.NoError(t, err)
+		require.Equal(t, signer.PublicKeyBytes(), pubKey.Value)
+	})
+
+	t.Run("key URL passed as key ID", func(t *testing.T) {
+		pubKey, err := URLKeyFetcher(nil, WithAllowedKeyOrigins(srv.URL))(vc.Issuer.ID, "#"+srv.URL+"/jwks#key2")
+		require.NoError(t, err)
+		require.Equal(t, otherSigner.PublicKeyBytes(), pubKey.Value)
+	})
+
+	t.Run("cached key documents expire and are evicted", func(t *testing.T) {
+		fetcher := URLKeyFetcher(nil, WithAllowedKeyOrigins(srv.URL), WithKeyCache(1, time.Hour))
+		requestsBefore := requests["/keys/1"]
+
+		for _, kid := range []string{"/keys/1", "/jwks#key1", "/keys/1"} {
+			_, err := fetcher(vc.Issuer.ID, srv.URL+kid)
+			require.NoError(t, err)
+		}
+
+		// the only cache entry was taken by /jwks
+		require.Equal(t, requestsBefore+2, requests["/keys/1"])
+
+		fetcher = URLKeyFetcher(nil, WithAllowedKeyOrigins(srv.URL), WithKeyCache(10, -time.Second))
+
+		for i := 0; i < 2; i++ {
+			_, err := fetcher(vc.Issuer.ID, srv.URL+"/keys/1")
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, requestsBefore+4, requests["/keys/1"])
+	})
+
+	t.Run("key is not found", func(t *testing.T) {
+		pubKey, err := URLKeyFetcher(nil)(srv.URL+"/jwks", "key3")
+		require.EqualError(t, err, "public key with KID key3 is not found at "+srv.URL+"/jwks")
+		require.Nil(t, pubKey)
+	})
+
+	t.Run("key ID is not a URL", func(t *testing.T) {
+		pubKey, err := URLKeyFetcher(nil)(vc.Issuer.ID, "#key1")
+		require.EqualError(t, err, "key ID #key1 of "+vc.Issuer.ID+" is not a URL")
+		require.Nil(t, pubKey)
+	})
+
+	t.Run("key document endpoint failure", func(t *testing.T) {
+		pubKey, err := URLKeyFetcher(nil)(srv.URL+"/keys/2", "")
+		require.EqualError(t, err, "key document endpoint HTTP failure [404]")
+		require.Nil(t, pubKey)
+	})
+}
+
+func TestDIDJWKFetcher(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	signerJWK, err := jwksupport.PubKeyBytesToJWK(signer.PublicKeyBytes(), kms.ED25519Type)
+	require.NoError(t, err)
+
+	jwkBytes, err := signerJWK.MarshalJSON()
+	require.NoError(t, err)
+
+	issuerDID := "did:jwk:" + base64.RawURLEncoding.EncodeToString(jwkBytes)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	// self-issued credential embedding the issuer key
+	vc.Issuer = Issuer{
+		ID:           issuerDID,
+		CustomFields: CustomFields{"publicKeyJwk": signerJWK},
+	}
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	jws, err := jwtClaims.MarshalJWS(EdDSA, signer, issuerDID+"#0")
+	require.NoError(t, err)
+
+	t.Run("verify did:jwk self-issued credential", func(t *testing.T) {
+		vcParsed, err := parseTestCredential(t, []byte(jws), WithPublicKeyFetcher(DIDJWKFetcher()))
+		require.NoError(t, err)
+		require.Equal(t, issuerDID, vcParsed.Issuer.ID)
+	})
+
+	t.Run("credential signed by another key", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		otherJWS, err := jwtClaims.MarshalJWS(EdDSA, otherSigner, issuerDID+"#0")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(otherJWS), WithPublicKeyFetcher(DIDJWKFetcher()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid signature")
+	})
+
+	t.Run("not a did:jwk issuer", func(t *testing.T) {
+		pubKey, err := DIDJWKFetcher()("did:example:76e12ec712ebc6f1c221ebfeb1f", "0")
+		require.EqualError(t, err, "issuer did:example:76e12ec712ebc6f1c221ebfeb1f is not a did:jwk DID")
+		require.Nil(t, pubKey)
+	})
+
+	t.Run("unknown key ID", func(t *testing.T) {
+		pubKey, err := DIDJWKFetcher()(issuerDID, "#1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "public key with KID #1 is not found")
+		require.Nil(t, pubKey)
+	})
+
+	t.Run("invalid did:jwk", func(t *testing.T) {
+		pubKey, err := DIDJWKFetcher()("did:jwk:"+base64.RawURLEncoding.EncodeToString([]byte("{}")), "0")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode did:jwk")
+		require.Nil(t, pubKey)
+
+		pubKey, err = DIDJWKFetcher()("did:jwk:!!!", "0")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode did:jwk")
+		require.Nil(t, pubKey)
+	})
+}
+
+type recordingResolver struct {
+	didDoc   *did.Doc
+	resolved []string
+}
+
+func (r *recordingResolver) Resolve(didID string, _ ...vdr.DIDMethodOption) (*did.DocResolution, error) {
+	r.resolved = append(r.resolved, didID)
+
+	return &did.DocResolution{DIDDocument: r.didDoc}, nil
+}
+
+func TestVDRKeyResolver_PathBasedDIDWeb(t *testing.T) {
+	const issuer = "did:web:example.com:users:alice"
+
+	vm := did.NewVerificationMethodFromBytes(issuer+"#key-1", "Ed25519VerificationKey2018", issuer,
+		[]byte("public key"))
+
+	resolver := &recordingResolver{didDoc: &did.Doc{
+		Context:            []string{did.ContextV1},
+		ID:                 issuer,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+	}}
+
+	adapter := &keyResolverAdapter{pubKeyFetcher: NewVDRKeyResolver(resolver).PublicKeyFetcher()}
+
+	pubKey, err := adapter.Resolve(issuer + "#key-1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("public key"), pubKey.Value)
+	require.Equal(t, []string{issuer}, resolver.resolved)
+}
+
+func TestVDRKeyResolver_WithRequiredKeyRelationship(t *testing.T) {
+	const issuer = "did:example:76e12ec712ebc6f1c221ebfeb1f"
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes(issuer+"#key-1", "Ed25519VerificationKey2018", issuer,
+		signer.PublicKeyBytes())
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	vcJWT, err := jwtClaims.MarshalJWS(EdDSA, signer, issuer+"#key-1")
+	require.NoError(t, err)
+
+	t.Run("key is in assertionMethod", func(t *testing.T) {
+		resolver := &recordingResolver{didDoc: &did.Doc{
+			ID:                 issuer,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+		}}
+
+		keyResolver := NewVDRKeyResolver(resolver, WithRequiredKeyRelationship(did.AssertionMethod))
+
+		_, err := parseTestCredential(t, []byte(vcJWT), WithPublicKeyFetcher(keyResolver.PublicKeyFetcher()))
+		require.NoError(t, err)
+	})
+
+	t.Run("key exists but is not in assertionMethod", func(t *testing.T) {
+		resolver := &recordingResolver{didDoc: &did.Doc{
+			ID:                 issuer,
+			VerificationMethod: []did.VerificationMethod{*vm},
+			Authentication:     []did.Verification{*did.NewReferencedVerification(vm, did.Authentication)},
+		}}
+
+		// any key except key agreement is accepted by default
+		_, err := parseTestCredential(t, []byte(vcJWT),
+			WithPublicKeyFetcher(NewVDRKeyResolver(resolver).PublicKeyFetcher()))
+		require.NoError(t, err)
+
+		keyResolver := NewVDRKeyResolver(resolver, WithRequiredKeyRelationship(did.AssertionMethod))
+
+		_, err = keyResolver.PublicKeyFetcher()(issuer, "key-1")
+		require.EqualError(t, err, "public key with KID key-1 is not found in assertionMethod of DID "+issuer)
+
+		_, err = parseTestCredential(t, []byte(vcJWT), WithPublicKeyFetcher(keyResolver.PublicKeyFetcher()))
+		require.ErrorContains(t, err, "public key with KID key-1 is not found in assertionMethod")
+	})
+}
+
+func TestVDRKeyResolver_RelativeVerificationMethod(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   sigSuite,
+		VerificationMethod:      "#key-1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t))))
+
+	vcBytes, err := vc.MarshalJSON()
+	require.NoError(t, err)
+
+	vm := did.NewVerificationMethodFromBytes(vc.Issuer.ID+"#key-1", "Ed25519VerificationKey2018", vc.Issuer.ID,
+		signer.PublicKeyBytes())
+
+	resolver := &recordingResolver{didDoc: &did.Doc{
+		ID:                 vc.Issuer.ID,
+		VerificationMethod: []did.VerificationMethod{*vm},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(vm, did.AssertionMethod)},
+	}}
+
+	_, err = parseTestCredential(t, vcBytes,
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(NewVDRKeyResolver(resolver).PublicKeyFetcher()))
+	require.NoError(t, err)
+	require.Equal(t, []string{vc.Issuer.ID}, resolver.resolved)
+}
+
+func TestNewDIDResolverFetcher(t *testing.T) {
+	const issuer = "did:web:example.com"
+
+	edSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ecSigner, err := newCryptoSigner(kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	ecJWK, err := jwksupport.PubKeyBytesToJWK(ecSigner.PublicKeyBytes(), kms.ECDSAP256TypeIEEEP1363)
+	require.NoError(t, err)
+
+	ecJWKBytes, err := ecJWK.MarshalJSON()
+	require.NoError(t, err)
+
+	didDoc, err := did.ParseDocument([]byte(`{
+  "@context": ["https://www.w3.org/ns/did/v1"],
+  "id": "` + issuer + `",
+  "verificationMethod": [
+    {
+      "id": "` + issuer + `#key-1",
+      "type": "Ed25519VerificationKey2018",
+      "controller": "` + issuer + `",
+      "publicKeyBase58": "` + base58.Encode(edSigner.PublicKeyBytes()) + `"
+    },
+    {
+      "id": "#key-2",
+      "type": "JsonWebKey2020",
+      "controller": "` + issuer + `",
+      "publicKeyJwk": ` + string(ecJWKBytes) + `
+    }
+  ],
+  "assertionMethod": ["` + issuer + `#key-1", "` + issuer + `#key-2"]
+}`))
+	require.NoError(t, err)
+
+	fetcher := NewDIDResolverFetcher(&mockResolver{didDoc: didDoc})
+
+	t.Run("publicKeyBase58", func(t *testing.T) {
+		pubKey, err := fetcher(issuer, "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, "Ed25519VerificationKey2018", pubKey.Type)
+		require.Equal(t, edSigner.PublicKeyBytes(), pubKey.Value)
+		require.Nil(t, pubKey.JWK)
+	})
+
+	t.Run("publicKeyJwk", func(t *testing.T) {
+		pubKey, err := fetcher(issuer, "key-2")
+		require.NoError(t, err)
+		require.Equal(t, "JsonWebKey2020", pubKey.Type)
+		require.NotNil(t, pubKey.JWK)
+		require.Equal(t, ecSigner.PublicKeyBytes(), pubKey.Value)
+	})
+
+	t.Run("verify JWT credentials", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = issuer
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		edJWT, err := jwtClaims.MarshalJWS(EdDSA, edSigner, issuer+"#key-1")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(edJWT), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		ecJWT, err := jwtClaims.MarshalJWS(ES256, ecSigner, issuer+"#key-2")
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(ecJWT), WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+	})
+
+	t.Run("key ID is not found", func(t *testing.T) {
+		// the key ID must match the fragment exactly
+		_, err := fetcher(issuer, "key")
+		require.EqualError(t, err, "public key with KID key is not found for DID "+issuer)
+
+		_, err = fetcher(issuer, "#key-3")
+		require.EqualError(t, err, "public key with KID #key-3 is not found for DID "+issuer)
+	})
+
+	t.Run("required key relationship", func(t *testing.T) {
+		pubKey, err := NewDIDResolverFetcher(&mockResolver{didDoc: didDoc},
+			WithRequiredKeyRelationship(did.AssertionMethod))(issuer, "#key-1")
+		require.NoError(t, err)
+		require.Equal(t, edSigner.PublicKeyBytes(), pubKey.Value)
+
+		_, err = NewDIDResolverFetcher(&mockResolver{didDoc: didDoc},
+			WithRequiredKeyRelationship(did.Authentication))(issuer, "#key-1")
+		require.EqualError(t, err, "public key with KID #key-1 is not found in authentication of DID "+issuer)
+	})
+
+	t.Run("resolve error", func(t *testing.T) {
+		_, err := NewDIDResolverFetcher(&failingResolver{})(issuer, "key-1")
+		require.EqualError(t, err, "resolve DID "+issuer+": DID not found")
+	})
+}
+
+type failingResolver struct{}
+
+func (r *failingResolver) Resolve(string, ...vdr.DIDMethodOption) (*did.DocResolution, error) {
+	return nil, errors.New("DID not found")
+}
@@ -247,6 +247,9 @@ const (
 
 	// https://www.w3.org/TR/vc-data-model/#presentations-0
 	vpType = "VerifiablePresentation"
+
+	// https://w3c-ccg.github.io/ldp-bbs2020/#the-bbs-signature-suite-2020
+	bbsContext = "https://w3id.org/security/bbs/v1"
 )
 
 // vcModelValidationMode defines constraint put on context and type of VC.
@@ -454,6 +457,43 @@ func (i *Issuer) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// NameForLang returns the issuer name in the given language.
+//
+// The "name" custom field of the issuer may be a plain string, a language-tagged value object
+// (e.g. {"@value": "Example University", "@language": "en"}) or an array of those. The value tagged with lang
+// (compared case-insensitively) is returned; otherwise a value without a language tag is used, if any.
+// An empty string is returned if the issuer has no name in the given language.
+func (i *Issuer) NameForLang(lang string) string {
+	values, ok := i.CustomFields["name"].([]interface{})
+	if !ok {
+		values = []interface{}{i.CustomFields["name"]}
+	}
+
+	var untagged string
+
+	for _, v := range values {
+		switch name := v.(type) {
+		case string:
+			if untagged == "" {
+				untagged = name
+			}
+		case map[string]interface{}:
+			value, _ := name["@value"].(string)
+			valueLang, _ := name["@language"].(string)
+
+			if valueLang == "" && untagged == "" {
+				untagged = value
+			}
+
+			if valueLang != "" && strings.EqualFold(valueLang, lang) {
+				return value
+			}
+		}
+	}
+
+	return untagged
+}
+
 // Subject of the Verifiable Credential.
 type Subject struct {
 	ID string `json:"id,omitempty"`
@@ -510,6 +550,7 @@ type Credential struct {
 	Issuer         Issuer
 	Issued         *util.TimeWrapper
 	Expired        *util.TimeWrapper
+	ValidFrom      *util.TimeWrapper
 	Proofs         []Proof
 	Status         *TypedID
 	Schemas        []TypedID
@@ -524,6 +565,9 @@ type Credential struct {
 	SDHolderBinding  string
 
 	CustomFields CustomFields
+
+	// subjectArray keeps a single credentialSubject decoded from JSON array marshalled as array.
+	subjectArray bool
 }
 
 // rawCredential is a basic verifiable credential.
@@ -566,7 +610,8 @@ func (rc *rawCredential) UnmarshalJSON(data []byte) error {
 	alias := (*Alias)(rc)
 	rc.CustomFields = make(CustomFields)
 
-	err := jsonutil.UnmarshalWithCustomFields(data, alias, rc.CustomFields)
+	// JSON numbers of the credential custom fields are kept as json.Number not to lose precision.
+	err := jsonutil.UnmarshalWithCustomFieldsUseNumber(data, alias, rc.CustomFields)
 	if err != nil {
 		return err
 	}
@@ -583,18 +628,42 @@ type CredentialTemplate func() *Credential
 
 // credentialOpts holds options for the Verifiable Credential decoding.
 type credentialOpts struct {
-	publicKeyFetcher      PublicKeyFetcher
-	disabledCustomSchema  bool
-	schemaLoader          *CredentialSchemaLoader
-	modelValidationMode   vcModelValidationMode
-	allowedCustomContexts map[string]bool
-	allowedCustomTypes    map[string]bool
-	disabledProofCheck    bool
-	strictValidation      bool
-	ldpSuites             []verifier.SignatureSuite
-	defaultSchema         string
-	disableValidation     bool
-	verifyDataIntegrity   *verifyDataIntegrityOpts
+	publicKeyFetcher        PublicKeyFetcher
+	disabledCustomSchema    bool
+	schemaLoader            *CredentialSchemaLoader
+	modelValidationMode     vcModelValidationMode
+	allowedCustomContexts   map[string]bool
+	allowedCustomTypes      map[string]bool
+	disabledProofCheck      bool
+	strictValidation        bool
+	ldpSuites               []verifier.SignatureSuite
+	defaultSchema           string
+	disableValidation       bool
+	dateOnlyValidFrom       bool
+	rejectUnknownJWTClaims  bool
+	strictJWTExpiration     bool
+	jwtExpirationSkew       time.Duration
+	strictJWTDates          bool
+	jwtDatesSkew            time.Duration
+	suiteRegistry           *SuiteRegistry
+	subjectDecrypter        jose.Decrypter
+	contextSchemaValidation bool
+	subjectSchemaLoader     SchemaDocumentLoader
+	minAssurance            *minAssuranceOpts
+	canonicalContexts       bool
+	requireStatus           bool
+	expectedChallenge       string
+	expectedProofPurpose    string
+	expectedBBSNonce        []byte
+	rejectFutureProofs      bool
+	validityTime            *time.Time
+	confidenceMethodChecker ConfidenceMethodChecker
+	futureProofSkew         time.Duration
+	proofPurposeResolver    Resolver
+	verifyDataIntegrity     *verifyDataIntegrityOpts
+	sdJWTHolderBinding      bool
+	proofVerificationMode   ProofVerificationMode
+	unknownProofPolicy      UnknownProofPolicy
 
 	jsonldCredentialOpts
 }
@@ -616,6 +685,96 @@ func WithCredDisableValidation() CredentialOpt {
 	}
 }
 
+// WithDateOnlyValidFrom makes Credential.ValidFrom be filled also from a validFrom defined as a date only
+// (e.g. "2024-01-01"), as permitted by VC Data Model 2.0. Such value is interpreted as midnight UTC.
+// By default, only RFC3339 validFrom is interpreted. A validFrom which is not interpreted is kept
+// in the custom fields as is.
+func WithDateOnlyValidFrom() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.dateOnlyValidFrom = true
+	}
+}
+
+// WithRejectUnknownJWTClaims makes decoding of JWT credential fail if it has claims other than
+// "iss", "sub", "exp", "nbf", "iat", "jti", "aud" and "vc".
+// JWT credential with the flat layout, i.e. without "vc" claim, is not checked, as all its claims belong to the
+// credential.
+func WithRejectUnknownJWTClaims() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.rejectUnknownJWTClaims = true
+	}
+}
+
+// WithStrictJWTExpiration makes decoding of JWT credential fail if it has both "exp" claim and "expirationDate"
+// in the "vc" claim, and they differ by more than the given clock skew.
+func WithStrictJWTExpiration(clockSkew time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.strictJWTExpiration = true
+		opts.jwtExpirationSkew = clockSkew
+	}
+}
+
+// WithStrictJWTDates makes decoding of JWT credential fail if its "nbf" or "exp" claim denotes an instant which
+// differs by more than the given clock skew from the "issuanceDate" or "expirationDate" in the "vc" claim
+// respectively. The "iat" claim tells when the JWT was signed, which may differ from "issuanceDate", and is not
+// checked. The dates are normalized before comparison, so the same instant expressed in different formats or
+// timezones is accepted.
+func WithStrictJWTDates(clockSkew time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.strictJWTDates = true
+		opts.jwtDatesSkew = clockSkew
+	}
+}
+
+// WithCredExpectedChallenge validates that every linked data proof of the credential has the given challenge
+// (e.g. for a credential bound to a presentation request).
+func WithCredExpectedChallenge(challenge string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expectedChallenge = challenge
+	}
+}
+
+// WithRejectFutureProofs makes decoding fail if "created" of any embedded proof of the credential (Data Integrity
+// proofs included) is later than the current time plus the given clock skew, which indicates clock issues or tampering.
+func WithRejectFutureProofs(clockSkew time.Duration) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.rejectFutureProofs = true
+		opts.futureProofSkew = clockSkew
+	}
+}
+
+// WithSDJWTHolderBindingCheck verifies the Holder (Key) Binding JWT of an SD-JWT credential, if it is presented,
+// against the holder public key held in the "cnf" claim of the credential. It has no effect if proof check is disabled.
+func WithSDJWTHolderBindingCheck() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.sdJWTHolderBinding = true
+	}
+}
+
+// ProofVerificationMode defines how the embedded linked data proofs of a credential are verified
+// if it has more than one proof.
+type ProofVerificationMode int
+
+const (
+	// FailFast verifies the proofs in order and fails on the first invalid proof. It is the default mode.
+	FailFast ProofVerificationMode = iota
+
+	// RequireAll verifies every proof and fails if any of them is invalid. The error then wraps
+	// a ProofVerificationError holding the verification result of each proof.
+	RequireAll
+
+	// AtLeastOne verifies every proof and succeeds if at least one of them is valid.
+	AtLeastOne
+)
+
+// WithProofVerificationMode sets how the embedded linked data proofs of the credential are verified
+// (FailFast by default).
+func WithProofVerificationMode(mode ProofVerificationMode) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofVerificationMode = mode
+	}
+}
+
 // WithSchema option to set custom schema.
 func WithSchema(schema string) CredentialOpt {
 	return func(opts *credentialOpts) {
@@ -704,7 +863,8 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 	}
 }
 
-// WithJSONLDDocumentLoader defines a JSON-LD document loader.
+// WithJSONLDDocumentLoader defines a JSON-LD document loader used to resolve @context documents when validating
+// JSON-LD and checking linked data proofs of VC. If not defined, @context documents are fetched from the network.
 func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOpt {
 	return func(opts *credentialOpts) {
 		opts.jsonldDocumentLoader = documentLoader
@@ -717,6 +877,9 @@ func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOp
 //
 // In case of JSON-LD validation, the comparison of JSON-LD VC document after compaction with original VC one is made.
 // In case of mismatch a validation exception is raised.
+//
+// Additionally, a VC whose expirationDate precedes its issuanceDate is rejected, as well as a VC
+// whose credentialStatus.statusListCredential is not a syntactically valid URI.
 func WithStrictValidation() CredentialOpt {
 	return func(opts *credentialOpts) {
 		opts.strictValidation = true
@@ -862,6 +1025,12 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 			return nil, err
 		}
 
+		if holderBinding != "" && vcOpts.sdJWTHolderBinding && !vcOpts.disabledProofCheck {
+			if err = verifySDJWTHolderBinding(vcStr, holderBinding); err != nil {
+				return nil, err
+			}
+		}
+
 		externalJWT = vcStr
 	} else {
 		// Decode json-ld credential, from unsecured JWT or raw JSON
@@ -871,11 +1040,27 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		}
 	}
 
+	vcDataDecoded, err = dropEmptyProof(vcDataDecoded)
+	if err != nil {
+		return nil, fmt.Errorf("drop empty proof: %w", err)
+	}
+
+	if vcOpts.subjectDecrypter != nil {
+		vcDataDecoded, err = decryptSubject(vcDataDecoded, vcOpts.subjectDecrypter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	vc, err := populateCredential(vcDataDecoded, disclosures, sdJWTVersion)
 	if err != nil {
 		return nil, err
 	}
 
+	if vc.ValidFrom == nil && vcOpts.dateOnlyValidFrom {
+		vc.ValidFrom = extractValidFrom(vc.CustomFields, true)
+	}
+
 	if externalJWT == "" && !vcOpts.disableValidation {
 		// TODO: consider new validation options for, eg, jsonschema only, for JWT VC
 		err = validateCredential(vc, vcDataDecoded, vcOpts)
@@ -884,6 +1069,46 @@ func ParseCredential(vcData []byte, opts ...CredentialOpt) (*Credential, error)
 		}
 	}
 
+	if vcOpts.contextSchemaValidation {
+		if err = vc.validateContextSchemas(&vcOpts.jsonldCredentialOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.subjectSchemaLoader != nil {
+		if err = vc.validateSubjectSchemas(vcOpts.subjectSchemaLoader); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.minAssurance != nil {
+		if err = vcOpts.minAssurance.check(vc); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.requireStatus && vc.Status == nil {
+		return nil, errors.New("credential status is required but missing")
+	}
+
+	if vcOpts.validityTime != nil {
+		if err = vc.checkValidityPeriod(*vcOpts.validityTime); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.confidenceMethodChecker != nil {
+		if err = vc.checkConfidenceMethods(vcOpts.confidenceMethodChecker); err != nil {
+			return nil, err
+		}
+	}
+
+	if vcOpts.canonicalContexts {
+		if err = vc.canonicalizeContexts(externalJWT); err != nil {
+			return nil, err
+		}
+	}
+
 	vc.JWT = externalJWT
 	vc.SDHolderBinding = holderBinding
 
@@ -939,6 +1164,16 @@ func populateCredential(vcJSON []byte, sdDisclosures []string, sdJWTVersion comm
 }
 
 func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts) error {
+	if vcOpts.strictValidation {
+		if err := vc.validateDatesOrder(); err != nil {
+			return err
+		}
+
+		if err := validateStatusReference(vc.Status); err != nil {
+			return err
+		}
+	}
+
 	// Credential and type constraint.
 	switch vcOpts.modelValidationMode {
 	case combinedValidation:
@@ -967,6 +1202,20 @@ func validateCredential(vc *Credential, vcBytes []byte, vcOpts *credentialOpts)
 	}
 }
 
+// validateDatesOrder checks that expirationDate, when both dates are defined, does not precede issuanceDate.
+func (vc *Credential) validateDatesOrder() error {
+	if vc.Issued == nil || vc.Expired == nil {
+		return nil
+	}
+
+	if vc.Expired.Time.Before(vc.Issued.Time) {
+		return fmt.Errorf("violated dates order: expirationDate %s is before issuanceDate %s",
+			vc.Expired.FormatToString(), vc.Issued.FormatToString())
+	}
+
+	return nil
+}
+
 func (vc *Credential) validateBaseContext(vcBytes []byte, vcOpts *credentialOpts) error {
 	if len(vc.Types) > 1 || vc.Types[0] != vcType {
 		return errors.New("violated type constraint: not base only type defined")
@@ -996,12 +1245,84 @@ func (vc *Credential) validateBaseContextWithExtendedValidation(vcOpts *credenti
 }
 
 func (vc *Credential) validateJSONLD(vcBytes []byte, vcOpts *credentialOpts) error {
-	return docjsonld.ValidateJSONLD(string(vcBytes),
+	err := docjsonld.ValidateJSONLD(string(vcBytes),
 		docjsonld.WithDocumentLoader(vcOpts.jsonldCredentialOpts.jsonldDocumentLoader),
 		docjsonld.WithExternalContext(vcOpts.jsonldCredentialOpts.externalContext),
 		docjsonld.WithStrictValidation(vcOpts.strictValidation),
 		docjsonld.WithStrictContextURIPosition(baseContext),
 	)
+	if err != nil {
+		return err
+	}
+
+	if vcOpts.strictValidation {
+		return vc.validateTypeContexts(&vcOpts.jsonldCredentialOpts)
+	}
+
+	return nil
+}
+
+// ValidateTypeContexts checks that each type of the credential is defined by its @context, i.e. expands to an
+// absolute IRI. A type which is not defined, e.g. UniversityDegreeCredential declared without the context of
+// the examples, is a relative IRI after expansion and the terms of the typed object are dropped by JSON-LD processing.
+// The document loader and external contexts are taken from the options.
+func (vc *Credential) ValidateTypeContexts(opts ...CredentialOpt) error {
+	return vc.validateTypeContexts(&getCredentialOpts(opts).jsonldCredentialOpts)
+}
+
+func (vc *Credential) validateTypeContexts(jsonldOpts *jsonldCredentialOpts) error {
+	contexts := make([]interface{}, 0, len(vc.Context)+len(vc.CustomContext)+len(jsonldOpts.externalContext))
+
+	for _, c := range vc.Context {
+		contexts = append(contexts, c)
+	}
+
+	contexts = append(contexts, vc.CustomContext...)
+
+	for _, c := range jsonldOpts.externalContext {
+		contexts = append(contexts, c)
+	}
+
+	types := make([]interface{}, len(vc.Types))
+	for i, t := range vc.Types {
+		types[i] = t
+	}
+
+	ldOptions := jsonld.NewJsonLdOptions("")
+	ldOptions.ProcessingMode = jsonld.JsonLd_1_1
+
+	if jsonldOpts.jsonldDocumentLoader != nil {
+		ldOptions.DocumentLoader = jsonldOpts.jsonldDocumentLoader
+	}
+
+	expanded, err := jsonld.NewJsonLdProcessor().Expand(map[string]interface{}{
+		"@context": contexts,
+		"@type":    types,
+	}, ldOptions)
+	if err != nil {
+		return fmt.Errorf("expand credential types: %w", err)
+	}
+
+	var expandedTypes []interface{}
+
+	if len(expanded) == 1 {
+		if node, ok := expanded[0].(map[string]interface{}); ok {
+			expandedTypes, _ = node["@type"].([]interface{}) //nolint:errcheck
+		}
+	}
+
+	if len(expandedTypes) != len(vc.Types) {
+		return errors.New("expand credential types: unexpected expansion result")
+	}
+
+	for i, t := range expandedTypes {
+		if iri, ok := t.(string); !ok || !jsonld.IsAbsoluteIri(iri) {
+			return fmt.Errorf("violated @context constraint: type %s is not defined by the credential contexts",
+				vc.Types[i])
+		}
+	}
+
+	return nil
 }
 
 // CustomCredentialProducer is a factory for Credentials with extended data model.
@@ -1113,6 +1434,7 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		Issuer:           issuer,
 		Issued:           raw.Issued,
 		Expired:          raw.Expired,
+		ValidFrom:        extractValidFrom(raw.CustomFields, false),
 		Proofs:           proofs,
 		Status:           raw.Status,
 		Schemas:          schemas,
@@ -1124,6 +1446,7 @@ func newCredential(raw *rawCredential) (*Credential, error) {
 		SDJWTHashAlg:     raw.SDJWTHashAlg,
 		SDJWTVersion:     raw.SDJWTVersion,
 		SDJWTDisclosures: disclosures,
+		subjectArray:     isJSONArray(raw.Subject),
 	}, nil
 }
 
@@ -1149,6 +1472,27 @@ func parseTypedID(data json.RawMessage) ([]TypedID, error) {
 	return nil, err
 }
 
+// typedIDsFromCustomField parses the custom field of the credential defined either as a single typed ID
+// or as an array of them. It returns nil if the field is not defined.
+func (vc *Credential) typedIDsFromCustomField(key string) ([]TypedID, error) {
+	value, ok := vc.CustomFields[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("marshal %s: %w", key, err)
+	}
+
+	typedIDs, err := parseTypedID(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", key, err)
+	}
+
+	return typedIDs, nil
+}
+
 func parseDisclosures(disclosures []string, hash crypto.Hash) ([]*common.DisclosureClaim, error) {
 	if len(disclosures) == 0 {
 		return nil, nil
@@ -1237,6 +1581,25 @@ func decodeJWTVC(vcStr string, vcOpts *credentialOpts) (jose.Headers, []byte, er
 		return nil, nil, errors.New("public key fetcher is not defined")
 	}
 
+	if vcOpts.rejectUnknownJWTClaims {
+		if err := checkJWTCredClaimNames(vcStr); err != nil {
+			return nil, nil, fmt.Errorf("JWS decoding: %w", err)
+		}
+	}
+
+	if vcOpts.strictJWTExpiration {
+		if err := checkJWTExpirationConsistency(vcStr, vcOpts.jwtExpirationSkew); err != nil {
+			return nil, nil, fmt.Errorf("JWS decoding: %w", err)
+		}
+	}
+
+	if vcOpts.strictJWTDates {
+		err := checkJWTDatesConsistency(vcStr, vcOpts.jwtDatesSkew, jwtNotBeforeClaim, jwtExpiryClaim)
+		if err != nil {
+			return nil, nil, fmt.Errorf("JWS decoding: %w", err)
+		}
+	}
+
 	joseHeaders, vcDecodedBytes, err := decodeCredJWS(vcStr, !vcOpts.disabledProofCheck, vcOpts.publicKeyFetcher)
 	if err != nil {
 		return nil, nil, fmt.Errorf("JWS decoding: %w", err)
@@ -1270,11 +1633,20 @@ func JWTVCToJSON(vc []byte) ([]byte, error) {
 
 func getEmbeddedProofCheckOpts(vcOpts *credentialOpts) *embeddedProofCheckOpts {
 	return &embeddedProofCheckOpts{
-		publicKeyFetcher:     vcOpts.publicKeyFetcher,
-		disabledProofCheck:   vcOpts.disabledProofCheck,
-		ldpSuites:            vcOpts.ldpSuites,
-		jsonldCredentialOpts: vcOpts.jsonldCredentialOpts,
-		dataIntegrityOpts:    vcOpts.verifyDataIntegrity,
+		publicKeyFetcher:      vcOpts.publicKeyFetcher,
+		disabledProofCheck:    vcOpts.disabledProofCheck,
+		ldpSuites:             vcOpts.ldpSuites,
+		suiteRegistry:         vcOpts.suiteRegistry,
+		jsonldCredentialOpts:  vcOpts.jsonldCredentialOpts,
+		dataIntegrityOpts:     vcOpts.verifyDataIntegrity,
+		expectedChallenge:     vcOpts.expectedChallenge,
+		expectedProofPurpose:  vcOpts.expectedProofPurpose,
+		proofPurposeResolver:  vcOpts.proofPurposeResolver,
+		expectedBBSNonce:      vcOpts.expectedBBSNonce,
+		rejectFutureProofs:    vcOpts.rejectFutureProofs,
+		futureProofSkew:       vcOpts.futureProofSkew,
+		proofVerificationMode: vcOpts.proofVerificationMode,
+		unknownProofPolicy:    vcOpts.unknownProofPolicy,
 	}
 }
 
@@ -1415,7 +1787,8 @@ func validateCredentialUsingJSONSchema(data []byte, schemas []TypedID, opts *cre
 }
 
 func getSchemaLoader(schemas []TypedID, opts *credentialOpts) (gojsonschema.JSONLoader, error) {
-	if opts.disabledCustomSchema {
+	// The custom schemas are applied to credentialSubject only if WithSchemaValidation is used.
+	if opts.disabledCustomSchema || opts.subjectSchemaLoader != nil {
 		return defaultSchemaLoaderWithOpts(opts), nil
 	}
 
@@ -1498,7 +1871,11 @@ func defaultSchemaLoader() gojsonschema.JSONLoader {
 }
 
 func getJSONSchema(url string, opts *credentialOpts) ([]byte, error) {
-	loader := opts.schemaLoader
+	return opts.schemaLoader.LoadSchema(url)
+}
+
+// LoadSchema downloads the JSON Schema document from url, unless it is in the cache of the loader.
+func (loader *CredentialSchemaLoader) LoadSchema(url string) ([]byte, error) {
 	cache := loader.cache
 
 	if cache == nil {
@@ -1646,6 +2023,23 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		return nil, err
 	}
 
+	if vc.subjectArray && len(subject) > 0 && !isJSONArray(subject) {
+		subject = wrapJSONArray(subject)
+	}
+
+	customFields := vc.CustomFields
+
+	// ValidFrom takes precedence over "validFrom" custom field, which is only left for values not parsed as time.
+	if vc.ValidFrom != nil {
+		customFields = make(CustomFields, len(vc.CustomFields)+1)
+
+		for k, v := range vc.CustomFields {
+			customFields[k] = v
+		}
+
+		customFields[validFromField] = vc.ValidFrom.FormatToString()
+	}
+
 	r := &rawCredential{
 		Context:        contextToRaw(vc.Context, vc.CustomContext),
 		ID:             vc.ID,
@@ -1662,12 +2056,38 @@ func (vc *Credential) raw() (*rawCredential, error) {
 		Expired:        vc.Expired,
 		JWT:            vc.JWT,
 		SDJWTHashAlg:   vc.SDJWTHashAlg,
-		CustomFields:   vc.CustomFields,
+		CustomFields:   customFields,
 	}
 
 	return r, nil
 }
 
+const validFromField = "validFrom"
+
+// extractValidFrom returns "validFrom" custom field as time and removes it from the custom fields, so that
+// Credential.ValidFrom is its only source. It returns nil and keeps the field if it is not a time string.
+// Date-only values (e.g. "2024-01-01") are accepted if dateOnly is set.
+func extractValidFrom(cf CustomFields, dateOnly bool) *util.TimeWrapper {
+	timeStr, ok := cf[validFromField].(string)
+	if !ok {
+		return nil
+	}
+
+	parse := util.ParseTimeWrapper
+	if dateOnly {
+		parse = util.ParseTimeWrapperOrDateOnly
+	}
+
+	validFrom, err := parse(timeStr)
+	if err != nil {
+		return nil
+	}
+
+	delete(cf, validFromField)
+
+	return validFrom
+}
+
 func typesToRaw(types []string) interface{} {
 	if len(types) == 1 {
 		// as string
@@ -1733,3 +2153,152 @@ func (vc *Credential) MarshalJSON() ([]byte, error) {
 
 	return byteCred, nil
 }
+
+// MarshalJSONOmitEmpty converts Verifiable Credential to JSON bytes omitting optional top-level members which are
+// null or hold an empty array or object (e.g. "credentialSchema":[] or "proof":null). The values of the members are
+// marshalled as by MarshalJSON, i.e. the claims of credentialSubject are not pruned.
+// Mandatory members (@context, type, credentialSubject, issuer and issuanceDate) are kept even if empty.
+// A credential in JWT form is marshalled the same way as by MarshalJSON.
+func (vc *Credential) MarshalJSONOmitEmpty() ([]byte, error) {
+	byteCred, err := vc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	if vc.JWT != "" {
+		return byteCred, nil
+	}
+
+	var credMap map[string]json.RawMessage
+
+	err = json.Unmarshal(byteCred, &credMap)
+	if err != nil {
+		return nil, fmt.Errorf("JSON marshalling of verifiable credential: %w", err)
+	}
+
+	for k, v := range credMap {
+		if isEmptyJSON(v) && !requiredCredentialFields[k] {
+			delete(credMap, k)
+		}
+	}
+
+	return json.Marshal(credMap)
+}
+
+// IssuanceOptions are the options of a W3C VC API issuance request, see
+// https://w3c-ccg.github.io/vc-api/#issue-credential.
+type IssuanceOptions struct {
+	// Type is the type of the proof (signature suite) to be created by the issuer, e.g. "Ed25519Signature2020".
+	Type               string            `json:"type,omitempty"`
+	VerificationMethod string            `json:"verificationMethod,omitempty"`
+	Created            *util.TimeWrapper `json:"created,omitempty"`
+	Challenge          string            `json:"challenge,omitempty"`
+	Domain             string            `json:"domain,omitempty"`
+	CredentialStatus   *TypedID          `json:"credentialStatus,omitempty"`
+}
+
+// ToIssuanceRequest returns the body of a W3C VC API issuance request for the credential, i.e. the credential
+// without proofs together with the issuance options. A credential parsed from JWT is sent in its JSON-LD form.
+func (vc *Credential) ToIssuanceRequest(options IssuanceOptions) ([]byte, error) {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+	vcCopy.JWT = ""
+
+	raw, err := vcCopy.raw()
+	if err != nil {
+		return nil, fmt.Errorf("to issuance request: %w", err)
+	}
+
+	request := struct {
+		Credential *rawCredential   `json:"credential"`
+		Options    *IssuanceOptions `json:"options,omitempty"`
+	}{
+		Credential: raw,
+	}
+
+	if options != (IssuanceOptions{}) {
+		request.Options = &options
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("to issuance request: %w", err)
+	}
+
+	return body, nil
+}
+
+// FlattenSubject returns claims of credential subject as flat key-value pairs, e.g. for indexing and search.
+// Keys of nested objects are joined by dots and array elements are indexed, e.g. "degree.type" or
+// "alumniOf[0].name"; keys of multiple subjects start with the index of the subject, e.g. "[1].name".
+// Values are converted to strings, null values become empty strings.
+func (vc *Credential) FlattenSubject() (map[string]string, error) {
+	subjectBytes, err := subjectToBytes(vc.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("flatten credential subject: %w", err)
+	}
+
+	flat := make(map[string]string)
+
+	if len(subjectBytes) == 0 {
+		return flat, nil
+	}
+
+	var subject interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(subjectBytes))
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&subject); err != nil {
+		return nil, fmt.Errorf("flatten credential subject: %w", err)
+	}
+
+	if subjectID, ok := subject.(string); ok {
+		flat["id"] = subjectID
+
+		return flat, nil
+	}
+
+	flattenClaims("", subject, flat)
+
+	return flat, nil
+}
+
+func flattenClaims(key string, value interface{}, flat map[string]string) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if key == "" {
+				flattenClaims(k, child, flat)
+			} else {
+				flattenClaims(key+"."+k, child, flat)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenClaims(fmt.Sprintf("%s[%d]", key, i), child, flat)
+		}
+	case nil:
+		flat[key] = ""
+	case string:
+		flat[key] = v
+	default:
+		flat[key] = fmt.Sprint(v)
+	}
+}
+
+//nolint:gochecknoglobals
+var requiredCredentialFields = map[string]bool{
+	"@context":                      true,
+	schemaPropertyType:              true,
+	schemaPropertyCredentialSubject: true,
+	schemaPropertyIssuer:            true,
+	schemaPropertyIssuanceDate:      true,
+}
+
+// isEmptyJSON checks whether JSON value is null, an empty array or an empty object.
+func isEmptyJSON(v json.RawMessage) bool {
+	v = bytes.TrimSpace(v)
+
+	return bytes.Equal(v, []byte("null")) || bytes.Equal(v, []byte("[]")) || bytes.Equal(v, []byte("{}"))
+}
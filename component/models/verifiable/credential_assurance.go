@@ -0,0 +1,66 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AssuranceLevelError is returned when the assurance level of a credential is below the required minimum.
+type AssuranceLevelError struct {
+	Level    int
+	MinLevel int
+}
+
+func (e *AssuranceLevelError) Error() string {
+	return fmt.Sprintf("credential assurance level %d is below the required minimum %d", e.Level, e.MinLevel)
+}
+
+type minAssuranceOpts struct {
+	level     int
+	extractor func(*Credential) int
+}
+
+// WithMinAssuranceLevel option is for rejecting credentials with assurance level below level. The assurance level
+// of a credential is provided by extractor, e.g. from its evidence or a level of assurance custom field
+// (see AssuranceLevelFromCustomField). The error returned for such credentials wraps *AssuranceLevelError.
+func WithMinAssuranceLevel(level int, extractor func(*Credential) int) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.minAssurance = &minAssuranceOpts{level: level, extractor: extractor}
+	}
+}
+
+// AssuranceLevelFromCustomField returns an assurance level extractor reading the numeric custom field of
+// the credential, e.g. "levelOfAssurance". A credential without the field has assurance level 0.
+func AssuranceLevelFromCustomField(field string) func(*Credential) int {
+	return func(vc *Credential) int {
+		switch level := vc.CustomFields[field].(type) {
+		case float64:
+			return int(level)
+		case int:
+			return level
+		case json.Number:
+			l, err := level.Int64()
+			if err != nil {
+				return 0
+			}
+
+			return int(l)
+		default:
+			return 0
+		}
+	}
+}
+
+func (o *minAssuranceOpts) check(vc *Credential) error {
+	if level := o.extractor(vc); level < o.level {
+		return &AssuranceLevelError{Level: level, MinLevel: o.level}
+	}
+
+	return nil
+}
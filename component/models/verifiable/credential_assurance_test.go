@@ -0,0 +1,75 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMinAssuranceLevel(t *testing.T) {
+	newVCBytes := func(t *testing.T, level interface{}) []byte {
+		t.Helper()
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["levelOfAssurance"] = level
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	levelOfAssurance := AssuranceLevelFromCustomField("levelOfAssurance")
+
+	t.Run("above the threshold", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, 3), WithMinAssuranceLevel(2, levelOfAssurance))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+
+		_, err = parseTestCredential(t, newVCBytes(t, 2), WithMinAssuranceLevel(2, levelOfAssurance))
+		require.NoError(t, err)
+	})
+
+	t.Run("below the threshold", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, 1), WithMinAssuranceLevel(2, levelOfAssurance))
+		require.EqualError(t, err, "credential assurance level 1 is below the required minimum 2")
+		require.Nil(t, vc)
+
+		var levelErr *AssuranceLevelError
+
+		require.True(t, errors.As(err, &levelErr))
+		require.Equal(t, &AssuranceLevelError{Level: 1, MinLevel: 2}, levelErr)
+
+		// no level declared
+		_, err = parseTestCredential(t, []byte(validCredential), WithMinAssuranceLevel(1, levelOfAssurance))
+		require.True(t, errors.As(err, &levelErr))
+		require.Equal(t, 0, levelErr.Level)
+	})
+
+	t.Run("custom extractor", func(t *testing.T) {
+		evidenceLevel := func(vc *Credential) int {
+			if vc.Evidence != nil {
+				return 2
+			}
+
+			return 0
+		}
+
+		_, err := parseTestCredential(t, []byte(validCredential), WithMinAssuranceLevel(2, evidenceLevel))
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, []byte(validCredential), WithMinAssuranceLevel(3, evidenceLevel))
+		require.Error(t, err)
+	})
+}
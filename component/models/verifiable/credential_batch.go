@@ -0,0 +1,204 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	jsonld "github.com/piprate/json-gold/ld"
+
+	"github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+// BatchResult is the outcome of verification of a single credential of the batch.
+type BatchResult struct {
+	// Credential is the verified credential, nil if verification failed.
+	Credential *Credential
+	// Err is the verification error, nil if verification succeeded.
+	Err error
+}
+
+type batchOpts struct {
+	workers        int
+	credentialOpts []CredentialOpt
+}
+
+// BatchOpt is the VerifyBatch option.
+type BatchOpt func(opts *batchOpts)
+
+// WithBatchWorkers sets the number of credentials verified concurrently. Defaults to the number of CPUs.
+func WithBatchWorkers(n int) BatchOpt {
+	return func(opts *batchOpts) {
+		opts.workers = n
+	}
+}
+
+// WithBatchCredentialOpts sets the options every credential of the batch is parsed with (see ParseCredential).
+func WithBatchCredentialOpts(opts ...CredentialOpt) BatchOpt {
+	return func(batchOpts *batchOpts) {
+		batchOpts.credentialOpts = append(batchOpts.credentialOpts, opts...)
+	}
+}
+
+// VerifyBatch parses and verifies the credentials concurrently with a bounded pool of workers. Results are returned
+// in the order of creds.
+//
+// The JSON-LD document loader and the public key fetcher of the credential options are shared by the batch, and
+// documents and keys they resolve are cached for the time of the batch.
+//
+// If ctx is canceled, the credentials which are not verified yet fail with ctx error, and in-flight verifications
+// are aborted at their next document load or key fetch. The ctx error is also returned along with the results.
+func VerifyBatch(ctx context.Context, creds [][]byte, opts ...BatchOpt) ([]BatchResult, error) {
+	bOpts := &batchOpts{workers: runtime.NumCPU()}
+
+	for _, opt := range opts {
+		opt(bOpts)
+	}
+
+	if bOpts.workers < 1 {
+		bOpts.workers = 1
+	}
+
+	credOpts, err := batchCredentialOpts(ctx, bOpts.credentialOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(creds))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < bOpts.workers && w < len(creds); w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				results[i].Credential, results[i].Err = ParseCredential(creds[i], credOpts...)
+			}
+		}()
+	}
+
+	next := 0
+
+feed:
+	for ; next < len(creds) && ctx.Err() == nil; next++ {
+		select {
+		case jobs <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	for i := next; i < len(creds); i++ {
+		results[i].Err = ctx.Err()
+	}
+
+	return results, ctx.Err()
+}
+
+// batchCredentialOpts appends to opts the document loader and the public key fetcher shared by the batch.
+func batchCredentialOpts(ctx context.Context, opts []CredentialOpt) ([]CredentialOpt, error) {
+	vcOpts := &credentialOpts{}
+
+	for _, opt := range opts {
+		opt(vcOpts)
+	}
+
+	loader, err := newSharedDocumentLoader(vcOpts.jsonldDocumentLoader)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(append([]CredentialOpt{}, opts...),
+		WithJSONLDDocumentLoader(&batchDocumentLoader{ctx: ctx, loader: loader}))
+
+	if vcOpts.publicKeyFetcher != nil {
+		opts = append(opts, WithPublicKeyFetcher(newBatchKeyFetcher(ctx, vcOpts.publicKeyFetcher)))
+	}
+
+	return opts, nil
+}
+
+// batchDocumentLoader fails loading of documents once the batch is canceled.
+type batchDocumentLoader struct {
+	ctx    context.Context
+	loader jsonld.DocumentLoader
+}
+
+func (l *batchDocumentLoader) LoadDocument(u string) (*jsonld.RemoteDocument, error) {
+	if err := l.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return l.loader.LoadDocument(u)
+}
+
+func newBatchKeyFetcher(ctx context.Context, fetcher PublicKeyFetcher) PublicKeyFetcher {
+	cachingFetcher := newCachingKeyFetcher(fetcher)
+
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		return cachingFetcher(issuerID, keyID)
+	}
+}
+
+// newSharedDocumentLoader returns a document loader caching the documents resolved by loader (or by the default
+// loader if it's nil), to be shared by verifications of a batch.
+func newSharedDocumentLoader(loader jsonld.DocumentLoader) (jsonld.DocumentLoader, error) {
+	if loader == nil {
+		loader = jsonld.NewDefaultDocumentLoader(nil)
+	}
+
+	cachingLoader, err := documentloader.NewCachingDocumentLoader(loader)
+	if err != nil {
+		return nil, fmt.Errorf("new shared document loader: %w", err)
+	}
+
+	return cachingLoader, nil
+}
+
+// newCachingKeyFetcher returns a PublicKeyFetcher memoizing the keys resolved by fetcher. Failures are not cached.
+func newCachingKeyFetcher(fetcher PublicKeyFetcher) PublicKeyFetcher {
+	var (
+		mu   sync.Mutex
+		keys = make(map[[2]string]*verifier.PublicKey)
+	)
+
+	return func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		mu.Lock()
+		key, ok := keys[[2]string{issuerID, keyID}]
+		mu.Unlock()
+
+		if ok {
+			return key, nil
+		}
+
+		key, err := fetcher(issuerID, keyID)
+		if err != nil {
+			return nil, err
+		}
+
+		mu.Lock()
+		keys[[2]string{issuerID, keyID}] = key
+		mu.Unlock()
+
+		return key, nil
+	}
+}
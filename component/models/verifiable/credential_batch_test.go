@@ -0,0 +1,82 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vcJWT := createEdDSAJWS(t, []byte(validCredential), signer, false)
+
+	var fetches int32
+
+	fetcher := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		atomic.AddInt32(&fetches, 1)
+
+		return SingleKey(signer.PublicKeyBytes(), kms.ED25519)(issuerID, keyID)
+	}
+
+	newOpts := func(workers int) []BatchOpt {
+		return []BatchOpt{
+			WithBatchWorkers(workers),
+			WithBatchCredentialOpts(WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+				WithPublicKeyFetcher(fetcher)),
+		}
+	}
+
+	t.Run("verifies every credential", func(t *testing.T) {
+		atomic.StoreInt32(&fetches, 0)
+
+		creds := [][]byte{vcJWT, []byte("invalid"), vcJWT, vcJWT, []byte(validCredential)}
+
+		results, err := VerifyBatch(context.Background(), creds, newOpts(2)...)
+		require.NoError(t, err)
+		require.Len(t, results, len(creds))
+
+		for _, i := range []int{0, 2, 3, 4} {
+			require.NoError(t, results[i].Err)
+			require.NotNil(t, results[i].Credential)
+		}
+
+		require.Error(t, results[1].Err)
+		require.Nil(t, results[1].Credential)
+
+		// the key is fetched once at most by every worker racing for it
+		require.LessOrEqual(t, atomic.LoadInt32(&fetches), int32(2))
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, err := VerifyBatch(ctx, [][]byte{vcJWT, vcJWT}, newOpts(1)...)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, results, 2)
+
+		for _, r := range results {
+			require.ErrorIs(t, r.Err, context.Canceled)
+			require.Nil(t, r.Credential)
+		}
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		results, err := VerifyBatch(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+}
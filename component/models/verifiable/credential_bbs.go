@@ -9,12 +9,24 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
+	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/bbsblssignatureproof2020"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
 )
 
+// WithExpectedBBSNonce validates that every BBS+ derived proof (BbsBlsSignatureProof2020) of the credential
+// is bound to the given verifier nonce, which rejects a derivation replayed from another verifier. A credential
+// without derived proof, e.g. the one with the underived BbsBlsSignature2020 proof only, is rejected.
+func WithExpectedBBSNonce(nonce []byte) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expectedBBSNonce = nonce
+	}
+}
+
 // GenerateBBSSelectiveDisclosure generate BBS+ selective disclosure from one BBS+ signature.
+// The derived proof is bound to the nonce given by the verifier, see WithExpectedBBSNonce.
 func (vc *Credential) GenerateBBSSelectiveDisclosure(revealDoc map[string]interface{},
 	nonce []byte, opts ...CredentialOpt) (*Credential, error) {
 	if len(vc.Proofs) == 0 {
@@ -35,7 +47,7 @@ func (vc *Credential) GenerateBBSSelectiveDisclosure(revealDoc map[string]interf
 		return nil, err
 	}
 
-	keyResolver := &keyResolverAdapter{vcOpts.publicKeyFetcher}
+	keyResolver := &keyResolverAdapter{pubKeyFetcher: vcOpts.publicKeyFetcher, baseDID: vc.Issuer.ID}
 
 	vcWithSelectiveDisclosureDoc, err := suite.SelectiveDisclosure(vcDoc, revealDoc, nonce,
 		keyResolver, jsonldProcessorOpts...)
@@ -52,3 +64,65 @@ func (vc *Credential) GenerateBBSSelectiveDisclosure(revealDoc map[string]interf
 
 	return ParseCredential(vcWithSelectiveDisclosureBytes, opts...)
 }
+
+// BBSDisclosedAttributes returns the attributes of VC which are disclosed by the BBS+ selective disclosure
+// generated with revealDoc frame (see GenerateBBSSelectiveDisclosure). Besides the attributes requested by the frame,
+// these include the mandatory ones which are always revealed, e.g. id and type of the credential and its subject.
+// Attributes are returned sorted, as dot separated paths of JSON fields, e.g. "credentialSubject.givenName".
+func (vc *Credential) BBSDisclosedAttributes(revealDoc map[string]interface{},
+	opts ...CredentialOpt) ([]string, error) {
+	vcOpts := getCredentialOpts(opts)
+	jsonldProcessorOpts := mapJSONLDProcessorOpts(&vcOpts.jsonldCredentialOpts)
+
+	vcDoc, err := jsonutil.ToMap(vc)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(vcDoc, "proof")
+
+	frameDoc, err := jsonutil.ToMap(revealDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	revealed, err := processor.Default().Frame(vcDoc, frameDoc,
+		append(jsonldProcessorOpts, processor.WithFrameBlankNodes())...)
+	if err != nil {
+		return nil, fmt.Errorf("frame VC with reveal doc: %w", err)
+	}
+
+	delete(revealed, "@context")
+
+	attributes := make(map[string]struct{})
+	collectAttributes(revealed, "", attributes)
+
+	paths := make([]string, 0, len(attributes))
+	for path := range attributes {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+func collectAttributes(value interface{}, path string, attributes map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			attributes[childPath] = struct{}{}
+
+			collectAttributes(child, childPath, attributes)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectAttributes(child, path, attributes)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/multiformats/go-multibase"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/primitive/bbs12381g2pub"
@@ -129,6 +130,41 @@ func TestCredential_GenerateBBSSelectiveDisclosure(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, vcVerified)
 
+	t.Run("expected nonce", func(t *testing.T) {
+		nonceOpts := []CredentialOpt{
+			WithJSONLDDocumentLoader(createTestDocumentLoader(t)),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")),
+		}
+
+		vcVerified, err := parseTestCredential(t, vcSelectiveDisclosureBytes,
+			append(nonceOpts, WithExpectedBBSNonce(nonce))...)
+		require.NoError(t, err)
+		require.NotNil(t, vcVerified)
+
+		vcVerified, err = parseTestCredential(t, vcSelectiveDisclosureBytes,
+			append(nonceOpts, WithExpectedBBSNonce([]byte("another nonce")))...)
+		require.ErrorContains(t, err, "check embedded proof: proof nonce does not match expected one")
+		require.Nil(t, vcVerified)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, append(nonceOpts, WithExpectedBBSNonce(nonce))...)
+		require.ErrorContains(t, err,
+			"check embedded proof: BBS+ derived proof bound to the expected nonce is missing")
+
+		_, err = parseTestCredential(t, []byte(vcJSON), append(nonceOpts, WithExpectedBBSNonce(nonce))...)
+		require.ErrorContains(t, err,
+			"check embedded proof: BBS+ derived proof bound to the expected nonce is missing")
+
+		vcVerified, err = parseTestCredential(t, vcSelectiveDisclosureBytes,
+			WithEmbeddedSignatureSuites(bbsblssignatureproof2020.New(suite.WithCompactProof(),
+				suite.WithVerifier(bbsblssignatureproof2020.NewG2PublicKeyVerifier([]byte("another nonce"))))),
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")))
+		require.Error(t, err)
+		require.Nil(t, vcVerified)
+	})
+
 	// error cases
 	t.Run("failed generation of selective disclosure", func(t *testing.T) {
 		var (
@@ -212,6 +248,7 @@ func signVCWithBBS(t *testing.T, privKey *bbs12381g2pub.PrivateKey, pubKeyBytes
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
@@ -244,8 +281,203 @@ func signVCWithEd25519(t *testing.T, vc *Credential) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
+	}
+
+	err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+}
+
+func TestCredential_AddBBSLinkedDataProof(t *testing.T) {
+	vcJSON := `
+	{
+	 "@context": [
+	   "https://www.w3.org/2018/credentials/v1",
+	   "https://w3id.org/citizenship/v1",
+	   "https://w3id.org/security/bbs/v1"
+	 ],
+	 "id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+	 "type": [
+	   "VerifiableCredential",
+	   "PermanentResidentCard"
+	 ],
+	 "issuer": "did:example:489398593",
+	 "issuanceDate": "2019-12-03T12:19:52Z",
+	 "credentialSubject": {
+	   "id": "did:example:b34ca6cd37bbf23",
+	   "type": [
+	     "PermanentResident",
+	     "Person"
+	   ],
+	   "givenName": "JOHN",
+	   "familyName": "SMITH"
+	 }
 	}
+	`
+
+	pubKey, privKey, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := pubKey.Marshal()
+	require.NoError(t, err)
+
+	bbsSigner, err := newBBSSigner(privKey)
+	require.NoError(t, err)
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "BbsBlsSignature2020",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   bbsblssignature2020.New(suite.WithSigner(bbsSigner)),
+		VerificationMethod:      "did:example:489398593#key1",
+	}
+
+	vc, err := parseTestCredential(t, []byte(vcJSON))
+	require.NoError(t, err)
 
 	err = vc.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
 	require.NoError(t, err)
+	require.Len(t, vc.Proofs, 1)
+	require.Equal(t, "BbsBlsSignature2020", vc.Proofs[0]["type"])
+
+	t.Run("verify with BLS12-381 G2 public key of the fetcher", func(t *testing.T) {
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		vcVerified, err := parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")))
+		require.NoError(t, err)
+		require.Len(t, vcVerified.Proofs, 1)
+
+		// proof made by other key is rejected
+		otherPubKey, _, err := bbs12381g2pub.GenerateKeyPair(sha256.New, nil)
+		require.NoError(t, err)
+
+		otherPubKeyBytes, err := otherPubKey.Marshal()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(otherPubKeyBytes, "Bls12381G2Key2020")))
+		require.ErrorContains(t, err, "check embedded proof")
+	})
+
+	t.Run("verify multibase encoded proofValue", func(t *testing.T) {
+		signature, err := base64.RawURLEncoding.DecodeString(vc.Proofs[0]["proofValue"].(string))
+		require.NoError(t, err)
+
+		multibaseValue, err := multibase.Encode(multibase.Base58BTC, signature)
+		require.NoError(t, err)
+
+		vcMultibase := *vc
+		vcMultibase.Proofs = []Proof{copyProof(vc.Proofs[0])}
+		vcMultibase.Proofs[0]["proofValue"] = multibaseValue
+
+		vcBytes, err := json.Marshal(&vcMultibase)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(pubKeyBytes, "Bls12381G2Key2020")))
+		require.NoError(t, err)
+	})
+
+	t.Run("BBS+ context is missing", func(t *testing.T) {
+		var raw map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(vcJSON), &raw))
+
+		raw["@context"] = []interface{}{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://w3id.org/citizenship/v1",
+		}
+
+		vcBytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		vcWithoutContext, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		err = vcWithoutContext.AddLinkedDataProof(ldpContext, jsonld.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.EqualError(t, err, "add linked data proof to VC: BbsBlsSignature2020 proof requires "+
+			"https://w3id.org/security/bbs/v1 @context")
+		require.Empty(t, vcWithoutContext.Proofs)
+	})
+}
+
+func copyProof(p Proof) Proof {
+	c := make(Proof, len(p))
+
+	for k, v := range p {
+		c[k] = v
+	}
+
+	return c
+}
+
+func TestCredential_BBSDisclosedAttributes(t *testing.T) {
+	vcJSON := `
+	{
+	 "@context": [
+	   "https://www.w3.org/2018/credentials/v1",
+	   "https://w3id.org/citizenship/v1",
+	   "https://w3id.org/security/bbs/v1"
+	 ],
+	 "id": "https://issuer.oidp.uscis.gov/credentials/83627465",
+	 "type": [
+	   "VerifiableCredential",
+	   "PermanentResidentCard"
+	 ],
+	 "issuer": "did:example:489398593",
+	 "identifier": "83627465",
+	 "issuanceDate": "2019-12-03T12:19:52Z",
+	 "credentialSubject": {
+	   "id": "did:example:b34ca6cd37bbf23",
+	   "type": [
+	     "PermanentResident",
+	     "Person"
+	   ],
+	   "givenName": "JOHN",
+	   "familyName": "SMITH",
+	   "gender": "Male"
+	 }
+	}
+	`
+
+	revealJSON := `
+	{
+	 "@context": [
+	   "https://www.w3.org/2018/credentials/v1",
+	   "https://w3id.org/citizenship/v1",
+	   "https://w3id.org/security/bbs/v1"
+	 ],
+	 "type": ["VerifiableCredential", "PermanentResidentCard"],
+	 "@explicit": true,
+	 "issuanceDate": {},
+	 "credentialSubject": {
+	   "@explicit": true,
+	   "type": ["PermanentResident", "Person"],
+	   "givenName": {}
+	 }
+	}
+	`
+
+	vc, err := parseTestCredential(t, []byte(vcJSON))
+	require.NoError(t, err)
+
+	revealDoc, err := jsonutil.ToMap(revealJSON)
+	require.NoError(t, err)
+
+	attributes, err := vc.BBSDisclosedAttributes(revealDoc, WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+	// ids are mandatory, i.e. disclosed though they are not in the frame
+	require.Equal(t, []string{
+		"credentialSubject",
+		"credentialSubject.givenName",
+		"credentialSubject.id",
+		"credentialSubject.type",
+		"id",
+		"issuanceDate",
+		"type",
+	}, attributes)
+
+	// reveal doc is not changed
+	require.NotContains(t, revealDoc, "id")
 }
@@ -0,0 +1,121 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	util "github.com/hyperledger/aries-framework-go/component/models/util/time"
+)
+
+// CredentialBuilder defines a builder of Credential.
+type CredentialBuilder struct {
+	vc       *Credential
+	autoID   bool
+	idScheme IDScheme
+}
+
+// NewCredentialBuilder creates a new instance of CredentialBuilder.
+func NewCredentialBuilder() *CredentialBuilder {
+	return &CredentialBuilder{
+		vc: &Credential{},
+	}
+}
+
+// WithContext appends @context URIs of the credential, the first one must be the base credentials context.
+func (b *CredentialBuilder) WithContext(contexts ...string) *CredentialBuilder {
+	b.vc.Context = append(b.vc.Context, contexts...)
+	return b
+}
+
+// WithID sets the id of the credential.
+func (b *CredentialBuilder) WithID(id string) *CredentialBuilder {
+	b.vc.ID = id
+	return b
+}
+
+// WithAutoID makes Build generate the id of the credential according to scheme if it's not set by WithID.
+func (b *CredentialBuilder) WithAutoID(scheme IDScheme) *CredentialBuilder {
+	b.autoID = true
+	b.idScheme = scheme
+
+	return b
+}
+
+// WithTypes appends types of the credential, e.g. "VerifiableCredential".
+func (b *CredentialBuilder) WithTypes(types ...string) *CredentialBuilder {
+	b.vc.Types = append(b.vc.Types, types...)
+	return b
+}
+
+// WithSubject sets the subject of the credential, it can be of any type accepted by Credential.Subject.
+func (b *CredentialBuilder) WithSubject(subject interface{}) *CredentialBuilder {
+	b.vc.Subject = subject
+	return b
+}
+
+// WithIssuer sets the issuer of the credential.
+func (b *CredentialBuilder) WithIssuer(issuer Issuer) *CredentialBuilder {
+	b.vc.Issuer = issuer
+	return b
+}
+
+// WithIssuanceDate sets the issuance date of the credential.
+func (b *CredentialBuilder) WithIssuanceDate(issued time.Time) *CredentialBuilder {
+	b.vc.Issued = util.NewTime(issued)
+	return b
+}
+
+// WithExpirationDate sets the expiration date of the credential.
+func (b *CredentialBuilder) WithExpirationDate(expired time.Time) *CredentialBuilder {
+	b.vc.Expired = util.NewTime(expired)
+	return b
+}
+
+// Build validates that the required fields of the credential are set and returns the constructed Credential.
+// The id is generated at this point if WithAutoID is used, after all the other fields are set.
+func (b *CredentialBuilder) Build() (*Credential, error) {
+	vc := *b.vc
+
+	if err := validateBuiltCredential(&vc); err != nil {
+		return nil, fmt.Errorf("build credential: %w", err)
+	}
+
+	if b.autoID {
+		if err := vc.AssignID(b.idScheme); err != nil {
+			return nil, fmt.Errorf("build credential: %w", err)
+		}
+	}
+
+	return &vc, nil
+}
+
+func validateBuiltCredential(vc *Credential) error {
+	if len(vc.Context) == 0 || vc.Context[0] != baseContext {
+		return fmt.Errorf("@context must start with %s", baseContext)
+	}
+
+	if !containsString(vc.Types, vcType) {
+		return fmt.Errorf("type must include %s", vcType)
+	}
+
+	if vc.Issuer.ID == "" {
+		return errors.New("issuer is required")
+	}
+
+	if vc.Issued == nil {
+		return errors.New("issuance date is required")
+	}
+
+	if vc.Subject == nil {
+		return errors.New("subject is required")
+	}
+
+	return nil
+}
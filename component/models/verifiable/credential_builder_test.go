@@ -0,0 +1,93 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialBuilder(t *testing.T) {
+	issued := time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	newBuilder := func() *CredentialBuilder {
+		return NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/v1", "https://www.w3.org/2018/credentials/examples/v1").
+			WithTypes("VerifiableCredential", "UniversityDegreeCredential").
+			WithSubject(Subject{ID: "did:example:ebfeb1f712ebc6f1c276e12ec21"}).
+			WithIssuer(Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}).
+			WithIssuanceDate(issued)
+	}
+
+	t.Run("build credential", func(t *testing.T) {
+		expired := issued.AddDate(1, 0, 0)
+
+		vc, err := newBuilder().
+			WithID("http://example.edu/credentials/1872").
+			WithExpirationDate(expired).
+			Build()
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+		require.Equal(t, []string{"VerifiableCredential", "UniversityDegreeCredential"}, vc.Types)
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f", vc.Issuer.ID)
+		require.True(t, issued.Equal(vc.Issued.Time))
+		require.True(t, expired.Equal(vc.Expired.Time))
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+	})
+
+	t.Run("auto id", func(t *testing.T) {
+		vc, err := newBuilder().WithAutoID(IDSchemeUUID).Build()
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(vc.ID, "urn:uuid:"))
+
+		vc, err = newBuilder().WithID("http://example.edu/credentials/1872").WithAutoID(IDSchemeHash).Build()
+		require.NoError(t, err)
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		_, err := NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/examples/v1").
+			Build()
+		require.EqualError(t, err, "build credential: @context must start with https://www.w3.org/2018/credentials/v1")
+
+		_, err = NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/v1").
+			WithTypes("UniversityDegreeCredential").
+			Build()
+		require.EqualError(t, err, "build credential: type must include VerifiableCredential")
+
+		_, err = NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/v1").
+			WithTypes("VerifiableCredential").
+			Build()
+		require.EqualError(t, err, "build credential: issuer is required")
+
+		_, err = NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/v1").
+			WithTypes("VerifiableCredential").
+			WithIssuer(Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}).
+			Build()
+		require.EqualError(t, err, "build credential: issuance date is required")
+
+		_, err = NewCredentialBuilder().
+			WithContext("https://www.w3.org/2018/credentials/v1").
+			WithTypes("VerifiableCredential").
+			WithIssuer(Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}).
+			WithIssuanceDate(issued).
+			Build()
+		require.EqualError(t, err, "build credential: subject is required")
+	})
+}
@@ -0,0 +1,84 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"sort"
+)
+
+// WithCanonicalContexts option is for normalizing @context of the decoded credential for canonical storage:
+// the base context goes first, followed by the other context URLs deduplicated and sorted, with inline context
+// objects last. MarshalJSON of the credential then emits the contexts in this order.
+//
+// Reordering contexts changes the input of proofs, so the option is for credentials which are not signed yet.
+// Decoding a credential with a proof or in JWT form fails with this option.
+func WithCanonicalContexts() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.canonicalContexts = true
+	}
+}
+
+func (vc *Credential) canonicalizeContexts(vcJWT string) error {
+	if len(vc.Proofs) > 0 || vcJWT != "" {
+		return errors.New("canonical contexts: credential is already signed")
+	}
+
+	contexts := vc.Context
+	inline := make([]interface{}, 0, len(vc.CustomContext))
+
+	// context URLs following an inline context are decoded as custom ones
+	for _, c := range vc.CustomContext {
+		if ctxURL, ok := c.(string); ok {
+			contexts = append(contexts, ctxURL)
+
+			continue
+		}
+
+		inline = append(inline, c)
+	}
+
+	vc.Context = canonicalContexts(contexts)
+
+	if len(inline) == 0 {
+		inline = nil
+	}
+
+	vc.CustomContext = inline
+
+	return nil
+}
+
+// canonicalContexts returns the base context first (if present), followed by the other contexts deduplicated
+// and sorted.
+func canonicalContexts(contexts []string) []string {
+	seen := make(map[string]bool, len(contexts))
+	others := make([]string, 0, len(contexts))
+	hasBase := false
+
+	for _, c := range contexts {
+		if c == baseContext {
+			hasBase = true
+
+			continue
+		}
+
+		if !seen[c] {
+			seen[c] = true
+
+			others = append(others, c)
+		}
+	}
+
+	sort.Strings(others)
+
+	if !hasBase {
+		return others
+	}
+
+	return append([]string{baseContext}, others...)
+}
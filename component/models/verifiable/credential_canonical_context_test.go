@@ -0,0 +1,80 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCanonicalContexts(t *testing.T) {
+	t.Run("normalizes context order", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["@context"] = []interface{}{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+			map[string]interface{}{"@vocab": "https://example.com/vocab#"},
+			"https://w3id.org/citizenship/v1",
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes, WithCanonicalContexts())
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://w3id.org/citizenship/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+		}, vc.Context)
+
+		vcBytes, err = vc.MarshalJSON()
+		require.NoError(t, err)
+
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(vcBytes, &raw))
+		require.Equal(t, []interface{}{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://w3id.org/citizenship/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+			map[string]interface{}{"@vocab": "https://example.com/vocab#"},
+		}, raw["@context"])
+	})
+
+	t.Run("rejects signed credential", func(t *testing.T) {
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["proof"] = map[string]interface{}{
+			"type":               "Ed25519Signature2018",
+			"created":            "2010-01-01T19:23:24Z",
+			"proofPurpose":       "assertionMethod",
+			"verificationMethod": "did:example:123#key1",
+			"jws":                "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..signature",
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithCanonicalContexts(), WithDisabledProofCheck())
+		require.EqualError(t, err, "canonical contexts: credential is already signed")
+	})
+}
+
+func TestCanonicalContexts(t *testing.T) {
+	require.Equal(t, []string{baseContext, "https://a.example.com/v1", "https://b.example.com/v1"},
+		canonicalContexts([]string{
+			"https://b.example.com/v1", baseContext, "https://a.example.com/v1", "https://b.example.com/v1",
+		}))
+
+	require.Equal(t, []string{"https://a.example.com/v1"},
+		canonicalContexts([]string{"https://a.example.com/v1", "https://a.example.com/v1"}))
+}
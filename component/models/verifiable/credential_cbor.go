@@ -0,0 +1,49 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ParseCredentialFromCBOR parses Verifiable Credential from CBOR bytes, e.g. as delivered by constrained (IoT) issuers.
+// The CBOR data item is either a map holding the credential data model, or a text string holding a serialized JWT.
+// The decoded credential goes through the same decoding, proof check and validation as in ParseCredential,
+// which is configured by the same options.
+func ParseCredentialFromCBOR(vcData []byte, opts ...CredentialOpt) (*Credential, error) {
+	decMode, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}{}),
+	}.DecMode()
+	if err != nil {
+		return nil, fmt.Errorf("parse credential from CBOR: %w", err)
+	}
+
+	var vcRaw interface{}
+
+	if err = decMode.Unmarshal(vcData, &vcRaw); err != nil {
+		return nil, fmt.Errorf("parse credential from CBOR: %w", err)
+	}
+
+	if jwtVC, ok := vcRaw.(string); ok {
+		return ParseCredential([]byte(jwtVC), opts...)
+	}
+
+	if _, ok := vcRaw.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("parse credential from CBOR: unexpected CBOR data item of type %T", vcRaw)
+	}
+
+	vcJSON, err := json.Marshal(vcRaw)
+	if err != nil {
+		return nil, fmt.Errorf("parse credential from CBOR: %w", err)
+	}
+
+	return ParseCredential(vcJSON, opts...)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestParseCredentialFromCBOR(t *testing.T) {
+	loaderOpt := WithJSONLDDocumentLoader(createTestDocumentLoader(t))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	toCBOR := func(t *testing.T, vc *Credential) []byte {
+		t.Helper()
+
+		vcJSON, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal(vcJSON, &vcMap))
+
+		vcCBOR, err := cbor.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcCBOR
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		vcFromCBOR, err := ParseCredentialFromCBOR(toCBOR(t, vc), loaderOpt)
+		require.NoError(t, err)
+		require.Equal(t, vc, vcFromCBOR)
+	})
+
+	t.Run("JWT credential in CBOR text string", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		vcCBOR, err := cbor.Marshal(vcJWS)
+		require.NoError(t, err)
+
+		vcFromCBOR, err := ParseCredentialFromCBOR(vcCBOR, loaderOpt,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, vcJWS, vcFromCBOR.JWT)
+		require.Equal(t, vc.ID, vcFromCBOR.ID)
+	})
+
+	t.Run("credential validation is applied", func(t *testing.T) {
+		invalidVC := *vc
+		invalidVC.Types = []string{"UniversityDegreeCredential"}
+
+		_, err := ParseCredentialFromCBOR(toCBOR(t, &invalidVC), loaderOpt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verifiable credential is not valid")
+	})
+
+	t.Run("invalid CBOR", func(t *testing.T) {
+		_, err := ParseCredentialFromCBOR([]byte{0xff}, loaderOpt)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse credential from CBOR")
+	})
+
+	t.Run("unexpected CBOR data item", func(t *testing.T) {
+		vcCBOR, err := cbor.Marshal([]string{"not", "a", "credential"})
+		require.NoError(t, err)
+
+		_, err = ParseCredentialFromCBOR(vcCBOR, loaderOpt)
+		require.EqualError(t, err, "parse credential from CBOR: unexpected CBOR data item of type []interface {}")
+	})
+}
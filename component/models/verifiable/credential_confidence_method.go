@@ -0,0 +1,75 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const confidenceMethodField = "confidenceMethod"
+
+// ConfidenceMethodChecker runs a confidence method declared by the issuer of the credential, e.g. checks that
+// the holder controls the key referenced by the method. It returns an error if confidence is not gained.
+type ConfidenceMethodChecker func(vc *Credential, method TypedID) error
+
+// WithConfidenceMethodCheck makes decoding of the credential run checker for every confidence method declared
+// in its confidenceMethod property and fail if any check fails.
+func WithConfidenceMethodCheck(checker ConfidenceMethodChecker) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.confidenceMethodChecker = checker
+	}
+}
+
+// ConfidenceMethods returns the confidence methods declared in the confidenceMethod property of the credential,
+// which describe how a verifier can gain confidence in the credential, e.g. by authenticating its subject.
+func (vc *Credential) ConfidenceMethods() ([]TypedID, error) {
+	return vc.typedIDsFromCustomField(confidenceMethodField)
+}
+
+// AddConfidenceMethod declares the confidence method in the confidenceMethod property of the credential.
+// It has to be done before the credential is signed.
+func (vc *Credential) AddConfidenceMethod(method TypedID) error {
+	methods, err := vc.ConfidenceMethods()
+	if err != nil {
+		return err
+	}
+
+	data, err := typedIDsToRaw(append(methods, method))
+	if err != nil {
+		return fmt.Errorf("marshal confidence methods: %w", err)
+	}
+
+	var value interface{}
+
+	if err = json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("unmarshal confidence methods: %w", err)
+	}
+
+	if vc.CustomFields == nil {
+		vc.CustomFields = CustomFields{}
+	}
+
+	vc.CustomFields[confidenceMethodField] = value
+
+	return nil
+}
+
+func (vc *Credential) checkConfidenceMethods(checker ConfidenceMethodChecker) error {
+	methods, err := vc.ConfidenceMethods()
+	if err != nil {
+		return err
+	}
+
+	for _, method := range methods {
+		if err = checker(vc, method); err != nil {
+			return fmt.Errorf("confidence method %s: %w", method.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_ConfidenceMethod(t *testing.T) {
+	vcMap := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+	vcMap["confidenceMethod"] = map[string]interface{}{
+		"id":                "did:example:ebfeb1f712ebc6f1c276e12ec21#key-1",
+		"type":              "VerificationMethodConfidence",
+		"controllerSubject": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+	}
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	t.Run("checker consumes declared method", func(t *testing.T) {
+		var checked []TypedID
+
+		vc, err := parseTestCredential(t, vcBytes, WithConfidenceMethodCheck(func(vc *Credential, method TypedID) error {
+			checked = append(checked, method)
+
+			return nil
+		}))
+		require.NoError(t, err)
+		require.Len(t, checked, 1)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21#key-1", checked[0].ID)
+		require.Equal(t, "VerificationMethodConfidence", checked[0].Type)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", checked[0].CustomFields["controllerSubject"])
+
+		methods, err := vc.ConfidenceMethods()
+		require.NoError(t, err)
+		require.Equal(t, checked, methods)
+	})
+
+	t.Run("checker fails", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, WithConfidenceMethodCheck(func(*Credential, TypedID) error {
+			return errors.New("holder is not authenticated")
+		}))
+		require.EqualError(t, err, "confidence method did:example:ebfeb1f712ebc6f1c276e12ec21#key-1: "+
+			"holder is not authenticated")
+	})
+
+	t.Run("add confidence method", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		methods, err := vc.ConfidenceMethods()
+		require.NoError(t, err)
+		require.Empty(t, methods)
+
+		require.NoError(t, vc.AddConfidenceMethod(TypedID{ID: "did:example:holder#key-1", Type: "Type1"}))
+		require.NoError(t, vc.AddConfidenceMethod(TypedID{ID: "did:example:holder#key-2", Type: "Type2"}))
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		var ids []string
+
+		_, err = parseTestCredential(t, vcBytes, WithConfidenceMethodCheck(func(_ *Credential, method TypedID) error {
+			ids = append(ids, method.ID)
+
+			return nil
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []string{"did:example:holder#key-1", "did:example:holder#key-2"}, ids)
+	})
+}
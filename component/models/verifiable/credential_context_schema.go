@@ -0,0 +1,120 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/piprate/json-gold/ld"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ContextSubjectSchemaTerm is the member of a JSON-LD context document which embeds a JSON Schema of the
+// credential subject, next to the "@context" member, e.g.
+//
+//	{
+//	  "@context": {...},
+//	  "credentialSubjectSchema": {"type": "object", "required": ["name"], ...}
+//	}
+//
+// JSON-LD processing ignores the member.
+const ContextSubjectSchemaTerm = "credentialSubjectSchema"
+
+// WithContextSchemaValidation option is for validating the credential subject against the JSON Schemas embedded
+// in the @context documents of the credential (see ContextSubjectSchemaTerm). Contexts without an embedded schema
+// are skipped.
+func WithContextSchemaValidation() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.contextSchemaValidation = true
+	}
+}
+
+// ValidateContextSchemas validates the credential subject against the JSON Schemas embedded in the @context
+// documents of the credential (see ContextSubjectSchemaTerm), without fetching the schemas separately.
+// Each subject is validated if the credential has several ones. The contexts are loaded with the document loader
+// taken from the options.
+func (vc *Credential) ValidateContextSchemas(opts ...CredentialOpt) error {
+	return vc.validateContextSchemas(&getCredentialOpts(opts).jsonldCredentialOpts)
+}
+
+func (vc *Credential) validateContextSchemas(jsonldOpts *jsonldCredentialOpts) error {
+	var loader ld.DocumentLoader = ld.NewDefaultDocumentLoader(nil)
+
+	if jsonldOpts.jsonldDocumentLoader != nil {
+		loader = jsonldOpts.jsonldDocumentLoader
+	}
+
+	var subjects []interface{}
+
+	for _, ctx := range vc.Context {
+		schema, err := loadContextSubjectSchema(ctx, loader)
+		if err != nil {
+			return fmt.Errorf("load subject schema of @context %s: %w", ctx, err)
+		}
+
+		if schema == nil {
+			continue
+		}
+
+		if subjects == nil {
+			if subjects, err = vc.subjectsJSON(); err != nil {
+				return err
+			}
+		}
+
+		for _, subject := range subjects {
+			result, err := gojsonschema.Validate(gojsonschema.NewGoLoader(schema), gojsonschema.NewGoLoader(subject))
+			if err != nil {
+				return fmt.Errorf("validation of credential subject against schema of @context %s: %w", ctx, err)
+			}
+
+			if !result.Valid() {
+				return errors.New(describeSchemaValidationError(result, "credential subject"))
+			}
+		}
+	}
+
+	return nil
+}
+
+func loadContextSubjectSchema(ctxURL string, loader ld.DocumentLoader) (interface{}, error) {
+	doc, err := loader.LoadDocument(ctxURL)
+	if err != nil {
+		return nil, err
+	}
+
+	ctxDoc, ok := doc.Document.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	return ctxDoc[ContextSubjectSchemaTerm], nil
+}
+
+// subjectsJSON returns the JSON form of the credential subject(s).
+func (vc *Credential) subjectsJSON() ([]interface{}, error) {
+	vcJSON, err := json.Marshal(vc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal credential: %w", err)
+	}
+
+	var raw struct {
+		Subject interface{} `json:"credentialSubject"`
+	}
+
+	if err = json.Unmarshal(vcJSON, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal credential subject: %w", err)
+	}
+
+	if subjects, ok := raw.Subject.([]interface{}); ok {
+		return subjects, nil
+	}
+
+	return []interface{}{raw.Subject}, nil
+}
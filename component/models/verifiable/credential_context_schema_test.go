@@ -0,0 +1,127 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+)
+
+const schemaContextURL = "https://example.com/context/membership/v1"
+
+const schemaContext = `{
+  "@context": {
+    "@version": 1.1,
+    "MembershipCredential": "https://example.com/vocab#MembershipCredential",
+    "memberName": "https://example.com/vocab#memberName",
+    "memberLevel": "https://example.com/vocab#memberLevel"
+  },
+  "credentialSubjectSchema": {
+    "type": "object",
+    "required": ["memberName", "memberLevel"],
+    "properties": {
+      "memberName": {"type": "string"},
+      "memberLevel": {"type": "string", "enum": ["bronze", "silver", "gold"]}
+    }
+  }
+}`
+
+func TestCredential_ValidateContextSchemas(t *testing.T) {
+	loader := createTestDocumentLoader(t, ldcontext.Document{
+		URL:     schemaContextURL,
+		Content: []byte(schemaContext),
+	})
+
+	newVCBytes := func(t *testing.T, subject map[string]interface{}) []byte {
+		t.Helper()
+
+		vc := map[string]interface{}{
+			"@context":          []string{"https://www.w3.org/2018/credentials/v1", schemaContextURL},
+			"id":                "http://example.com/credentials/1",
+			"type":              []string{"VerifiableCredential", "MembershipCredential"},
+			"issuer":            "did:example:76e12ec712ebc6f1c221ebfeb1f",
+			"issuanceDate":      "2023-01-01T19:23:24Z",
+			"credentialSubject": subject,
+		}
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	validSubject := map[string]interface{}{
+		"id":          "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"memberName":  "Jayden Doe",
+		"memberLevel": "gold",
+	}
+
+	invalidSubject := map[string]interface{}{
+		"id":          "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"memberLevel": "platinum",
+	}
+
+	t.Run("parse credential with subject matching embedded schema", func(t *testing.T) {
+		vc, err := ParseCredential(newVCBytes(t, validSubject),
+			WithJSONLDDocumentLoader(loader), WithContextSchemaValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+
+		require.NoError(t, vc.ValidateContextSchemas(WithJSONLDDocumentLoader(loader)))
+	})
+
+	t.Run("parse credential with subject violating embedded schema", func(t *testing.T) {
+		vc, err := ParseCredential(newVCBytes(t, invalidSubject),
+			WithJSONLDDocumentLoader(loader), WithContextSchemaValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential subject is not valid")
+		require.Contains(t, err.Error(), "memberName is required")
+		require.Contains(t, err.Error(), "memberLevel must be one of the following")
+		require.Nil(t, vc)
+
+		// the embedded schema is not checked without the option
+		vc, err = ParseCredential(newVCBytes(t, invalidSubject), WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		err = vc.ValidateContextSchemas(WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "credential subject is not valid")
+	})
+
+	t.Run("each subject is validated", func(t *testing.T) {
+		vc, err := ParseCredential(newVCBytes(t, validSubject), WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		vc.Subject = []Subject{
+			{ID: "did:example:1", CustomFields: CustomFields{"memberName": "Alice", "memberLevel": "silver"}},
+			{ID: "did:example:2", CustomFields: CustomFields{"memberName": "Bob"}},
+		}
+
+		err = vc.ValidateContextSchemas(WithJSONLDDocumentLoader(loader))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "memberLevel is required")
+	})
+
+	t.Run("contexts without embedded schema are skipped", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithContextSchemaValidation())
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("context load error", func(t *testing.T) {
+		vc, err := ParseCredential(newVCBytes(t, validSubject), WithJSONLDDocumentLoader(loader))
+		require.NoError(t, err)
+
+		err = vc.ValidateContextSchemas(WithJSONLDDocumentLoader(createTestDocumentLoader(t)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "load subject schema of @context "+schemaContextURL)
+	})
+}
@@ -0,0 +1,101 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// CustomField returns the value of the custom field of the credential and whether it's defined.
+func (vc *Credential) CustomField(key string) (interface{}, bool) {
+	value, ok := vc.CustomFields[key]
+
+	return value, ok
+}
+
+// CustomFieldString returns the value of the custom field of the credential if it's a string.
+// False is returned if the field is not defined or is of another type.
+func (vc *Credential) CustomFieldString(key string) (string, bool) {
+	value, ok := vc.CustomFields[key].(string)
+
+	return value, ok
+}
+
+// CustomFieldInt returns the value of the custom field of the credential if it's an integer number.
+// JSON numbers (json.Number or float64) are accepted if they have no fractional part and fit into int.
+// False is returned if the field is not defined or is of another type.
+func (vc *Credential) CustomFieldInt(key string) (int, bool) {
+	switch value := vc.CustomFields[key].(type) {
+	case int:
+		return value, true
+	case int64:
+		if value < math.MinInt || value > math.MaxInt {
+			return 0, false
+		}
+
+		return int(value), true
+	case float64:
+		return floatToInt(value)
+	case json.Number:
+		i, err := value.Int64()
+		if err != nil {
+			// e.g. 8.3294847e+07
+			f, fErr := value.Float64()
+			if fErr != nil {
+				return 0, false
+			}
+
+			return floatToInt(f)
+		}
+
+		if i < math.MinInt || i > math.MaxInt {
+			return 0, false
+		}
+
+		return int(i), true
+	default:
+		return 0, false
+	}
+}
+
+// CustomFieldFloat returns the value of the custom field of the credential if it's a number.
+// False is returned if the field is not defined or is of another type.
+func (vc *Credential) CustomFieldFloat(key string) (float64, bool) {
+	switch value := vc.CustomFields[key].(type) {
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		return f, true
+	default:
+		return 0, false
+	}
+}
+
+// CustomFieldMap returns the value of the custom field of the credential if it's a JSON object.
+// False is returned if the field is not defined or is of another type.
+func (vc *Credential) CustomFieldMap(key string) (map[string]interface{}, bool) {
+	value, ok := vc.CustomFields[key].(map[string]interface{})
+
+	return value, ok
+}
+
+// floatToInt converts float to int if it's lossless.
+func floatToInt(f float64) (int, bool) {
+	if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+		return 0, false
+	}
+
+	return int(f), true
+}
@@ -0,0 +1,136 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestCredential_CustomFields(t *testing.T) {
+	vcMap := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+	vcMap["referenceNumber"] = 83294847
+	vcMap["score"] = 4.5
+	vcMap["label"] = "degree"
+	vcMap["details"] = map[string]interface{}{"level": "master"}
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, vcBytes)
+	require.NoError(t, err)
+
+	t.Run("any value", func(t *testing.T) {
+		value, ok := vc.CustomField("label")
+		require.True(t, ok)
+		require.Equal(t, "degree", value)
+
+		_, ok = vc.CustomField("unknown")
+		require.False(t, ok)
+	})
+
+	t.Run("string", func(t *testing.T) {
+		value, ok := vc.CustomFieldString("label")
+		require.True(t, ok)
+		require.Equal(t, "degree", value)
+
+		_, ok = vc.CustomFieldString("referenceNumber")
+		require.False(t, ok)
+	})
+
+	t.Run("int", func(t *testing.T) {
+		value, ok := vc.CustomFieldInt("referenceNumber")
+		require.True(t, ok)
+		require.Equal(t, 83294847, value)
+
+		_, ok = vc.CustomFieldInt("score")
+		require.False(t, ok)
+
+		_, ok = vc.CustomFieldInt("label")
+		require.False(t, ok)
+
+		_, ok = vc.CustomFieldInt("unknown")
+		require.False(t, ok)
+
+		vc := &Credential{CustomFields: CustomFields{
+			"int":    7,
+			"int64":  int64(8),
+			"number": json.Number("9"),
+			"exp":    json.Number("8.3294847e+07"),
+			"big":    1e300,
+		}}
+
+		for key, expected := range map[string]int{"int": 7, "int64": 8, "number": 9, "exp": 83294847} {
+			value, ok = vc.CustomFieldInt(key)
+			require.True(t, ok)
+			require.Equal(t, expected, value)
+		}
+
+		_, ok = vc.CustomFieldInt("big")
+		require.False(t, ok)
+	})
+
+	t.Run("float", func(t *testing.T) {
+		value, ok := vc.CustomFieldFloat("score")
+		require.True(t, ok)
+		require.Equal(t, 4.5, value)
+
+		value, ok = vc.CustomFieldFloat("referenceNumber")
+		require.True(t, ok)
+		require.Equal(t, float64(83294847), value)
+
+		_, ok = vc.CustomFieldFloat("label")
+		require.False(t, ok)
+	})
+
+	t.Run("large integer survives round trip", func(t *testing.T) {
+		const largeInt = "9007199254740993" // 2^53 + 1, not representable as float64
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vcBytes = []byte(strings.Replace(string(vcBytes), "83294847", largeInt, 1))
+
+		vcWithLargeInt, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, json.Number(largeInt), vcWithLargeInt.CustomFields["referenceNumber"])
+
+		vcBytes, err = vcWithLargeInt.MarshalJSON()
+		require.NoError(t, err)
+		require.Contains(t, string(vcBytes), `"referenceNumber":`+largeInt)
+
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		jwtClaims, err := vcWithLargeInt.JWTClaims(true)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalJWS(EdDSA, signer, vcWithLargeInt.Issuer.ID+"#keys-1")
+		require.NoError(t, err)
+
+		vcFromJWT, err := parseTestCredential(t, []byte(vcJWT),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, json.Number(largeInt), vcFromJWT.CustomFields["referenceNumber"])
+	})
+
+	t.Run("map", func(t *testing.T) {
+		value, ok := vc.CustomFieldMap("details")
+		require.True(t, ok)
+		require.Equal(t, map[string]interface{}{"level": "master"}, value)
+
+		_, ok = vc.CustomFieldMap("label")
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+)
+
+const credentialSubjectField = "credentialSubject"
+
+// WithSubjectDecrypter defines the decrypter of the verifier's JWE recipient key used to decrypt credentialSubject
+// which the issuer encrypted for the verifier, i.e. the credentialSubject is a serialized JWE (compact or JSON)
+// whose plaintext is the JSON of the subject. The proofs of the credential are checked against the encrypted
+// subject, while the Credential exposes the cleartext claims.
+func WithSubjectDecrypter(decrypter jose.Decrypter) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.subjectDecrypter = decrypter
+	}
+}
+
+// decryptSubject replaces the JWE wrapped credentialSubject of the decoded VC with its plaintext.
+// VC is returned as is if its credentialSubject is not a JWE.
+func decryptSubject(vcBytes []byte, decrypter jose.Decrypter) ([]byte, error) {
+	var vcMap map[string]interface{}
+
+	if err := json.Unmarshal(vcBytes, &vcMap); err != nil {
+		return nil, fmt.Errorf("decrypt credentialSubject: %w", err)
+	}
+
+	serializedJWE, ok := vcMap[credentialSubjectField].(string)
+	if !ok {
+		return vcBytes, nil
+	}
+
+	jwe, err := jose.Deserialize(serializedJWE)
+	if err != nil {
+		// e.g. the subject is defined by its ID only
+		return vcBytes, nil
+	}
+
+	plaintext, err := decrypter.Decrypt(jwe)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentialSubject: %w", err)
+	}
+
+	var subject interface{}
+
+	if err = json.Unmarshal(plaintext, &subject); err != nil {
+		return nil, fmt.Errorf("decrypt credentialSubject: plaintext is not JSON: %w", err)
+	}
+
+	vcMap[credentialSubjectField] = subject
+
+	return json.Marshal(vcMap)
+}
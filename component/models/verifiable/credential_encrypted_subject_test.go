@@ -0,0 +1,99 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	cryptoapi "github.com/hyperledger/aries-framework-go/spi/crypto"
+	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestWithSubjectDecrypter(t *testing.T) {
+	verifierKMS, err := createKMS()
+	require.NoError(t, err)
+
+	tinkCrypto, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	kid, pubKeyBytes, err := verifierKMS.CreateAndExportPubKeyBytes(kmsapi.NISTP256ECDHKWType)
+	require.NoError(t, err)
+
+	verifierPubKey := &cryptoapi.PublicKey{}
+	require.NoError(t, json.Unmarshal(pubKeyBytes, verifierPubKey))
+
+	verifierPubKey.KID = kid
+
+	encrypter, err := jose.NewJWEEncrypt(jose.A256GCM, "", "", "", nil,
+		[]*cryptoapi.PublicKey{verifierPubKey}, tinkCrypto)
+	require.NoError(t, err)
+
+	subject := map[string]interface{}{
+		"id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"degree": map[string]interface{}{
+			"type": "BachelorDegree",
+			"name": "Bachelor of Science and Arts",
+		},
+	}
+
+	subjectBytes, err := json.Marshal(subject)
+	require.NoError(t, err)
+
+	jwe, err := encrypter.Encrypt(subjectBytes)
+	require.NoError(t, err)
+
+	encryptedSubject, err := jwe.FullSerialize(json.Marshal)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Subject = encryptedSubject
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	t.Run("decrypt subject", func(t *testing.T) {
+		decrypted, err := parseTestCredential(t, vcBytes,
+			WithSubjectDecrypter(jose.NewJWEDecrypt(nil, tinkCrypto, verifierKMS)))
+		require.NoError(t, err)
+
+		subjects, ok := decrypted.Subject.([]Subject)
+		require.True(t, ok)
+		require.Len(t, subjects, 1)
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subjects[0].ID)
+		require.Equal(t, subject["degree"], subjects[0].CustomFields["degree"])
+	})
+
+	t.Run("subject is kept encrypted without decrypter", func(t *testing.T) {
+		encrypted, err := parseTestCredential(t, vcBytes, WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, encryptedSubject, encrypted.Subject)
+	})
+
+	t.Run("subject which is not JWE is not changed", func(t *testing.T) {
+		plain, err := parseTestCredential(t, []byte(validCredential),
+			WithSubjectDecrypter(jose.NewJWEDecrypt(nil, tinkCrypto, verifierKMS)))
+		require.NoError(t, err)
+		require.Equal(t, vc.Issuer, plain.Issuer)
+		require.NotEqual(t, encryptedSubject, plain.Subject)
+	})
+
+	t.Run("decryption by other recipient fails", func(t *testing.T) {
+		otherKMS, err := createKMS()
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes,
+			WithSubjectDecrypter(jose.NewJWEDecrypt(nil, tinkCrypto, otherKMS)))
+		require.ErrorContains(t, err, "decrypt credentialSubject")
+	})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/gowebpki/jcs"
+)
+
+// IDScheme defines how an id is generated for a credential which has none.
+type IDScheme int
+
+const (
+	// IDSchemeUUID generates a random "urn:uuid:" id.
+	IDSchemeUUID IDScheme = iota
+	// IDSchemeHash generates a "urn:sha256:" id derived from the content of the credential, so the same credential
+	// always gets the same id.
+	IDSchemeHash
+)
+
+const (
+	uuidIDPrefix   = "urn:uuid:"
+	sha256IDPrefix = "urn:sha256:"
+)
+
+// AssignID sets the id of the credential generated according to scheme, unless the credential already has an id.
+//
+// The hash based id is the hex encoded SHA-256 hash of the JSON canonicalization (RFC 8785) of the credential
+// without its proofs, hence it must be assigned when all the other fields of the credential are set.
+func (vc *Credential) AssignID(scheme IDScheme) error {
+	if vc.ID != "" {
+		return nil
+	}
+
+	id, err := generateCredentialID(vc, scheme)
+	if err != nil {
+		return fmt.Errorf("assign credential id: %w", err)
+	}
+
+	vc.ID = id
+
+	return nil
+}
+
+func generateCredentialID(vc *Credential, scheme IDScheme) (string, error) {
+	switch scheme {
+	case IDSchemeUUID:
+		return uuidIDPrefix + uuid.NewString(), nil
+
+	case IDSchemeHash:
+		if vc.JWT != "" {
+			return "", errors.New("hash based id of JWT credential is not supported")
+		}
+
+		vcCopy := *vc
+		vcCopy.Proofs = nil
+
+		vcJSON, err := json.Marshal(&vcCopy)
+		if err != nil {
+			return "", err
+		}
+
+		canonical, err := jcs.Transform(vcJSON)
+		if err != nil {
+			return "", fmt.Errorf("canonicalize credential: %w", err)
+		}
+
+		digest := sha256.Sum256(canonical)
+
+		return sha256IDPrefix + hex.EncodeToString(digest[:]), nil
+
+	default:
+		return "", fmt.Errorf("unsupported id scheme %d", scheme)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_AssignID(t *testing.T) {
+	newVC := func() *Credential {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.ID = ""
+
+		return vc
+	}
+
+	t.Run("urn:uuid id", func(t *testing.T) {
+		vc := newVC()
+
+		require.NoError(t, vc.AssignID(IDSchemeUUID))
+		require.True(t, strings.HasPrefix(vc.ID, "urn:uuid:"))
+
+		_, err := uuid.Parse(strings.TrimPrefix(vc.ID, "urn:uuid:"))
+		require.NoError(t, err)
+
+		other := newVC()
+
+		require.NoError(t, other.AssignID(IDSchemeUUID))
+		require.NotEqual(t, vc.ID, other.ID)
+	})
+
+	t.Run("hash based id", func(t *testing.T) {
+		vc := newVC()
+
+		require.NoError(t, vc.AssignID(IDSchemeHash))
+		require.Regexp(t, "^urn:sha256:[0-9a-f]{64}$", vc.ID)
+
+		// the id does not depend on proofs
+		same := newVC()
+		same.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+		require.NoError(t, same.AssignID(IDSchemeHash))
+		require.Equal(t, vc.ID, same.ID)
+
+		// but depends on the content
+		other := newVC()
+		other.Types = append(other.Types, "AlumniCredential")
+
+		require.NoError(t, other.AssignID(IDSchemeHash))
+		require.NotEqual(t, vc.ID, other.ID)
+	})
+
+	t.Run("existing id is kept", func(t *testing.T) {
+		vc := newVC()
+		vc.ID = "http://example.edu/credentials/1872"
+
+		require.NoError(t, vc.AssignID(IDSchemeUUID))
+		require.Equal(t, "http://example.edu/credentials/1872", vc.ID)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vc := newVC()
+
+		require.EqualError(t, vc.AssignID(IDScheme(-1)), "assign credential id: unsupported id scheme -1")
+
+		vc.JWT = "header.payload.signature"
+
+		require.EqualError(t, vc.AssignID(IDSchemeHash),
+			"assign credential id: hash based id of JWT credential is not supported")
+		require.Empty(t, vc.ID)
+	})
+}
@@ -0,0 +1,114 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// AuthorizedCredentialTypesTerm is the member of the issuer credential subject listing the credential types
+// the subject is authorized to issue.
+const AuthorizedCredentialTypesTerm = "authorizedCredentialTypes"
+
+// VerifyIssuerChain verifies the chain of trust of a credential issued by delegation. issuerCredential is
+// the credential authorizing the issuer of vc: it must be issued by one of the rootTrust issuers, its subject must be
+// the issuer of vc, it must be valid at the issuance date of vc and authorize (by AuthorizedCredentialTypesTerm)
+// every type of vc other than VerifiableCredential.
+//
+// The proofs of both credentials are not verified here: they are expected to be parsed with the proof check
+// enabled. issuerCredential is required to be secured by a proof though.
+func VerifyIssuerChain(vc, issuerCredential *Credential, rootTrust []string) error {
+	if vc == nil || issuerCredential == nil {
+		return errors.New("verify issuer chain: credential and issuer credential must be defined")
+	}
+
+	if err := verifyIssuerChain(vc, issuerCredential, rootTrust); err != nil {
+		return fmt.Errorf("verify issuer chain: %w", err)
+	}
+
+	return nil
+}
+
+func verifyIssuerChain(vc, issuerCredential *Credential, rootTrust []string) error {
+	if len(issuerCredential.Proofs) == 0 && issuerCredential.JWT == "" {
+		return errors.New("issuer credential is not secured by a proof")
+	}
+
+	if !containsString(rootTrust, issuerCredential.Issuer.ID) {
+		return fmt.Errorf("issuer credential is issued by untrusted issuer %s", issuerCredential.Issuer.ID)
+	}
+
+	subjects, err := issuerCredential.subjectsJSON()
+	if err != nil {
+		return err
+	}
+
+	if len(subjects) != 1 {
+		return errors.New("issuer credential must have a single subject")
+	}
+
+	subject, ok := subjects[0].(map[string]interface{})
+	if !ok {
+		return errors.New("issuer credential subject is not an object")
+	}
+
+	if subjectID, _ := subject["id"].(string); subjectID != vc.Issuer.ID {
+		return fmt.Errorf("issuer credential subject %q is not the credential issuer %s", subjectID, vc.Issuer.ID)
+	}
+
+	issued := time.Now()
+	if vc.Issued != nil {
+		issued = vc.Issued.Time
+	}
+
+	if issuerCredential.Issued != nil && issued.Before(issuerCredential.Issued.Time) {
+		return errors.New("credential is issued before the issuer credential")
+	}
+
+	if issuerCredential.Expired != nil && issued.After(issuerCredential.Expired.Time) {
+		return errors.New("credential is issued after the issuer credential expired")
+	}
+
+	authorized, err := authorizedCredentialTypes(subject)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range vc.Types {
+		if t != vcType && !containsString(authorized, t) {
+			return fmt.Errorf("issuer is not authorized to issue credentials of type %s", t)
+		}
+	}
+
+	return nil
+}
+
+func authorizedCredentialTypes(subject map[string]interface{}) ([]string, error) {
+	switch types := subject[AuthorizedCredentialTypesTerm].(type) {
+	case string:
+		return []string{types}, nil
+	case []interface{}:
+		result := make([]string, len(types))
+
+		for i, t := range types {
+			s, ok := t.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s of issuer credential subject", AuthorizedCredentialTypesTerm)
+			}
+
+			result[i] = s
+		}
+
+		return result, nil
+	case nil:
+		return nil, fmt.Errorf("issuer credential subject has no %s", AuthorizedCredentialTypesTerm)
+	default:
+		return nil, fmt.Errorf("invalid %s of issuer credential subject", AuthorizedCredentialTypesTerm)
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	utiltime "github.com/hyperledger/aries-framework-go/component/models/util/time"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestVerifyIssuerChain(t *testing.T) {
+	const rootID = "did:example:root"
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	loader := createTestDocumentLoader(t)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Types = []string{"VerifiableCredential", "UniversityDegreeCredential"}
+
+	newIssuerCredential := func(t *testing.T, issuer string, authorized interface{}) *Credential {
+		t.Helper()
+
+		issuerVC := &Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1"},
+			ID:      "http://example.edu/credentials/authorization/1",
+			Types:   []string{"VerifiableCredential"},
+			Issuer:  Issuer{ID: issuer},
+			Issued:  utiltime.NewTime(vc.Issued.Add(-time.Hour)),
+			Subject: Subject{
+				ID:           vc.Issuer.ID,
+				CustomFields: CustomFields{AuthorizedCredentialTypesTerm: authorized},
+			},
+		}
+
+		require.NoError(t, issuerVC.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      issuer + "#key1",
+		}, jsonldsig.WithDocumentLoader(loader)))
+
+		issuerVCBytes, err := json.Marshal(issuerVC)
+		require.NoError(t, err)
+
+		// round trip through parsing, which checks the proof
+		issuerVC, err = ParseCredential(issuerVCBytes, WithJSONLDDocumentLoader(loader),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+
+		return issuerVC
+	}
+
+	t.Run("valid chain", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, rootID, []interface{}{"UniversityDegreeCredential"})
+
+		require.NoError(t, VerifyIssuerChain(vc, issuerVC, []string{"did:example:other", rootID}))
+	})
+
+	t.Run("single authorized type", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, rootID, "UniversityDegreeCredential")
+
+		require.NoError(t, VerifyIssuerChain(vc, issuerVC, []string{rootID}))
+	})
+
+	t.Run("broken chain", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, "did:example:untrusted", []interface{}{"UniversityDegreeCredential"})
+
+		err := VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, "verify issuer chain: issuer credential is issued by untrusted issuer "+
+			"did:example:untrusted")
+
+		issuerVC = newIssuerCredential(t, rootID, []interface{}{"UniversityDegreeCredential"})
+		issuerVC.Subject = Subject{
+			ID:           "did:example:someone-else",
+			CustomFields: CustomFields{AuthorizedCredentialTypesTerm: "UniversityDegreeCredential"},
+		}
+
+		err = VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, `verify issuer chain: issuer credential subject "did:example:someone-else" `+
+			"is not the credential issuer "+vc.Issuer.ID)
+	})
+
+	t.Run("type is not authorized", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, rootID, []interface{}{"DriversLicenseCredential"})
+
+		err := VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err,
+			"verify issuer chain: issuer is not authorized to issue credentials of type UniversityDegreeCredential")
+
+		issuerVC = newIssuerCredential(t, rootID, nil)
+
+		err = VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, "verify issuer chain: issuer credential subject has no authorizedCredentialTypes")
+	})
+
+	t.Run("issuer credential validity", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, rootID, "UniversityDegreeCredential")
+		issuerVC.Expired = utiltime.NewTime(vc.Issued.Add(-time.Minute))
+
+		err := VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, "verify issuer chain: credential is issued after the issuer credential expired")
+
+		issuerVC = newIssuerCredential(t, rootID, "UniversityDegreeCredential")
+		issuerVC.Issued = utiltime.NewTime(vc.Issued.Add(time.Minute))
+
+		err = VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, "verify issuer chain: credential is issued before the issuer credential")
+	})
+
+	t.Run("unsecured issuer credential", func(t *testing.T) {
+		issuerVC := newIssuerCredential(t, rootID, "UniversityDegreeCredential")
+		issuerVC.Proofs = nil
+
+		err := VerifyIssuerChain(vc, issuerVC, []string{rootID})
+		require.EqualError(t, err, "verify issuer chain: issuer credential is not secured by a proof")
+
+		require.Error(t, VerifyIssuerChain(vc, nil, []string{rootID}))
+	})
+}
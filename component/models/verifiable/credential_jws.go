@@ -6,12 +6,46 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 )
 
 // MarshalJWS serializes JWT into signed form (JWS).
-func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jcc, signatureAlg, signer, keyID)
+func (jcc *JWTCredClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...MarshalJWSOpt) (string, error) {
+	return marshalJWS(jcc, signatureAlg, signer, keyID, opts...)
+}
+
+// SigningInput returns JWS signing input of JWT claims, i.e. base64url(header) + "." + base64url(payload),
+// together with base64url encoded JWS header. It allows to sign the JWT externally (e.g. using HSM);
+// the token is then assembled as signing input + "." + base64url(signature).
+func (jcc *JWTCredClaims) SigningInput(signatureAlg JWSAlgorithm, keyID string) ([]byte, string, error) {
+	return jwsSigningInput(jcc, signatureAlg, keyID)
+}
+
+// EstimateSize returns approximate length of compact JWS produced by MarshalJWS without signing the claims.
+// A signature of the length typical for signatureAlg is assumed (RSA signatures are estimated for 2048-bit keys).
+func (jcc *JWTCredClaims) EstimateSize(signatureAlg JWSAlgorithm, keyID string) (int, error) {
+	return estimateJWSSize(jcc, signatureAlg, keyID)
+}
+
+// JWTSigningInput returns the JWS signing input of the JWT credential, i.e. base64url(header) + "." +
+// base64url(payload) exactly as in vc.JWT, e.g. to archive the signed bytes for non-repudiation.
+// The token is the signing input + "." + base64url(signature). Disclosures of SD-JWT credential are not signed
+// by the issuer, so they are not part of the signing input.
+func (vc *Credential) JWTSigningInput() ([]byte, error) {
+	if vc.JWT == "" {
+		return nil, errors.New("JWT signing input: credential is not a JWT")
+	}
+
+	parts := strings.Split(vc.JWT, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("JWT signing input: JWT is not in compact serialization")
+	}
+
+	return []byte(parts[0] + "." + parts[1]), nil
 }
 
 func unmarshalJWSClaims(
@@ -8,7 +8,9 @@ package verifiable
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/go-jose/go-jose/v3"
@@ -50,6 +52,87 @@ func TestJWTCredClaimsMarshalJWS(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, vc.stringJSON(t), vcRaw.stringJSON(t))
 	})
+
+	t.Run("empty kid is kept by default", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(RS256, signer, "")
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{"alg":"RS256","kid":""}`, jwsHeaderJSON(t, jws))
+	})
+
+	t.Run("empty kid is omitted", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(RS256, signer, "", WithOmitEmptyKID())
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{"alg":"RS256"}`, jwsHeaderJSON(t, jws))
+
+		vcFromJWS, err := parseTestCredential(t, []byte(jws), WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+	})
+
+	t.Run("non-empty kid is not omitted", func(t *testing.T) {
+		jws, err := jwtClaims.MarshalJWS(RS256, signer, "did:123#key1", WithOmitEmptyKID())
+		require.NoError(t, err)
+
+		require.JSONEq(t, `{"alg":"RS256","kid":"did:123#key1"}`, jwsHeaderJSON(t, jws))
+	})
+}
+
+func jwsHeaderJSON(t *testing.T, jws string) string {
+	t.Helper()
+
+	header, _, _ := strings.Cut(jws, ".")
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(header)
+	require.NoError(t, err)
+
+	return string(headerBytes)
+}
+
+func TestJWTCredClaimsSigningInput(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	t.Run("assemble token from externally produced signature", func(t *testing.T) {
+		signingInput, header, err := jwtClaims.SigningInput(EdDSA, "did:123#key1")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(signingInput), header+"."))
+
+		headerBytes, err := base64.RawURLEncoding.DecodeString(header)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"alg":"EdDSA","kid":"did:123#key1"}`, string(headerBytes))
+
+		// sign externally, e.g. by HSM
+		signature, err := signer.Sign(signingInput)
+		require.NoError(t, err)
+
+		jws := string(signingInput) + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+		vcFromJWS, err := parseTestCredential(t, []byte(jws),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+
+		// the token is the same as the one made by MarshalJWS
+		expectedJWS, err := jwtClaims.MarshalJWS(EdDSA, signer, "did:123#key1")
+		require.NoError(t, err)
+		require.Equal(t, expectedJWS, jws)
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		signingInput, header, err := jwtClaims.SigningInput(JWSAlgorithm(-1), "did:123#key1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported algorithm")
+		require.Empty(t, signingInput)
+		require.Empty(t, header)
+	})
 }
 
 type invalidCredClaims struct {
@@ -58,6 +141,43 @@ type invalidCredClaims struct {
 	Credential int `json:"vc,omitempty"`
 }
 
+func TestCredential_JWTSigningInput(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vcJWT := string(createEdDSAJWS(t, []byte(validCredential), signer, false))
+
+	vc, err := parseTestCredential(t, []byte(vcJWT),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+	require.NoError(t, err)
+
+	signingInput, err := vc.JWTSigningInput()
+	require.NoError(t, err)
+
+	signature := vcJWT[strings.LastIndex(vcJWT, ".")+1:]
+	require.NotEmpty(t, signature)
+	require.Equal(t, vcJWT, string(signingInput)+"."+signature)
+
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	require.NoError(t, err)
+
+	require.NoError(t, verifier.NewEd25519SignatureVerifier().Verify(&verifier.PublicKey{
+		Type:  kms.ED25519,
+		Value: signer.PublicKeyBytes(),
+	}, signingInput, sig))
+
+	t.Run("not a JWT credential", func(t *testing.T) {
+		ldVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		_, err = ldVC.JWTSigningInput()
+		require.EqualError(t, err, "JWT signing input: credential is not a JWT")
+
+		_, err = (&Credential{JWT: "not.a-jwt"}).JWTSigningInput()
+		require.EqualError(t, err, "JWT signing input: JWT is not in compact serialization")
+	})
+}
+
 func TestCredJWSDecoderUnmarshal(t *testing.T) {
 	signer, err := newCryptoSigner(kms.RSARS256Type)
 	require.NoError(t, err)
@@ -136,3 +256,46 @@ func TestCredJWSDecoderUnmarshal(t *testing.T) {
 		require.Nil(t, joseHeaders)
 	})
 }
+
+func TestJWTCredClaimsEstimateSize(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		alg     JWSAlgorithm
+		keyType kms.KeyType
+	}{
+		{alg: EdDSA, keyType: kms.ED25519Type},
+		{alg: RS256, keyType: kms.RSARS256Type},
+		{alg: ECDSASecp256r1, keyType: kms.ECDSAP256TypeIEEEP1363},
+		{alg: ECDSASecp384r1, keyType: kms.ECDSAP384TypeIEEEP1363},
+	}
+
+	for _, tc := range tests {
+		name, err := tc.alg.Name()
+		require.NoError(t, err)
+
+		t.Run(name, func(t *testing.T) {
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			size, err := jwtClaims.EstimateSize(tc.alg, "did:123#key1")
+			require.NoError(t, err)
+
+			jws, err := jwtClaims.MarshalJWS(tc.alg, signer, "did:123#key1")
+			require.NoError(t, err)
+
+			require.InDelta(t, len(jws), size, 4)
+		})
+	}
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		size, err := jwtClaims.EstimateSize(JWSAlgorithm(-1), "did:123#key1")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported algorithm")
+		require.Zero(t, size)
+	})
+}
@@ -6,9 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	josejwt "github.com/go-jose/go-jose/v3/jwt"
@@ -16,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 	"github.com/hyperledger/aries-framework-go/component/models/jwt"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
+	util "github.com/hyperledger/aries-framework-go/component/models/util/time"
 )
 
 const (
@@ -24,8 +28,25 @@ const (
 	vcExpirationDateField = "expirationDate"
 	vcIssuerField         = "issuer"
 	vcIssuerIDField       = "id"
+
+	jwtNotBeforeClaim = "nbf"
+	jwtExpiryClaim    = "exp"
 )
 
+// knownJWTCredClaims are the claims expected in JWT credential.
+//
+//nolint:gochecknoglobals
+var knownJWTCredClaims = map[string]bool{
+	"iss": true,
+	"sub": true,
+	"exp": true,
+	"nbf": true,
+	"iat": true,
+	"jti": true,
+	"aud": true,
+	"vc":  true,
+}
+
 // JWTCredClaims is JWT Claims extension by Verifiable Credential (with custom "vc" claim).
 type JWTCredClaims struct {
 	*jwt.Claims
@@ -56,7 +77,8 @@ func (jcc *JWTCredClaims) ToSDJWTV5CredentialPayload() ([]byte, error) {
 }
 
 // UnmarshalJSON defines custom unmarshalling of JWTCredClaims from JSON.
-// For SD-JWT case, it supports both v2 and v5 formats.
+// The credential is taken from the "vc" claim if it is present (nested layout). Otherwise, the claims other than
+// the registered JWT claims are the credential (flat layout, e.g. SD-JWT v5 credential).
 func (jcc *JWTCredClaims) UnmarshalJSON(data []byte) error {
 	type Alias JWTCredClaims
 
@@ -64,13 +86,34 @@ func (jcc *JWTCredClaims) UnmarshalJSON(data []byte) error {
 
 	customFields := make(CustomFields)
 
-	err := jsonutil.UnmarshalWithCustomFields(data, alias, customFields)
+	err := jsonutil.UnmarshalWithCustomFieldsUseNumber(data, alias, customFields)
 	if err != nil {
 		return fmt.Errorf("unmarshal JWTCredClaims: %w", err)
 	}
 
-	if len(customFields) > 0 && len(alias.VC) == 0 {
+	if alias.VC == nil {
 		alias.VC = customFields
+
+		return nil
+	}
+
+	// Like custom fields of the flat layout, JSON numbers of the "vc" claim are kept as json.Number,
+	// so the custom fields of the credential survive a JWT round trip without precision loss.
+	var nested struct {
+		VC json.RawMessage `json:"vc"`
+	}
+
+	err = json.Unmarshal(data, &nested)
+	if err != nil {
+		return fmt.Errorf("unmarshal JWTCredClaims: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(nested.VC))
+	decoder.UseNumber()
+
+	err = decoder.Decode(&alias.VC)
+	if err != nil {
+		return fmt.Errorf("unmarshal JWTCredClaims: %w", err)
 	}
 
 	return nil
@@ -121,7 +164,11 @@ func newJWTCredClaims(vc *Credential, minimizeVC bool) (*JWTCredClaims, error) {
 	// If a Credential was parsed from JWT, we don't want the original JWT included when marshaling back to JWT claims.
 	raw.JWT = ""
 
-	vcMap, err := jsonutil.MergeCustomFields(raw, raw.CustomFields)
+	// Custom fields are merged as they are, so their json.Number values aren't turned into float64.
+	customFields := raw.CustomFields
+	raw.CustomFields = nil
+
+	vcMap, err := jsonutil.MergeCustomFields(raw, customFields)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +203,113 @@ func decodeCredJWT(rawJWT string, unmarshaller JWTCredClaimsUnmarshaller) (jose.
 	return joseHeaders, vcData, nil
 }
 
+// checkJWTCredClaimNames checks that JWT has only claims listed in knownJWTCredClaims. JWT signature is not checked.
+// A JWT without "vc" claim has the credential in the flat layout, hence all its claims are known.
+func checkJWTCredClaimNames(rawJWT string) error {
+	var claims map[string]json.RawMessage
+
+	_, err := unmarshalJWS(rawJWT, false, nil, &claims)
+	if err != nil {
+		return fmt.Errorf("unmarshal JWT claims: %w", err)
+	}
+
+	if _, nested := claims["vc"]; !nested {
+		return nil
+	}
+
+	var unknown []string
+
+	for name := range claims {
+		if !knownJWTCredClaims[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+
+		return fmt.Errorf("unknown JWT claims: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// checkJWTExpirationConsistency checks that "exp" claim of JWT and "expirationDate" of its "vc" claim, if both are
+// present, differ by no more than clockSkew. JWT signature is not checked.
+func checkJWTExpirationConsistency(rawJWT string, clockSkew time.Duration) error {
+	return checkJWTDatesConsistency(rawJWT, clockSkew, jwtExpiryClaim)
+}
+
+// checkJWTDatesConsistency checks that the given registered date claims of JWT ("nbf", "exp") and the
+// corresponding dates of its "vc" claim ("issuanceDate" or "expirationDate"), if both are present, denote instants
+// which differ by no more than clockSkew, whatever the formats and timezones of the dates are.
+// JWT signature is not checked.
+func checkJWTDatesConsistency(rawJWT string, clockSkew time.Duration, claimNames ...string) error {
+	var claims JWTCredClaims
+
+	_, err := unmarshalJWS(rawJWT, false, nil, &claims)
+	if err != nil {
+		return fmt.Errorf("unmarshal JWT claims: %w", err)
+	}
+
+	if claims.Claims == nil {
+		return nil
+	}
+
+	for _, claimName := range claimNames {
+		var (
+			claim     *josejwt.NumericDate
+			dateField string
+		)
+
+		switch claimName {
+		case jwtNotBeforeClaim:
+			claim, dateField = claims.NotBefore, vcIssuanceDateField
+		case jwtExpiryClaim:
+			claim, dateField = claims.Expiry, vcExpirationDateField
+		default:
+			return fmt.Errorf("unsupported JWT date claim %s", claimName)
+		}
+
+		if err = checkJWTDateConsistency(claims.VC, claimName, claim, dateField, clockSkew); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func checkJWTDateConsistency(vcMap map[string]interface{}, claimName string, claim *josejwt.NumericDate,
+	dateField string, clockSkew time.Duration) error {
+	if claim == nil {
+		return nil
+	}
+
+	date, ok := vcMap[dateField].(string)
+	if !ok {
+		return nil
+	}
+
+	parsed, err := util.ParseTimeWrapper(date)
+	if err != nil {
+		return fmt.Errorf("parse vc %s: %w", dateField, err)
+	}
+
+	claimTime := claim.Time()
+
+	diff := claimTime.Sub(parsed.Time)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if diff > clockSkew {
+		return fmt.Errorf("JWT %s %s and vc %s %s differ by more than %s",
+			claimName, claimTime.UTC().Format(time.RFC3339), dateField, date, clockSkew)
+	}
+
+	return nil
+}
+
 func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	vcMap := jcc.VC
 	claims := jcc.Claims
@@ -165,8 +319,7 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	}
 
 	if nbf := claims.NotBefore; nbf != nil {
-		nbfTime := nbf.Time().UTC()
-		vcMap[vcIssuanceDateField] = nbfTime.Format(time.RFC3339)
+		refineVCDateFromJWTClaim(vcMap, vcIssuanceDateField, nbf.Time())
 	}
 
 	if jti := claims.ID; jti != "" {
@@ -174,16 +327,27 @@ func (jcc *JWTCredClaims) refineFromJWTClaims() {
 	}
 
 	if iat := claims.IssuedAt; iat != nil {
-		iatTime := iat.Time().UTC()
-		vcMap[vcIssuanceDateField] = iatTime.Format(time.RFC3339)
+		refineVCDateFromJWTClaim(vcMap, vcIssuanceDateField, iat.Time())
 	}
 
 	if exp := claims.Expiry; exp != nil {
-		expTime := exp.Time().UTC()
-		vcMap[vcExpirationDateField] = expTime.Format(time.RFC3339)
+		refineVCDateFromJWTClaim(vcMap, vcExpirationDateField, exp.Time())
 	}
 }
 
+// refineVCDateFromJWTClaim sets the date field of the "vc" claim from the JWT date claim. As JWT date claims have
+// a precision of seconds, the date of the "vc" claim is kept if it denotes the same second, so that its sub-second
+// precision is not lost.
+func refineVCDateFromJWTClaim(vcMap map[string]interface{}, dateField string, claimTime time.Time) {
+	if date, ok := vcMap[dateField].(string); ok {
+		if t, err := time.Parse(time.RFC3339, date); err == nil && t.Unix() == claimTime.Unix() {
+			return
+		}
+	}
+
+	vcMap[dateField] = claimTime.UTC().Format(time.RFC3339)
+}
+
 func refineVCIssuerFromJWTClaims(vcMap map[string]interface{}, iss string) {
 	// Issuer of Verifiable Credential could be either string (id) or struct (with "id" field).
 	if _, exists := vcMap[vcIssuerField]; !exists {
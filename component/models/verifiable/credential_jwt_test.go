@@ -8,6 +8,7 @@ package verifiable
 import (
 	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 	"github.com/hyperledger/aries-framework-go/component/models/jwt"
+	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
 )
 
 func TestDecodeJWT(t *testing.T) {
@@ -57,6 +60,44 @@ func TestRefineVcFromJwtClaims(t *testing.T) {
 	require.Equal(t, "2029-08-10T00:00:00Z", vcMap["expirationDate"])
 }
 
+func TestCredential_SubSecondDates(t *testing.T) {
+	vcData := strings.Replace(validCredential, `"issuanceDate": "2010-01-01T19:23:24Z"`,
+		`"issuanceDate": "2010-01-01T19:23:24.123Z"`, 1)
+
+	vc, err := parseTestCredential(t, []byte(vcData))
+	require.NoError(t, err)
+	require.Equal(t, 123*time.Millisecond, time.Duration(vc.Issued.Nanosecond()))
+
+	t.Run("JSON round trip", func(t *testing.T) {
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+		require.Contains(t, string(vcBytes), `"issuanceDate":"2010-01-01T19:23:24.123Z"`)
+
+		parsed, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, vc.Issued.Time, parsed.Issued.Time)
+	})
+
+	t.Run("JWT round trip", func(t *testing.T) {
+		jwtClaims, err := vc.JWTClaims(false)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		parsed, err := parseTestCredential(t, []byte(vcJWT), WithDisabledProofCheck())
+		require.NoError(t, err)
+		require.Equal(t, "2010-01-01T19:23:24.123Z", parsed.Issued.FormatToString())
+	})
+
+	t.Run("date of vc claim is replaced by a different JWT date", func(t *testing.T) {
+		vcMap := map[string]interface{}{"issuanceDate": "2010-01-01T19:23:24.123Z"}
+
+		refineVCDateFromJWTClaim(vcMap, "issuanceDate", time.Date(2010, time.January, 1, 19, 23, 25, 0, time.UTC))
+		require.Equal(t, "2010-01-01T19:23:25Z", vcMap["issuanceDate"])
+	})
+}
+
 func TestJWTCredClaims_ToSDJWTCredentialPayload(t *testing.T) {
 	jcc := &JWTCredClaims{
 		Claims: &jwt.Claims{
@@ -98,3 +139,246 @@ func TestJWTCredClaims_ToSDJWTCredentialPayload(t *testing.T) {
 
 	require.Equal(t, jcc, jccMapped)
 }
+
+func TestWithRejectUnknownJWTClaims(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(true)
+	require.NoError(t, err)
+
+	claimsMap, err := jsonutil.ToMap(jwtClaims)
+	require.NoError(t, err)
+
+	claimsMap["azp"] = "did:example:client"
+
+	jwsWithAZP, err := marshalJWS(claimsMap, EdDSA, signer, vc.Issuer.ID+"#key1")
+	require.NoError(t, err)
+
+	jws, err := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#key1")
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("unexpected claim is rejected", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(jwsWithAZP), fetcher, WithRejectUnknownJWTClaims())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unknown JWT claims: azp")
+		require.Nil(t, vcFromJWS)
+	})
+
+	t.Run("unexpected claim is ignored by default", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(jwsWithAZP), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+	})
+
+	t.Run("only expected claims", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(jws), fetcher, WithRejectUnknownJWTClaims())
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vcFromJWS.ID)
+	})
+}
+
+func TestWithStrictJWTExpiration(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	newJWS := func(expirationDate string) string {
+		jwtClaims, e := vc.JWTClaims(false)
+		require.NoError(t, e)
+
+		claimsMap, e := jsonutil.ToMap(jwtClaims)
+		require.NoError(t, e)
+
+		claimsMap["vc"].(map[string]interface{})["expirationDate"] = expirationDate
+
+		jws, e := marshalJWS(claimsMap, EdDSA, signer, vc.Issuer.ID+"#key1")
+		require.NoError(t, e)
+
+		return jws
+	}
+
+	t.Run("agreeing exp and expirationDate", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(newJWS("2020-01-01T19:23:24Z")), fetcher,
+			WithStrictJWTExpiration(0))
+		require.NoError(t, err)
+		require.Equal(t, vc.Expired.Time, vcFromJWS.Expired.Time)
+	})
+
+	t.Run("difference within clock skew", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(newJWS("2020-01-01T19:23:54Z")), fetcher,
+			WithStrictJWTExpiration(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, vc.Expired.Time, vcFromJWS.Expired.Time)
+	})
+
+	t.Run("disagreeing exp and expirationDate", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(newJWS("2021-01-01T19:23:24Z")), fetcher,
+			WithStrictJWTExpiration(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWT exp 2020-01-01T19:23:24Z and vc expirationDate "+
+			"2021-01-01T19:23:24Z differ by more than 1m0s")
+		require.Nil(t, vcFromJWS)
+	})
+
+	t.Run("disagreeing values are ignored by default", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(newJWS("2021-01-01T19:23:24Z")), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, vc.Expired.Time, vcFromJWS.Expired.Time)
+	})
+
+	t.Run("invalid expirationDate", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t, []byte(newJWS("not a date")), fetcher,
+			WithStrictJWTExpiration(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "parse vc expirationDate")
+		require.Nil(t, vcFromJWS)
+	})
+}
+
+func TestWithStrictJWTDates(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	newJWS := func(issuanceDate, expirationDate string) string {
+		jwtClaims, e := vc.JWTClaims(false)
+		require.NoError(t, e)
+
+		claimsMap, e := jsonutil.ToMap(jwtClaims)
+		require.NoError(t, e)
+
+		vcMap := claimsMap["vc"].(map[string]interface{})
+		vcMap["issuanceDate"] = issuanceDate
+		vcMap["expirationDate"] = expirationDate
+
+		jws, e := marshalJWS(claimsMap, EdDSA, signer, vc.Issuer.ID+"#key1")
+		require.NoError(t, e)
+
+		return jws
+	}
+
+	t.Run("same instants in different timezones", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t,
+			[]byte(newJWS("2010-01-01T21:23:24+02:00", "2020-01-01T14:23:24-05:00")), fetcher,
+			WithStrictJWTDates(0))
+		require.NoError(t, err)
+		require.True(t, vc.Issued.Time.Equal(vcFromJWS.Issued.Time))
+		require.True(t, vc.Expired.Time.Equal(vcFromJWS.Expired.Time))
+	})
+
+	t.Run("divergent issuanceDate in another timezone", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t,
+			[]byte(newJWS("2010-01-01T19:23:24+02:00", "2020-01-01T19:23:24Z")), fetcher,
+			WithStrictJWTDates(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWT nbf 2010-01-01T19:23:24Z and vc issuanceDate "+
+			"2010-01-01T19:23:24+02:00 differ by more than 1m0s")
+		require.Nil(t, vcFromJWS)
+	})
+
+	t.Run("divergent expirationDate in another timezone", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t,
+			[]byte(newJWS("2010-01-01T19:23:24Z", "2020-01-01T19:23:24-05:00")), fetcher,
+			WithStrictJWTDates(time.Minute))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "JWT exp 2020-01-01T19:23:24Z and vc expirationDate "+
+			"2020-01-01T19:23:24-05:00 differ by more than 1m0s")
+		require.Nil(t, vcFromJWS)
+	})
+
+	t.Run("iat differing from issuanceDate", func(t *testing.T) {
+		jwtClaims, e := vc.JWTClaims(false)
+		require.NoError(t, e)
+
+		jwtClaims.IssuedAt = josejwt.NewNumericDate(vc.Issued.Time.Add(time.Hour))
+
+		jws, e := jwtClaims.MarshalJWS(EdDSA, signer, vc.Issuer.ID+"#key1")
+		require.NoError(t, e)
+
+		_, err := parseTestCredential(t, []byte(jws), fetcher, WithStrictJWTDates(time.Minute))
+		require.NoError(t, err)
+	})
+
+	t.Run("divergent dates are ignored by default", func(t *testing.T) {
+		vcFromJWS, err := parseTestCredential(t,
+			[]byte(newJWS("2010-01-01T19:23:24+02:00", "2020-01-01T19:23:24-05:00")), fetcher)
+		require.NoError(t, err)
+		require.Equal(t, vc.Issued.Time, vcFromJWS.Issued.Time)
+	})
+}
+
+func TestParseCredentialFromJWS_Layouts(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	jwtClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	nestedClaims, err := jsonutil.ToMap(jwtClaims)
+	require.NoError(t, err)
+
+	// flat layout: the credential properties are top level claims of JWT
+	flatClaims, err := jsonutil.ToMap(jwtClaims.Claims)
+	require.NoError(t, err)
+
+	for k, v := range jwtClaims.VC {
+		flatClaims[k] = v
+	}
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	for name, claims := range map[string]map[string]interface{}{"nested": nestedClaims, "flat": flatClaims} {
+		t.Run(name, func(t *testing.T) {
+			jws, err := marshalJWS(claims, EdDSA, signer, vc.Issuer.ID+"#key1")
+			require.NoError(t, err)
+
+			vcFromJWS, err := parseTestCredential(t, []byte(jws), fetcher)
+			require.NoError(t, err)
+
+			vcFromJWS.JWT = ""
+			require.Equal(t, vc, vcFromJWS)
+
+			vcFromJWS, err = parseTestCredential(t, []byte(jws), fetcher, WithRejectUnknownJWTClaims())
+			require.NoError(t, err)
+			require.Equal(t, vc.ID, vcFromJWS.ID)
+		})
+	}
+
+	t.Run("nested layout is chosen when vc claim is present", func(t *testing.T) {
+		claims := make(map[string]interface{}, len(nestedClaims)+1)
+		for k, v := range nestedClaims {
+			claims[k] = v
+		}
+
+		claims["credentialSubject"] = map[string]interface{}{"id": "did:example:other"}
+
+		jws, err := marshalJWS(claims, EdDSA, signer, vc.Issuer.ID+"#key1")
+		require.NoError(t, err)
+
+		vcFromJWS, err := parseTestCredential(t, []byte(jws), fetcher)
+		require.NoError(t, err)
+
+		vcFromJWS.JWT = ""
+		require.Equal(t, vc, vcFromJWS)
+
+		_, err = parseTestCredential(t, []byte(jws), fetcher, WithRejectUnknownJWTClaims())
+		require.ErrorContains(t, err, "unknown JWT claims: credentialSubject")
+	})
+}
@@ -7,13 +7,38 @@ package verifiable
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/component/models/ld/processor"
 )
 
 // AddLinkedDataProof appends proof to the Verifiable Credential.
+// The DID of the context verification method must match the issuer DID unless context.AllowIssuerMismatch is set.
+// If context.ReplaceProofsOfType is set, the existing proofs of context.SignatureType are dropped.
+//
+// Building the proof involves no randomness: given the same credential, context.Created and a deterministic
+// signature, the same proof is produced, e.g. for reproducible test fixtures. Ed25519Signature2018,
+// Ed25519Signature2020 and JsonWebSignature2020 with Ed25519 keys are deterministic; ECDSA based suites
+// (EcdsaSecp256k1Signature2019, JsonWebSignature2020 with EC keys) and BbsBlsSignature2020 are not.
+// If context.Created is not set, the current time is used.
 func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonldOpts ...processor.Opts) error {
-	vcBytes, err := vc.MarshalJSON()
+	if !context.AllowIssuerMismatch {
+		if err := vc.checkVerificationMethodIssuer(context.VerificationMethod); err != nil {
+			return fmt.Errorf("add linked data proof to VC: %w", err)
+		}
+	}
+
+	if err := vc.checkProofContext(context.SignatureType); err != nil {
+		return fmt.Errorf("add linked data proof to VC: %w", err)
+	}
+
+	vcCopy := *vc
+
+	if context.ReplaceProofsOfType {
+		vcCopy.Proofs = proofsNotOfType(vc.Proofs, context.SignatureType)
+	}
+
+	vcBytes, err := vcCopy.MarshalJSON()
 	if err != nil {
 		return fmt.Errorf("add linked data proof to VC: %w", err)
 	}
@@ -27,3 +52,74 @@ func (vc *Credential) AddLinkedDataProof(context *LinkedDataProofContext, jsonld
 
 	return nil
 }
+
+// ReSign removes all the proofs of the Verifiable Credential and signs it with a fresh linked data proof,
+// e.g. after the credential was modified and its existing proofs are no longer valid.
+func (vc *Credential) ReSign(context *LinkedDataProofContext, jsonldOpts ...processor.Opts) error {
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+
+	if err := vcCopy.AddLinkedDataProof(context, jsonldOpts...); err != nil {
+		return fmt.Errorf("re-sign VC: %w", err)
+	}
+
+	vc.Proofs = vcCopy.Proofs
+
+	return nil
+}
+
+// ProofVerificationMethods returns verification methods referenced by the proofs of the Verifiable Credential,
+// deduplicated and in order of their first occurrence, e.g. to prefetch the keys before verification.
+func (vc *Credential) ProofVerificationMethods() []string {
+	var methods []string
+
+	seen := make(map[string]struct{})
+
+	for _, proof := range vc.Proofs {
+		vm, ok := proof["verificationMethod"].(string)
+		if !ok || vm == "" {
+			continue
+		}
+
+		if _, ok = seen[vm]; ok {
+			continue
+		}
+
+		seen[vm] = struct{}{}
+
+		methods = append(methods, vm)
+	}
+
+	return methods
+}
+
+func (vc *Credential) checkVerificationMethodIssuer(verificationMethod string) error {
+	if verificationMethod == "" || !strings.HasPrefix(vc.Issuer.ID, "did:") {
+		return nil
+	}
+
+	vmDID := strings.Split(verificationMethod, "#")[0]
+
+	// relative verification method, e.g. "#key-1", is resolved against the issuer DID
+	if vmDID != "" && vmDID != vc.Issuer.ID {
+		return fmt.Errorf("verification method DID %s does not match issuer DID %s", vmDID, vc.Issuer.ID)
+	}
+
+	return nil
+}
+
+// checkProofContext checks that VC declares the @context defining the terms of the proof of signatureType, which
+// otherwise are dropped by canonicalization making the proof unverifiable.
+func (vc *Credential) checkProofContext(signatureType string) error {
+	if signatureType != bbsBlsSignature2020 {
+		return nil
+	}
+
+	for _, c := range vc.Context {
+		if c == bbsContext {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s proof requires %s @context", signatureType, bbsContext)
+}
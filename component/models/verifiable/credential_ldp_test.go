@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/google/uuid"
@@ -25,7 +26,9 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/kms/localkms"
 	ldcontext "github.com/hyperledger/aries-framework-go/component/models/ld/context"
+	lddocloader "github.com/hyperledger/aries-framework-go/component/models/ld/documentloader"
 	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	sigsigner "github.com/hyperledger/aries-framework-go/component/models/signature/signer"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/bbsblssignature2020"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/bbsblssignatureproof2020"
@@ -33,6 +36,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2020"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/jsonwebsignature2020"
+	sigutil "github.com/hyperledger/aries-framework-go/component/models/signature/util"
 	sigverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
@@ -53,6 +57,7 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(validCredential))
@@ -71,6 +76,191 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2018(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialWithExpectedChallenge(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	createVC := func(challenge string) []byte {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      vc.Issuer.ID + "#key1",
+			Challenge:               challenge,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+
+		vcBytes, err := json.Marshal(vc)
+		r.NoError(err)
+
+		return vcBytes
+	}
+
+	opts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithCredExpectedChallenge("challenge-1"),
+	}
+
+	t.Run("challenge matches", func(t *testing.T) {
+		vc, err := parseTestCredential(t, createVC("challenge-1"), opts...)
+		r.NoError(err)
+		r.Equal("challenge-1", vc.Proofs[0]["challenge"])
+	})
+
+	t.Run("challenge mismatches", func(t *testing.T) {
+		vc, err := parseTestCredential(t, createVC("challenge-2"), opts...)
+		r.Error(err)
+		r.Contains(err.Error(), "proof challenge challenge-2 does not match expected one")
+		r.Nil(vc)
+	})
+
+	t.Run("challenge is absent", func(t *testing.T) {
+		vc, err := parseTestCredential(t, createVC(""), opts...)
+		r.Error(err)
+		r.Contains(err.Error(), "proof challenge is missing")
+		r.Nil(vc)
+	})
+}
+
+func TestParseCredentialFromLinkedDataProof_RejectFutureProofs(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	createVC := func(created time.Time) []byte {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   sigSuite,
+			VerificationMethod:      vc.Issuer.ID + "#key1",
+			Created:                 &created,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	opts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithRejectFutureProofs(time.Minute),
+	}
+
+	t.Run("created near now", func(t *testing.T) {
+		_, err := parseTestCredential(t, createVC(time.Now()), opts...)
+		require.NoError(t, err)
+	})
+
+	t.Run("created in the future within skew", func(t *testing.T) {
+		_, err := parseTestCredential(t, createVC(time.Now().Add(30*time.Second)), opts...)
+		require.NoError(t, err)
+	})
+
+	t.Run("created in the far future", func(t *testing.T) {
+		vcBytes := createVC(time.Now().Add(time.Hour))
+
+		_, err := parseTestCredential(t, vcBytes, opts...)
+		require.ErrorContains(t, err, "is in the future")
+
+		_, err = parseTestCredential(t, vcBytes, opts[:2]...)
+		require.NoError(t, err)
+	})
+}
+
+func TestParseCredentialFromLinkedDataProof_UnknownProofPolicy(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      vc.Issuer.ID + "#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	unknownProof := Proof{
+		"type":               "UnknownSignature2099",
+		"created":            "2023-01-01T00:00:00Z",
+		"proofPurpose":       "assertionMethod",
+		"verificationMethod": vc.Issuer.ID + "#key2",
+		"proofValue":         "z123",
+	}
+
+	vc.Proofs = append(vc.Proofs, unknownProof)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	vc.Proofs = []Proof{unknownProof}
+
+	unknownOnlyBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	fetcher := WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	t.Run("error (default)", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, fetcher)
+		require.ErrorContains(t, err, "unsupported proof type: UnknownSignature2099")
+
+		_, err = parseTestCredential(t, vcBytes, fetcher, WithUnknownProofPolicy(UnknownProofError))
+		require.ErrorContains(t, err, "unsupported proof type: UnknownSignature2099")
+	})
+
+	for name, policy := range map[string]UnknownProofPolicy{"skip": UnknownProofSkip, "warn": UnknownProofWarn} {
+		policy := policy
+
+		t.Run(name, func(t *testing.T) {
+			vcParsed, err := parseTestCredential(t, vcBytes, fetcher, WithUnknownProofPolicy(policy))
+			require.NoError(t, err)
+			require.Len(t, vcParsed.Proofs, 2)
+
+			vcParsed, err = parseTestCredential(t, vcBytes, fetcher, WithUnknownProofPolicy(policy),
+				WithEmbeddedSignatureSuites(sigSuite))
+			require.NoError(t, err)
+			require.Len(t, vcParsed.Proofs, 2)
+
+			_, err = parseTestCredential(t, unknownOnlyBytes, fetcher, WithUnknownProofPolicy(policy))
+			require.ErrorContains(t, err, "no proof of supported type")
+		})
+	}
+
+	t.Run("known proof is still verified", func(t *testing.T) {
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes, WithUnknownProofPolicy(UnknownProofSkip),
+			WithPublicKeyFetcher(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)))
+		require.ErrorContains(t, err, "check embedded proof")
+	})
+}
+
 func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
 	r := require.New(t)
 
@@ -86,6 +276,7 @@ func TestParseCredentialFromLinkedDataProof_Ed25519Signature2020(t *testing.T) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(validCredential))
@@ -253,6 +444,7 @@ func TestParseCredentialFromLinkedDataProof_JSONLD_Validation(t *testing.T) {
       "@version": 1.1,
       "xsd": "http://www.w3.org/2001/XMLSchema#",
       "schema": "http://schema.org/",
+      "BillOfLadingCredential": "schema:BillOfLadingCredential",
       "comments": "schema:text"
   }
 }
@@ -399,6 +591,7 @@ func TestExtraContextWithLDP(t *testing.T) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(vcJSON))
@@ -503,6 +696,7 @@ func TestParseCredentialFromLinkedDataProof_BbsBlsSignature2020(t *testing.T) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vcJSON := `
@@ -645,6 +839,7 @@ func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_Ed25519(t *test
 		SignatureRepresentation: SignatureJWS,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(validCredential))
@@ -663,6 +858,70 @@ func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_Ed25519(t *test
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_DefaultSuite(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyType kms.KeyType
+		alg     string
+	}{
+		{name: "P-256", keyType: kms.ECDSAP256TypeIEEEP1363, alg: "ES256"},
+		{name: "P-384", keyType: kms.ECDSAP384TypeIEEEP1363, alg: "ES384"},
+		{name: "Ed25519", keyType: kms.ED25519Type, alg: "EdDSA"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			vc, err := parseTestCredential(t, []byte(validCredential))
+			require.NoError(t, err)
+
+			err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "JsonWebSignature2020",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   jsonwebsignature2020.New(suite.WithSigner(signer)),
+				VerificationMethod:      "did:example:123456#key1",
+				AllowIssuerMismatch:     true,
+			}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+			require.NoError(t, err)
+
+			// detached JWS with alg inferred from the key type
+			jws, ok := vc.Proofs[0]["jws"].(string)
+			require.True(t, ok)
+
+			jwsParts := strings.Split(jws, ".")
+			require.Len(t, jwsParts, 3)
+			require.Empty(t, jwsParts[1])
+
+			headerBytes, err := base64.RawURLEncoding.DecodeString(jwsParts[0])
+			require.NoError(t, err)
+
+			var header map[string]interface{}
+
+			require.NoError(t, json.Unmarshal(headerBytes, &header))
+			require.Equal(t, tc.alg, header["alg"])
+
+			vcBytes, err := json.Marshal(vc)
+			require.NoError(t, err)
+
+			j, err := jwksupport.JWKFromKey(signer.PublicKey())
+			require.NoError(t, err)
+
+			vcWithLdp, err := parseTestCredential(t, vcBytes,
+				WithPublicKeyFetcher(func(issuerID, keyID string) (*sigverifier.PublicKey, error) {
+					return &sigverifier.PublicKey{
+						Type:  "JsonWebKey2020",
+						Value: signer.PublicKeyBytes(),
+						JWK:   j,
+					}, nil
+				}))
+			require.NoError(t, err)
+			require.Equal(t, vc, vcWithLdp)
+		})
+	}
+}
+
 func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_ecdsaP256(t *testing.T) {
 	r := require.New(t)
 
@@ -681,6 +940,7 @@ func TestParseCredentialFromLinkedDataProof_JsonWebSignature2020_ecdsaP256(t *te
 		SignatureRepresentation: SignatureJWS,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(validCredential))
@@ -725,6 +985,7 @@ func TestParseCredentialFromLinkedDataProof_EcdsaSecp256k1Signature2019(t *testi
 		SignatureRepresentation: SignatureJWS,
 		Suite:                   sigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}
 
 	vc, err := parseTestCredential(t, []byte(validCredential))
@@ -778,6 +1039,7 @@ func TestParseCredential_JSONLiteralsNotSupported(t *testing.T) {
       "@type": "@id"
     },
     "hetc": "http://localhost:9393/cmtr#",
+    "CertifiedMillTestReport": "hetc:CertifiedMillTestReport",
     "cmtr": {
       "@id": "hetc:cmtr",
       "@type": "@json"
@@ -995,24 +1257,36 @@ func TestParseCredential_JSONLiteralsNotSupported(t *testing.T) {
       ]
     },
     "id": "did:key:z6MkjRagNiMu91DduvCvgEsqLZDVzrJzFrwahc4tXLt9DoHd"
-  },
-  "proof": {
-    "type": "Ed25519Signature2018",
-    "created": "2020-05-14T15:22:26.065935+03:00",
-    "verificationMethod": "did:example:123456#key1",
-    "proofPurpose": "assertionMethod",
-    "jws": "eyJhbGciOiJFZERTQSIsImI2NCI6ZmFsc2UsImNyaXQiOlsiYjY0Il19..dmLYprMM4-E9XnEsd6iQHvmrgeC8pKe2liEKcSAu53A7Ok6LjognKQKLNdJSLsJd8cGh2g15ZTu6BKAnp2v7AQ"
   }
 }`
 
-	publicKeyBytes := base58.Decode("At4yQndGdrJs5AVFjYXqwDRALfm3ghLAmzhLux5eJkhh")
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
 
-	localCrypto, err := createLocalCrypto()
+	unsignedVC, err := ParseCredential([]byte(vcJSON),
+		WithDisabledProofCheck(),
+		WithJSONLDDocumentLoader(docLoader))
 	require.NoError(t, err)
-	vc, err := ParseCredential([]byte(vcJSON),
-		WithPublicKeyFetcher(SingleKey(publicKeyBytes, "Ed25519Signature2018")),
-		WithEmbeddedSignatureSuites(ed25519signature2018.New(
-			suite.WithVerifier(suite.NewCryptoVerifier(localCrypto)))),
+
+	err = unsignedVC.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   sigSuite,
+		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
+	}, jsonldsig.WithDocumentLoader(docLoader))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(unsignedVC)
+	require.NoError(t, err)
+
+	vc, err := ParseCredential(vcBytes,
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), "Ed25519Signature2018")),
+		WithEmbeddedSignatureSuites(sigSuite),
 		WithJSONLDOnlyValidRDF(),
 		WithStrictValidation(),
 		WithJSONLDDocumentLoader(docLoader))
@@ -1087,6 +1361,7 @@ func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 		SignatureRepresentation: SignatureProofValue,
 		Suite:                   ed25519SigSuite,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
 	r.NoError(err)
 
@@ -1102,6 +1377,7 @@ func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 		SignatureRepresentation: SignatureJWS,
 		Suite:                   ecdsaSigSuite,
 		VerificationMethod:      "did:example:123456#key2",
+		AllowIssuerMismatch:     true,
 	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
 	r.NoError(err)
 
@@ -1136,6 +1412,111 @@ func TestParseCredentialWithSeveralLinkedDataProofs(t *testing.T) {
 	r.Equal(vc, vcWithLdp)
 }
 
+func TestCredential_ProofVerificationMethods(t *testing.T) {
+	r := require.New(t)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+	r.Empty(vc.ProofVerificationMethods())
+
+	vc.Proofs = []Proof{
+		{"type": "Ed25519Signature2018", "verificationMethod": "did:example:123456#key1"},
+		{"type": "JsonWebSignature2020", "verificationMethod": "did:example:123456#key2"},
+		{"type": "Ed25519Signature2020", "verificationMethod": "did:example:123456#key1"},
+		{"type": "BbsBlsSignature2020"},
+	}
+
+	r.Equal([]string{"did:example:123456#key1", "did:example:123456#key2"}, vc.ProofVerificationMethods())
+}
+
+func TestParseCredentialWithProofVerificationMode(t *testing.T) {
+	r := require.New(t)
+
+	issuer1, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	issuer2, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	other, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	for i, signer := range []sigutil.Signer{issuer1, issuer2} {
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      fmt.Sprintf("%s#key%d", vc.Issuer.ID, i+1),
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+	}
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	keyFetcher := func(keys map[string]sigutil.Signer) CredentialOpt {
+		return WithPublicKeyFetcher(func(_, keyID string) (*sigverifier.PublicKey, error) {
+			return &sigverifier.PublicKey{Type: kms.ED25519, Value: keys[keyID].PublicKeyBytes()}, nil
+		})
+	}
+
+	// key2 resolves to the key of issuer1, so the proof made by issuer2 is invalid.
+	mixedValidity := keyFetcher(map[string]sigutil.Signer{"#key1": issuer1, "#key2": issuer1})
+	allValid := keyFetcher(map[string]sigutil.Signer{"#key1": issuer1, "#key2": issuer2})
+	allInvalid := keyFetcher(map[string]sigutil.Signer{"#key1": other, "#key2": other})
+
+	t.Run("fail fast by default", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes, mixedValidity)
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+		r.Nil(vcWithLdp)
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes, mixedValidity, WithProofVerificationMode(FailFast))
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+		r.Nil(vcWithLdp)
+
+		vcWithLdp, err = parseTestCredential(t, vcBytes, allValid, WithProofVerificationMode(FailFast))
+		r.NoError(err)
+		r.Len(vcWithLdp.Proofs, 2)
+	})
+
+	t.Run("require all returns per-proof results", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes, mixedValidity, WithProofVerificationMode(RequireAll))
+		r.Error(err)
+		r.Contains(err.Error(), "1 of 2 proofs are invalid")
+		r.Nil(vcWithLdp)
+
+		var proofsErr *ProofVerificationError
+
+		r.True(errors.As(err, &proofsErr))
+		r.Len(proofsErr.Results, 2)
+		r.NoError(proofsErr.Results[0].Err)
+		r.Error(proofsErr.Results[1].Err)
+		r.Equal(vc.Issuer.ID+"#key2", proofsErr.Results[1].Proof.VerificationMethod)
+
+		vcWithLdp, err = parseTestCredential(t, vcBytes, allValid, WithProofVerificationMode(RequireAll))
+		r.NoError(err)
+		r.Len(vcWithLdp.Proofs, 2)
+	})
+
+	t.Run("at least one", func(t *testing.T) {
+		vcWithLdp, err := parseTestCredential(t, vcBytes, mixedValidity, WithProofVerificationMode(AtLeastOne))
+		r.NoError(err)
+		r.Len(vcWithLdp.Proofs, 2)
+
+		vcWithLdp, err = parseTestCredential(t, vcBytes, allInvalid, WithProofVerificationMode(AtLeastOne))
+		r.Error(err)
+		r.Contains(err.Error(), "0 of 2 proofs are valid while 1 are required")
+		r.Nil(vcWithLdp)
+	})
+}
+
 func createLocalCrypto() (*LocalCrypto, error) {
 	lKMS, err := createKMS()
 	if err != nil {
@@ -1208,6 +1589,66 @@ func mapJWKToKMSKeyType(j *jwk.JWK) (kms.KeyType, error) {
 	return "", fmt.Errorf("unsupported JWK: %v", j)
 }
 
+func TestCredential_ReSign(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	ldpContext := &LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      vc.Issuer.ID + "#key-1",
+	}
+
+	err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	// the proof is stale once the credential is modified
+	vc.ID = "http://example.edu/credentials/1873"
+
+	parseOpts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+	}
+
+	vcBytes, err := json.Marshal(vc)
+	r.NoError(err)
+
+	_, err = parseTestCredential(t, vcBytes, parseOpts...)
+	r.Error(err)
+
+	err = vc.ReSign(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+	r.Len(vc.Proofs, 1)
+
+	vcBytes, err = json.Marshal(vc)
+	r.NoError(err)
+
+	vcReSigned, err := parseTestCredential(t, vcBytes, parseOpts...)
+	r.NoError(err)
+	r.Len(vcReSigned.Proofs, 1)
+	r.Equal("http://example.edu/credentials/1873", vcReSigned.ID)
+
+	t.Run("failure keeps existing proofs", func(t *testing.T) {
+		err = vc.ReSign(&LinkedDataProofContext{
+			Suite:                   sigSuite,
+			SignatureRepresentation: SignatureProofValue,
+		})
+		r.Error(err)
+		r.Contains(err.Error(), "re-sign VC")
+		r.Len(vc.Proofs, 1)
+	})
+}
+
 func TestCredential_AddLinkedDataProof(t *testing.T) {
 	r := require.New(t)
 
@@ -1226,6 +1667,7 @@ func TestCredential_AddLinkedDataProof(t *testing.T) {
 			SignatureRepresentation: SignatureJWS,
 			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
 			VerificationMethod:      "did:example:xyz#key-1",
+			AllowIssuerMismatch:     true,
 			Challenge:               uuid.New().String(),
 			Domain:                  "issuer.service.com",
 			Purpose:                 "authentication",
@@ -1253,6 +1695,63 @@ func TestCredential_AddLinkedDataProof(t *testing.T) {
 		r.Equal(originalVCMap, vcMap)
 	})
 
+	t.Run("Add Linked Data proof with verification method of the issuer", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      vc.Issuer.ID + "#key-1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 1)
+		r.Equal(vc.Issuer.ID+"#key-1", vc.Proofs[0]["verificationMethod"])
+	})
+
+	t.Run("Add Linked Data proof with verification method of other DID", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:xyz#key-1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.Error(err)
+		r.EqualError(err, "add linked data proof to VC: verification method DID did:example:xyz "+
+			"does not match issuer DID did:example:76e12ec712ebc6f1c221ebfeb1f")
+		r.Empty(vc.Proofs)
+	})
+
+	t.Run("Replace existing proofs of the same type", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		ldpContext := &LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      vc.Issuer.ID + "#key-1",
+		}
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+
+		vc.Proofs = append(vc.Proofs, Proof{"type": "Ed25519Signature2020"})
+
+		ldpContext.ReplaceProofsOfType = true
+		ldpContext.VerificationMethod = vc.Issuer.ID + "#key-2"
+
+		err = vc.AddLinkedDataProof(ldpContext, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		r.NoError(err)
+		r.Len(vc.Proofs, 2)
+		r.Equal("Ed25519Signature2020", vc.Proofs[0]["type"])
+		r.Equal(vc.Issuer.ID+"#key-2", vc.Proofs[1]["verificationMethod"])
+	})
+
 	t.Run("Add invalid Linked Data proof to VC", func(t *testing.T) {
 		vc, err := parseTestCredential(t, []byte(validCredential))
 		require.NoError(t, err)
@@ -1288,6 +1787,7 @@ func TestCredential_AddLinkedDataProof(t *testing.T) {
 			SignatureRepresentation: SignatureJWS,
 			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
 			VerificationMethod:      "did:example:xyz#key-1",
+			AllowIssuerMismatch:     true,
 			Challenge:               uuid.New().String(),
 			Domain:                  "issuer.service.com",
 			Purpose:                 "capabilityDelegation",
@@ -1360,3 +1860,85 @@ func (s *bbsSigner) textToLines(txt string) [][]byte {
 
 	return linesBytes
 }
+
+func TestCredential_AddLinkedDataProof_Deterministic(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	loader := createTestDocumentLoader(t)
+	created := time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		signatureType string
+		suite         sigsigner.SignatureSuite
+	}{
+		{"Ed25519Signature2018", ed25519signature2018.New(suite.WithSigner(signer))},
+		{"JsonWebSignature2020", jsonwebsignature2020.New(suite.WithSigner(signer))},
+	} {
+		t.Run(tc.signatureType, func(t *testing.T) {
+			addProof := func() Proof {
+				vc, err := parseTestCredential(t, []byte(validCredential))
+				require.NoError(t, err)
+
+				require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+					SignatureType:           tc.signatureType,
+					SignatureRepresentation: SignatureJWS,
+					Suite:                   tc.suite,
+					Created:                 &created,
+					VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+				}, jsonldsig.WithDocumentLoader(loader)))
+				require.Len(t, vc.Proofs, 1)
+
+				return vc.Proofs[0]
+			}
+
+			require.Equal(t, addProof(), addProof())
+		})
+	}
+}
+
+func TestWithJSONLDDocumentLoader_ContextNotFound(t *testing.T) {
+	vcWithUnknownContext := strings.Replace(validCredential, `"https://www.w3.org/2018/credentials/examples/v1"`,
+		`"https://www.w3.org/2018/credentials/examples/v1", "https://unknown.example.com/context/v1"`, 1)
+
+	loader := createTestDocumentLoader(t)
+
+	t.Run("parse credential", func(t *testing.T) {
+		_, err := ParseCredential([]byte(vcWithUnknownContext), WithJSONLDDocumentLoader(loader),
+			WithDisabledProofCheck())
+		require.ErrorIs(t, err, lddocloader.ErrContextNotFound)
+	})
+
+	t.Run("add linked data proof", func(t *testing.T) {
+		vc, err := ParseCredential([]byte(vcWithUnknownContext), WithCredDisableValidation(),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonldsig.WithDocumentLoader(loader))
+		require.ErrorIs(t, err, lddocloader.ErrContextNotFound)
+	})
+
+	t.Run("parse presentation", func(t *testing.T) {
+		vc, err := ParseCredential([]byte(vcWithUnknownContext), WithCredDisableValidation(),
+			WithDisabledProofCheck())
+		require.NoError(t, err)
+
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		_, err = ParsePresentation(vpBytes, WithPresJSONLDDocumentLoader(loader), WithPresDisabledProofCheck(),
+			WithPresStrictValidation())
+		require.ErrorIs(t, err, lddocloader.ErrContextNotFound)
+	})
+}
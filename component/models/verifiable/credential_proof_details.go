@@ -0,0 +1,127 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWTProofType is the ProofDetail type reported for the signature of a JWT credential.
+const JWTProofType = "JWT"
+
+// ProofDetail describes a single proof securing a Verifiable Credential.
+type ProofDetail struct {
+	// Type is the proof type, e.g. "Ed25519Signature2018", or JWTProofType for a JWT credential.
+	Type string
+	// Created is the proof creation time if it is known.
+	Created *time.Time
+	// VerificationMethod references the key the proof was created with.
+	VerificationMethod string
+	// ProofPurpose is the purpose of the proof, e.g. "assertionMethod".
+	ProofPurpose string
+}
+
+// ProofDetails returns the details of the proofs securing the Verifiable Credential, in order.
+// For a JWT credential a single detail is returned, with the verification method taken
+// from the "kid" header of the JWT and resolved against the issuer when it is a relative reference.
+func (vc *Credential) ProofDetails() []ProofDetail {
+	if vc.JWT != "" {
+		return []ProofDetail{vc.jwtProofDetail()}
+	}
+
+	details := make([]ProofDetail, 0, len(vc.Proofs))
+
+	for _, proof := range vc.Proofs {
+		var detail ProofDetail
+
+		detail.Type, _ = proof["type"].(string)
+		detail.VerificationMethod, _ = proof["verificationMethod"].(string)
+		detail.ProofPurpose, _ = proof["proofPurpose"].(string)
+
+		if createdStr, ok := proof["created"].(string); ok {
+			if created, err := time.Parse(time.RFC3339Nano, createdStr); err == nil {
+				detail.Created = &created
+			}
+		}
+
+		details = append(details, detail)
+	}
+
+	return details
+}
+
+// NoProof reports whether the Verifiable Credential is unsigned, i.e. it is neither a JWT nor has embedded proofs.
+// A credential parsed with an empty "proof" object or array is unsigned as well.
+func (vc *Credential) NoProof() bool {
+	return vc.JWT == "" && len(vc.Proofs) == 0
+}
+
+func (vc *Credential) jwtProofDetail() ProofDetail {
+	detail := ProofDetail{
+		Type:         JWTProofType,
+		ProofPurpose: assertionMethod,
+	}
+
+	if vc.Issued != nil {
+		created := vc.Issued.Time
+		detail.Created = &created
+	}
+
+	joseHeaders, _, err := decodeCredJWS(vc.JWT, false, nil)
+	if err != nil {
+		return detail
+	}
+
+	kid, _ := joseHeaders.KeyID()
+
+	if strings.HasPrefix(kid, "#") && vc.Issuer.ID != "" {
+		kid = vc.Issuer.ID + kid
+	}
+
+	detail.VerificationMethod = kid
+
+	return detail
+}
+
+// VerifyProof re-verifies the proofs of an already parsed Verifiable Credential using the given
+// public key fetcher, without parsing the credential again. Options such as WithJSONLDDocumentLoader
+// or WithEmbeddedSignatureSuites are honoured for linked data proofs; WithDisabledProofCheck is ignored.
+func (vc *Credential) VerifyProof(fetcher PublicKeyFetcher, opts ...CredentialOpt) error {
+	if fetcher == nil {
+		return errors.New("verify proof: public key fetcher is not defined")
+	}
+
+	if vc.JWT != "" {
+		if _, _, err := decodeCredJWS(vc.JWT, true, fetcher); err != nil {
+			return fmt.Errorf("verify proof: %w", err)
+		}
+
+		return nil
+	}
+
+	if len(vc.Proofs) == 0 {
+		return errors.New("verify proof: credential has no proof")
+	}
+
+	vcBytes, err := vc.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("verify proof: marshal credential: %w", err)
+	}
+
+	vcOpts := getCredentialOpts(opts)
+	vcOpts.publicKeyFetcher = fetcher
+	vcOpts.disabledProofCheck = false
+
+	if err = checkEmbeddedProof(vcBytes, getEmbeddedProofCheckOpts(vcOpts)); err != nil {
+		return fmt.Errorf("verify proof: %w", err)
+	}
+
+	return nil
+}
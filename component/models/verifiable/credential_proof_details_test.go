@@ -0,0 +1,91 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestCredential_ProofDetails(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	fetcher := SingleKey(signer.PublicKeyBytes(), kms.ED25519)
+
+	t.Run("linked data proof", func(t *testing.T) {
+		loader := createTestDocumentLoader(t)
+		created := time.Date(2023, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+		require.Empty(t, vc.ProofDetails())
+		require.True(t, vc.NoProof())
+
+		require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			Created:                 &created,
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+		}, jsonldsig.WithDocumentLoader(loader)))
+
+		require.False(t, vc.NoProof())
+
+		details := vc.ProofDetails()
+		require.Len(t, details, 1)
+		require.Equal(t, "Ed25519Signature2018", details[0].Type)
+		require.Equal(t, "did:example:76e12ec712ebc6f1c221ebfeb1f#key1", details[0].VerificationMethod)
+		require.Equal(t, "assertionMethod", details[0].ProofPurpose)
+		require.NotNil(t, details[0].Created)
+		require.True(t, created.Equal(*details[0].Created))
+
+		require.NoError(t, vc.VerifyProof(fetcher, WithJSONLDDocumentLoader(loader)))
+
+		vc.Issuer.ID = "did:example:other"
+		require.ErrorContains(t, vc.VerifyProof(fetcher, WithJSONLDDocumentLoader(loader)), "verify proof")
+	})
+
+	t.Run("JWT proof", func(t *testing.T) {
+		vc, err := parseTestCredential(t, createEdDSAJWS(t, []byte(validCredential), signer, false),
+			WithPublicKeyFetcher(fetcher))
+		require.NoError(t, err)
+
+		require.False(t, vc.NoProof())
+
+		details := vc.ProofDetails()
+		require.Len(t, details, 1)
+		require.Equal(t, JWTProofType, details[0].Type)
+		require.Equal(t, vc.Issuer.ID+"#keys-"+keyID, details[0].VerificationMethod)
+		require.Equal(t, "assertionMethod", details[0].ProofPurpose)
+		require.NotNil(t, details[0].Created)
+		require.True(t, vc.Issued.Time.Equal(*details[0].Created))
+
+		require.NoError(t, vc.VerifyProof(fetcher))
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		require.ErrorContains(t,
+			vc.VerifyProof(SingleKey(otherSigner.PublicKeyBytes(), kms.ED25519)), "verify proof")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		require.EqualError(t, vc.VerifyProof(nil), "verify proof: public key fetcher is not defined")
+		require.EqualError(t, vc.VerifyProof(fetcher), "verify proof: credential has no proof")
+	})
+}
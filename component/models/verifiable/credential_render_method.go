@@ -0,0 +1,75 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// RenderMethodTerm is the credential member describing how to display the credential.
+	RenderMethodTerm = "renderMethod"
+
+	// SvgRenderingTemplateType is a render method referencing an SVG template by its id, or embedding it
+	// in the "template" member.
+	SvgRenderingTemplateType = "SvgRenderingTemplate2023"
+
+	// OverlayCaptureBundleType is a render method referencing an Overlays Capture Architecture (OCA) bundle
+	// by its id.
+	OverlayCaptureBundleType = "OverlayCaptureBundleV1"
+)
+
+// RenderMethods returns the render methods of the credential, used by wallets to fetch display templates.
+// It returns nil if the credential has no renderMethod or it is malformed; use ValidateRenderMethods to find out why.
+func (vc *Credential) RenderMethods() []TypedID {
+	renderMethods, err := vc.renderMethods()
+	if err != nil {
+		return nil
+	}
+
+	return renderMethods
+}
+
+// ValidateRenderMethods checks that every render method of the credential has a type, and that render methods
+// of known types (SvgRenderingTemplateType, OverlayCaptureBundleType) define the members they require.
+// Render methods of other types are not validated further.
+func (vc *Credential) ValidateRenderMethods() error {
+	renderMethods, err := vc.renderMethods()
+	if err != nil {
+		return err
+	}
+
+	for i, rm := range renderMethods {
+		if err := validateRenderMethod(rm); err != nil {
+			return fmt.Errorf("invalid render method %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (vc *Credential) renderMethods() ([]TypedID, error) {
+	return vc.typedIDsFromCustomField(RenderMethodTerm)
+}
+
+func validateRenderMethod(rm TypedID) error {
+	switch rm.Type {
+	case "":
+		return errors.New("type is not defined")
+	case SvgRenderingTemplateType:
+		if rm.ID == "" && rm.CustomFields["template"] == nil {
+			return fmt.Errorf("%s must define id or template", rm.Type)
+		}
+	case OverlayCaptureBundleType:
+		if rm.ID == "" {
+			return fmt.Errorf("%s must define id", rm.Type)
+		}
+	}
+
+	return nil
+}
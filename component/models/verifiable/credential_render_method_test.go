@@ -0,0 +1,103 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredential_RenderMethods(t *testing.T) {
+	newVCBytes := func(t *testing.T, renderMethod interface{}) []byte {
+		t.Helper()
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap[RenderMethodTerm] = renderMethod
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("decode render methods", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, []interface{}{
+			map[string]interface{}{
+				"id":             "https://example.edu/credentials/degree.svg",
+				"type":           SvgRenderingTemplateType,
+				"name":           "Portrait",
+				"css3MediaQuery": "@media (orientation: portrait)",
+			},
+			map[string]interface{}{
+				"id":   "https://example.edu/oca/degree.zip",
+				"type": OverlayCaptureBundleType,
+			},
+		}))
+		require.NoError(t, err)
+		require.NoError(t, vc.ValidateRenderMethods())
+
+		renderMethods := vc.RenderMethods()
+		require.Len(t, renderMethods, 2)
+		require.Equal(t, "https://example.edu/credentials/degree.svg", renderMethods[0].ID)
+		require.Equal(t, SvgRenderingTemplateType, renderMethods[0].Type)
+		require.Equal(t, "Portrait", renderMethods[0].CustomFields["name"])
+		require.Equal(t, OverlayCaptureBundleType, renderMethods[1].Type)
+
+		// renderMethod survives the round trip
+		vcBytes, err := json.Marshal(vc)
+		require.NoError(t, err)
+
+		vc, err = parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Len(t, vc.RenderMethods(), 2)
+	})
+
+	t.Run("single render method with embedded template", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, map[string]interface{}{
+			"type":     SvgRenderingTemplateType,
+			"template": "<svg></svg>",
+		}))
+		require.NoError(t, err)
+		require.NoError(t, vc.ValidateRenderMethods())
+		require.Len(t, vc.RenderMethods(), 1)
+	})
+
+	t.Run("no render method", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+		require.Nil(t, vc.RenderMethods())
+		require.NoError(t, vc.ValidateRenderMethods())
+	})
+
+	t.Run("invalid render methods", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, map[string]interface{}{"type": SvgRenderingTemplateType}))
+		require.NoError(t, err)
+		require.EqualError(t, vc.ValidateRenderMethods(),
+			"invalid render method 0: SvgRenderingTemplate2023 must define id or template")
+
+		vc, err = parseTestCredential(t, newVCBytes(t, []interface{}{
+			map[string]interface{}{"id": "https://example.edu/credentials/degree.svg"},
+		}))
+		require.NoError(t, err)
+		require.EqualError(t, vc.ValidateRenderMethods(), "invalid render method 0: type is not defined")
+
+		vc, err = parseTestCredential(t, newVCBytes(t, map[string]interface{}{"type": OverlayCaptureBundleType}))
+		require.NoError(t, err)
+		require.EqualError(t, vc.ValidateRenderMethods(),
+			"invalid render method 0: OverlayCaptureBundleV1 must define id")
+
+		vc, err = parseTestCredential(t, newVCBytes(t, "https://example.edu/credentials/degree.svg"))
+		require.NoError(t, err)
+		require.Nil(t, vc.RenderMethods())
+		require.ErrorContains(t, vc.ValidateRenderMethods(), "parse renderMethod")
+	})
+}
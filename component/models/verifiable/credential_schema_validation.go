@@ -0,0 +1,128 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaDocumentLoader loads JSON Schema documents referenced by credentialSchema of a credential.
+// CredentialSchemaLoader is a SchemaDocumentLoader downloading the schemas over HTTP.
+type SchemaDocumentLoader interface {
+	// LoadSchema returns the JSON Schema document located at url.
+	LoadSchema(url string) ([]byte, error)
+}
+
+// SchemaLoadError is returned when a credential schema can't be loaded, e.g. its URL is unreachable.
+type SchemaLoadError struct {
+	SchemaID string
+	Err      error
+}
+
+func (e *SchemaLoadError) Error() string {
+	return fmt.Sprintf("load credential schema %s: %v", e.SchemaID, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *SchemaLoadError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaFieldError describes a field of credentialSubject which does not conform to a credential schema.
+type SchemaFieldError struct {
+	// Field is the dot separated path of the field, e.g. "credentialSubject.degree.type", or "credentialSubject.1.name"
+	// for the second one of several subjects.
+	Field       string
+	Description string
+}
+
+// SchemaValidationError is returned when credentialSubject does not conform to a credential schema.
+type SchemaValidationError struct {
+	SchemaID string
+	Fields   []SchemaFieldError
+}
+
+func (e *SchemaValidationError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		fields[i] = f.Field + ": " + f.Description
+	}
+
+	return fmt.Sprintf("credentialSubject does not conform to credential schema %s: %s",
+		e.SchemaID, strings.Join(fields, "; "))
+}
+
+// WithSchemaValidation option is for validating credentialSubject against each JsonSchemaValidator2018 schema
+// referenced by credentialSchema of the credential, using loader to fetch the schema documents.
+//
+// If a schema can't be loaded, the error wraps *SchemaLoadError. If credentialSubject violates a schema,
+// the error wraps *SchemaValidationError listing the fields which failed.
+//
+// The option replaces the check of the whole credential against its custom JSON schema (see WithNoCustomSchemaCheck),
+// the credential itself is validated against the default schema only.
+func WithSchemaValidation(loader SchemaDocumentLoader) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.subjectSchemaLoader = loader
+	}
+}
+
+func (vc *Credential) validateSubjectSchemas(loader SchemaDocumentLoader) error {
+	var subjects []interface{}
+
+	for _, schema := range vc.Schemas {
+		if schema.Type != jsonSchema2018Type {
+			logger.Warnf("unsupported credential schema: %s. Skipping validation of credentialSubject", schema.Type)
+
+			continue
+		}
+
+		schemaData, err := loader.LoadSchema(schema.ID)
+		if err != nil {
+			return &SchemaLoadError{SchemaID: schema.ID, Err: err}
+		}
+
+		if subjects == nil {
+			if subjects, err = vc.subjectsJSON(); err != nil {
+				return err
+			}
+		}
+
+		schemaLoader := gojsonschema.NewBytesLoader(schemaData)
+
+		var fields []SchemaFieldError
+
+		for i, subject := range subjects {
+			result, err := gojsonschema.Validate(schemaLoader, gojsonschema.NewGoLoader(subject))
+			if err != nil {
+				return fmt.Errorf("validate credentialSubject against credential schema %s: %w", schema.ID, err)
+			}
+
+			prefix := schemaPropertyCredentialSubject
+			if len(subjects) > 1 {
+				prefix = fmt.Sprintf("%s.%d", schemaPropertyCredentialSubject, i)
+			}
+
+			for _, desc := range result.Errors() {
+				field := prefix
+				if desc.Field() != gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+					field += "." + desc.Field()
+				}
+
+				fields = append(fields, SchemaFieldError{Field: field, Description: desc.Description()})
+			}
+		}
+
+		if len(fields) > 0 {
+			return &SchemaValidationError{SchemaID: schema.ID, Fields: fields}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	degreeSchemaURL = "https://example.com/schemas/degree.json"
+	nameSchemaURL   = "https://example.com/schemas/name.json"
+)
+
+const degreeSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["degree"],
+  "properties": {
+    "degree": {
+      "type": "object",
+      "required": ["type", "name"],
+      "properties": {
+        "type": {"type": "string"},
+        "name": {"type": "string"}
+      }
+    }
+  }
+}`
+
+const nameSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "required": ["name"],
+  "properties": {
+    "name": {"type": "string", "minLength": 1}
+  }
+}`
+
+// mockSchemaLoader is a SchemaDocumentLoader backed by in-memory schemas.
+type mockSchemaLoader map[string]string
+
+func (l mockSchemaLoader) LoadSchema(url string) ([]byte, error) {
+	schema, ok := l[url]
+	if !ok {
+		return nil, fmt.Errorf("schema %s not found", url)
+	}
+
+	return []byte(schema), nil
+}
+
+func TestWithSchemaValidation(t *testing.T) {
+	loader := mockSchemaLoader{degreeSchemaURL: degreeSchema, nameSchemaURL: nameSchema}
+
+	newVCBytes := func(t *testing.T, subject interface{}, schemaURLs ...string) []byte {
+		t.Helper()
+
+		var vcMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		schemas := make([]interface{}, len(schemaURLs))
+		for i, url := range schemaURLs {
+			schemas[i] = map[string]interface{}{"id": url, "type": "JsonSchemaValidator2018"}
+		}
+
+		vcMap["credentialSchema"] = schemas
+		vcMap["credentialSubject"] = subject
+
+		delete(vcMap, "credentialStatus")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	validSubject := map[string]interface{}{
+		"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+		"name":   "Jayden Doe",
+		"degree": map[string]interface{}{"type": "BachelorDegree", "name": "Bachelor of Science"},
+	}
+
+	t.Run("subject conforms to all schemas", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes(t, validSubject, degreeSchemaURL, nameSchemaURL),
+			WithSchemaValidation(loader))
+		require.NoError(t, err)
+		require.Len(t, vc.Schemas, 2)
+	})
+
+	t.Run("subject violates a schema", func(t *testing.T) {
+		subject := map[string]interface{}{
+			"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"name":   "Jayden Doe",
+			"degree": map[string]interface{}{"name": 42},
+		}
+
+		vc, err := parseTestCredential(t, newVCBytes(t, subject, nameSchemaURL, degreeSchemaURL),
+			WithSchemaValidation(loader))
+		require.Error(t, err)
+		require.Nil(t, vc)
+
+		var validationErr *SchemaValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, degreeSchemaURL, validationErr.SchemaID)
+		require.ElementsMatch(t, []SchemaFieldError{
+			{Field: "credentialSubject.degree", Description: "type is required"},
+			{Field: "credentialSubject.degree.name", Description: "Invalid type. Expected: string, given: integer"},
+		}, validationErr.Fields)
+		require.Contains(t, err.Error(), "credentialSubject does not conform to credential schema "+degreeSchemaURL)
+
+		// the schemas are not applied to credentialSubject without the option
+		_, err = parseTestCredential(t, newVCBytes(t, subject, nameSchemaURL, degreeSchemaURL),
+			WithNoCustomSchemaCheck())
+		require.NoError(t, err)
+	})
+
+	t.Run("each subject is validated", func(t *testing.T) {
+		subjects := []interface{}{validSubject, map[string]interface{}{"id": "did:example:2", "name": ""}}
+
+		_, err := parseTestCredential(t, newVCBytes(t, subjects, nameSchemaURL),
+			WithSchemaValidation(loader))
+
+		var validationErr *SchemaValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, []SchemaFieldError{
+			{Field: "credentialSubject.1.name", Description: "String length must be greater than or equal to 1"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("unreachable schema", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		schemaURL := server.URL + "/schemas/degree.json"
+
+		_, err := parseTestCredential(t, newVCBytes(t, validSubject, schemaURL),
+			WithSchemaValidation(NewCredentialSchemaLoaderBuilder().Build()))
+		require.Error(t, err)
+
+		var loadErr *SchemaLoadError
+
+		require.True(t, errors.As(err, &loadErr))
+		require.Equal(t, schemaURL, loadErr.SchemaID)
+		require.False(t, errors.As(err, new(*SchemaValidationError)))
+		require.EqualError(t, err, "load credential schema "+schemaURL+
+			": credential schema endpoint HTTP failure [404]")
+	})
+}
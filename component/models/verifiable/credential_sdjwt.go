@@ -8,14 +8,17 @@ package verifiable
 
 import (
 	"crypto"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 
 	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/common"
 	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/holder"
 	"github.com/hyperledger/aries-framework-go/component/models/sdjwt/issuer"
+	sdjwtverifier "github.com/hyperledger/aries-framework-go/component/models/sdjwt/verifier"
 	json2 "github.com/hyperledger/aries-framework-go/component/models/util/json"
 )
 
@@ -23,6 +26,7 @@ type marshalDisclosureOpts struct {
 	includeAllDisclosures bool
 	discloseIfAvailable   []string
 	discloseRequired      []string
+	disclosePaths         []string
 	holderBinding         *holder.BindingInfo
 	signer                jose.Signer
 	signingKeyID          string
@@ -33,8 +37,6 @@ type marshalDisclosureOpts struct {
 // MarshalDisclosureOption provides an option for Credential.MarshalWithDisclosure.
 type MarshalDisclosureOption func(opts *marshalDisclosureOpts)
 
-// TODO: should DiscloseGiven(IfAvailable|Required) have path semantics for disclosure?
-
 // DiscloseGivenIfAvailable sets that the disclosures with the given claim names will be disclosed by
 // Credential.MarshalWithDisclosure.
 //
@@ -59,6 +61,22 @@ func DiscloseGivenRequired(disclosureNames []string) MarshalDisclosureOption {
 	}
 }
 
+// DiscloseGivenPaths sets that only the disclosures needed to reveal the claims at the given paths will be disclosed
+// by Credential.MarshalWithDisclosure, producing a minimal SD-JWT presentation. All the other disclosures are dropped.
+//
+// A path is a dot-separated chain of claim names within the credential, with array elements addressed as "[i]",
+// e.g. "credentialSubject.degree" or "credentialSubject.nationalities[0]". Revealing a claim also reveals its nested
+// selectively disclosable claims, and the enclosing claims it is disclosed within.
+//
+// If any path provided does not have a matching disclosure, Credential.MarshalWithDisclosure will return an error.
+//
+// Will result in an error if this option is provided alongside DiscloseAll.
+func DiscloseGivenPaths(paths []string) MarshalDisclosureOption {
+	return func(opts *marshalDisclosureOpts) {
+		opts.disclosePaths = paths
+	}
+}
+
 // DiscloseAll sets that all disclosures in the given Credential will be disclosed by Credential.MarshalWithDisclosure.
 //
 // Will result in an error if this option is provided alongside DiscloseGivenIfAvailable or DiscloseGivenRequired.
@@ -109,7 +127,8 @@ func (vc *Credential) MarshalWithDisclosure(opts ...MarshalDisclosureOption) (st
 		opt(options)
 	}
 
-	if options.includeAllDisclosures && (len(options.discloseIfAvailable) > 0 || len(options.discloseRequired) > 0) {
+	if options.includeAllDisclosures && (len(options.discloseIfAvailable) > 0 || len(options.discloseRequired) > 0 ||
+		len(options.disclosePaths) > 0) {
 		return "", fmt.Errorf("incompatible options provided")
 	}
 
@@ -127,7 +146,15 @@ func (vc *Credential) MarshalWithDisclosure(opts ...MarshalDisclosureOption) (st
 }
 
 func filterSDJWTVC(vc *Credential, options *marshalDisclosureOpts) (string, error) {
-	disclosureCodes, err := filteredDisclosureCodes(vc.SDJWTDisclosures, options)
+	claims := map[string]interface{}{}
+
+	if len(options.disclosePaths) > 0 {
+		if _, err := unmarshalJWS(vc.JWT, false, nil, &claims); err != nil {
+			return "", fmt.Errorf("decode SD-JWT claims: %w", err)
+		}
+	}
+
+	disclosureCodes, err := filteredDisclosureCodes(vc.SDJWTDisclosures, claims, options)
 	if err != nil {
 		return "", err
 	}
@@ -161,7 +188,7 @@ func createSDJWTPresentation(vc *Credential, options *marshalDisclosureOpts) (st
 		return "", fmt.Errorf("parsing disclosure claims from vc sdjwt: %w", err)
 	}
 
-	disclosureCodes, err := filteredDisclosureCodes(disclosureClaims, options)
+	disclosureCodes, err := filteredDisclosureCodes(disclosureClaims, issued.SignedJWT.Payload, options)
 	if err != nil {
 		return "", err
 	}
@@ -187,6 +214,7 @@ func createSDJWTPresentation(vc *Credential, options *marshalDisclosureOpts) (st
 
 func filteredDisclosureCodes(
 	availableDisclosures []*common.DisclosureClaim,
+	sdJWTClaims map[string]interface{},
 	options *marshalDisclosureOpts,
 ) ([]string, error) {
 	var (
@@ -203,6 +231,15 @@ func filteredDisclosureCodes(
 		if err != nil {
 			return nil, err
 		}
+
+		if len(options.disclosePaths) > 0 {
+			pathDisclosures, e := filterDisclosuresByPaths(availableDisclosures, sdJWTClaims, options.disclosePaths)
+			if e != nil {
+				return nil, e
+			}
+
+			useDisclosures = appendMissingDisclosures(useDisclosures, pathDisclosures)
+		}
 	}
 
 	for _, disclosure := range useDisclosures {
@@ -258,6 +295,194 @@ func filterDisclosures(
 	return out, nil
 }
 
+// filterDisclosuresByPaths returns the disclosures needed to reveal the claims at the given paths.
+func filterDisclosuresByPaths(
+	disclosures []*common.DisclosureClaim,
+	sdJWTClaims map[string]interface{},
+	paths []string,
+) ([]*common.DisclosureClaim, error) {
+	byDigest := make(map[string]*common.DisclosureClaim, len(disclosures))
+
+	for _, disclosure := range disclosures {
+		byDigest[disclosure.Digest] = disclosure
+	}
+
+	root := interface{}(sdJWTClaims)
+	if vcClaims, ok := sdJWTClaims["vc"].(map[string]interface{}); ok {
+		root = vcClaims
+	}
+
+	var located []*locatedDisclosure
+
+	if err := locateDisclosures(root, "", byDigest, &located); err != nil {
+		return nil, err
+	}
+
+	var out []*common.DisclosureClaim
+
+	for _, path := range paths {
+		found := false
+
+		for _, ld := range located {
+			if isSameOrNestedClaimPath(ld.path, path) || isSameOrNestedClaimPath(path, ld.path) {
+				out = appendMissingDisclosures(out, []*common.DisclosureClaim{ld.disclosure})
+
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("disclosure list missing claim path %q", path)
+		}
+	}
+
+	return out, nil
+}
+
+// locatedDisclosure is a disclosure along with the path of the claim it discloses.
+type locatedDisclosure struct {
+	path       string
+	disclosure *common.DisclosureClaim
+}
+
+// locateDisclosures walks the SD-JWT claims and the values of the disclosures found in them, and records the claim
+// path of every disclosure.
+func locateDisclosures(
+	value interface{},
+	path string,
+	byDigest map[string]*common.DisclosureClaim,
+	located *[]*locatedDisclosure,
+) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		digests, _ := v[common.SDKey].([]interface{})
+
+		for _, digest := range digests {
+			disclosure, ok := byDigest[fmt.Sprint(digest)]
+			if !ok {
+				continue
+			}
+
+			name, disclosedValue, err := decodeDisclosure(disclosure.Disclosure)
+			if err != nil {
+				return err
+			}
+
+			if err = locateDisclosure(disclosure, disclosedValue, joinClaimPath(path, name), byDigest, located); err != nil {
+				return err
+			}
+		}
+
+		for name, nested := range v {
+			if name == common.SDKey || name == common.SDAlgorithmKey {
+				continue
+			}
+
+			if err := locateDisclosures(nested, joinClaimPath(path, name), byDigest, located); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, elem := range v {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+
+			if elemMap, ok := elem.(map[string]interface{}); ok && len(elemMap) == 1 {
+				if disclosure, ok := byDigest[fmt.Sprint(elemMap[common.ArrayElementDigestKey])]; ok {
+					_, disclosedValue, err := decodeDisclosure(disclosure.Disclosure)
+					if err != nil {
+						return err
+					}
+
+					if err = locateDisclosure(disclosure, disclosedValue, elemPath, byDigest, located); err != nil {
+						return err
+					}
+
+					continue
+				}
+			}
+
+			if err := locateDisclosures(elem, elemPath, byDigest, located); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func locateDisclosure(
+	disclosure *common.DisclosureClaim,
+	disclosedValue interface{},
+	path string,
+	byDigest map[string]*common.DisclosureClaim,
+	located *[]*locatedDisclosure,
+) error {
+	*located = append(*located, &locatedDisclosure{path: path, disclosure: disclosure})
+
+	return locateDisclosures(disclosedValue, path, byDigest, located)
+}
+
+const (
+	arrayElementDisclosureLen = 2
+	claimDisclosureLen        = 3
+)
+
+// decodeDisclosure returns the claim name (empty for an array element) and the raw value of the disclosure.
+func decodeDisclosure(disclosure string) (string, interface{}, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(disclosure)
+	if err != nil {
+		return "", nil, fmt.Errorf("decode disclosure: %w", err)
+	}
+
+	var elements []interface{}
+
+	if err = json.Unmarshal(decoded, &elements); err != nil {
+		return "", nil, fmt.Errorf("unmarshal disclosure: %w", err)
+	}
+
+	switch len(elements) {
+	case arrayElementDisclosureLen:
+		return "", elements[1], nil
+	case claimDisclosureLen:
+		return fmt.Sprint(elements[1]), elements[2], nil
+	default:
+		return "", nil, fmt.Errorf("unmarshal disclosure: invalid number of elements %d", len(elements))
+	}
+}
+
+func joinClaimPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// isSameOrNestedClaimPath returns true if path is the same as parent or addresses a claim nested under it.
+func isSameOrNestedClaimPath(path, parent string) bool {
+	return path == parent || strings.HasPrefix(path, parent+".") || strings.HasPrefix(path, parent+"[")
+}
+
+func appendMissingDisclosures(to, disclosures []*common.DisclosureClaim) []*common.DisclosureClaim {
+	for _, disclosure := range disclosures {
+		present := false
+
+		for _, existing := range to {
+			if existing.Digest == disclosure.Digest {
+				present = true
+
+				break
+			}
+		}
+
+		if !present {
+			to = append(to, disclosure)
+		}
+	}
+
+	return to
+}
+
 // MakeSDJWTOpts provides SD-JWT options for VC.
 type MakeSDJWTOpts struct {
 	hashAlg               crypto.Hash
@@ -589,3 +814,25 @@ func clearEmpty(claims map[string]interface{}) {
 		}
 	}
 }
+
+// holderBindingSigningAlgorithms are the signing algorithms accepted for the Holder (Key) Binding JWT.
+var holderBindingSigningAlgorithms = []string{"EdDSA", "ES256", "ES384", "ES512", "ES521", "ES256K", "PS256", "RS256"}
+
+// verifySDJWTHolderBinding verifies the holder binding JWT against the "cnf" claim of the SD-JWT.
+// The SD-JWT signature is expected to be verified already.
+func verifySDJWTHolderBinding(sdJWT, holderBinding string) error {
+	claims := map[string]interface{}{}
+
+	_, err := unmarshalJWS(sdJWT, false, nil, &claims)
+	if err != nil {
+		return fmt.Errorf("verify SD-JWT holder binding: %w", err)
+	}
+
+	err = sdjwtverifier.VerifyHolderVerification(claims, holderBinding,
+		sdjwtverifier.WithHolderSigningAlgorithms(holderBindingSigningAlgorithms))
+	if err != nil {
+		return fmt.Errorf("verify SD-JWT holder binding: %w", err)
+	}
+
+	return nil
+}
@@ -10,15 +10,18 @@ import (
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 
 	afgojwt "github.com/hyperledger/aries-framework-go/component/models/jwt"
@@ -109,6 +112,14 @@ func TestParseSDJWT(t *testing.T) {
 		require.Equal(t, mockHolderBinding, newVC.SDHolderBinding)
 	})
 
+	t.Run("mock holder binding is rejected by holder binding check", func(t *testing.T) {
+		newVC, e := ParseCredential([]byte(sdJWTString+common.CombinedFormatSeparator+"e30.e30.mockHolderBinding"),
+			WithPublicKeyFetcher(createDIDKeyFetcher(t, pubKey, issuerID)), WithSDJWTHolderBindingCheck())
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "verify SD-JWT holder binding")
+		require.Nil(t, newVC)
+	})
+
 	t.Run("invalid SDJWT disclosures", func(t *testing.T) {
 		sdJWTWithUnknownDisclosure := sdJWTString +
 			common.CombinedFormatSeparator + base64.RawURLEncoding.EncodeToString([]byte("blah blah"))
@@ -163,6 +174,45 @@ func TestMarshalWithDisclosure(t *testing.T) {
 			require.NotEmpty(t, res.HolderVerification)
 		})
 
+		t.Run("disclose only the degree path", func(t *testing.T) {
+			resultCred, err := newVC.MarshalWithDisclosure(DiscloseGivenPaths([]string{"credentialSubject.degree"}))
+			require.NoError(t, err)
+
+			res := common.ParseCombinedFormatForPresentation(resultCred)
+			require.Len(t, res.Disclosures, 2)
+			require.Empty(t, res.HolderVerification)
+
+			presentedVC, err := ParseCredential([]byte(resultCred), WithDisabledProofCheck())
+			require.NoError(t, err)
+			require.Len(t, presentedVC.SDJWTDisclosures, 2)
+
+			names := []string{presentedVC.SDJWTDisclosures[0].Name, presentedVC.SDJWTDisclosures[1].Name}
+			require.ElementsMatch(t, []string{"type", "university"}, names)
+		})
+
+		t.Run("disclose a nested path within a recursively disclosable claim", func(t *testing.T) {
+			recursiveCred, _ := createTestSDJWTCred(t, privKey,
+				MakeSDJWTWithVersion(common.SDJWTVersionV5), MakeSDJWTWithRecursiveClaimsObjects([]string{"degree"}))
+
+			recursiveVC, err := ParseCredential([]byte(recursiveCred), WithDisabledProofCheck())
+			require.NoError(t, err)
+			require.Len(t, recursiveVC.SDJWTDisclosures, 3)
+
+			resultCred, err := recursiveVC.MarshalWithDisclosure(
+				DiscloseGivenPaths([]string{"credentialSubject.degree.university"}))
+			require.NoError(t, err)
+
+			presentedVC, err := ParseCredential([]byte(resultCred), WithDisabledProofCheck())
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(presentedVC.SDJWTDisclosures))
+			for _, disclosure := range presentedVC.SDJWTDisclosures {
+				names = append(names, disclosure.Name)
+			}
+
+			require.ElementsMatch(t, []string{"degree", "university"}, names)
+		})
+
 		t.Run("disclose required and some if-available claims", func(t *testing.T) {
 			resultCred, err := newVC.MarshalWithDisclosure(
 				DiscloseGivenRequired([]string{"type"}),
@@ -221,6 +271,22 @@ func TestMarshalWithDisclosure(t *testing.T) {
 			require.Contains(t, err.Error(), "incompatible options provided")
 		})
 
+		t.Run("paths are incompatible with disclose all", func(t *testing.T) {
+			resultCred, err := newVC.MarshalWithDisclosure(
+				DiscloseAll(),
+				DiscloseGivenPaths([]string{"credentialSubject.degree"}))
+			require.Error(t, err)
+			require.Empty(t, resultCred)
+			require.Contains(t, err.Error(), "incompatible options provided")
+		})
+
+		t.Run("unknown claim path", func(t *testing.T) {
+			resultCred, err := newVC.MarshalWithDisclosure(DiscloseGivenPaths([]string{"credentialSubject.name"}))
+			require.Error(t, err)
+			require.Empty(t, resultCred)
+			require.Contains(t, err.Error(), `disclosure list missing claim path "credentialSubject.name"`)
+		})
+
 		t.Run("missing required claim", func(t *testing.T) {
 			t.Run("not in disclosure list", func(t *testing.T) {
 				resultCred, err := newVC.MarshalWithDisclosure(DiscloseGivenRequired([]string{"favourite-animal"}))
@@ -600,3 +666,91 @@ func createTestSDJWTCred(
 
 	return sdjwt, srcVC.Issuer.ID
 }
+
+func TestParsePresentationWithSDJWTCredentials(t *testing.T) {
+	issuerPubKey, issuerPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderPubKey, holderPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	holderJWK, err := jwksupport.JWKFromKey(holderPubKey)
+	require.NoError(t, err)
+
+	srcVC, err := parseTestCredential(t, []byte(jwtTestCredential))
+	require.NoError(t, err)
+
+	srcVC.CustomFields = CustomFields{"cnf": map[string]interface{}{"jwk": holderJWK}}
+
+	sdJWTString, err := srcVC.MakeSDJWT(afgojwt.NewEd25519Signer(issuerPrivKey), srcVC.Issuer.ID+"#keys-1")
+	require.NoError(t, err)
+
+	storedVC, err := ParseCredential([]byte(sdJWTString), WithDisabledProofCheck())
+	require.NoError(t, err)
+
+	presentCredential := func(t *testing.T, holderSigner jose.Signer) string {
+		t.Helper()
+
+		presented, e := storedVC.MarshalWithDisclosure(DiscloseGivenRequired([]string{"university"}),
+			DisclosureHolderBinding(&holder.BindingInfo{
+				Payload: holder.BindingPayload{
+					Nonce:    "abc123",
+					Audience: "did:example:verifier",
+					IssuedAt: jwt.NewNumericDate(time.Now()),
+				},
+				Signer: holderSigner,
+			}))
+		require.NoError(t, e)
+
+		vp, e := NewPresentation(WithJWTCredentials(presented))
+		require.NoError(t, e)
+
+		vpBytes, e := json.Marshal(vp)
+		require.NoError(t, e)
+
+		return string(vpBytes)
+	}
+
+	issuerFetcher := createDIDKeyFetcher(t, issuerPubKey, "76e12ec712ebc6f1c221ebfeb1f")
+
+	t.Run("success", func(t *testing.T) {
+		vpBytes := presentCredential(t, afgojwt.NewEd25519Signer(holderPrivKey))
+
+		vp, e := newTestPresentation(t, []byte(vpBytes), WithPresPublicKeyFetcher(issuerFetcher))
+		require.NoError(t, e)
+		require.Len(t, vp.Credentials(), 1)
+
+		vc, ok := vp.Credentials()[0].(*Credential)
+		require.True(t, ok)
+		require.Len(t, vc.SDJWTDisclosures, 1)
+		require.Equal(t, "university", vc.SDJWTDisclosures[0].Name)
+		require.NotEmpty(t, vc.SDHolderBinding)
+	})
+
+	t.Run("holder binding signed by another key", func(t *testing.T) {
+		_, otherPrivKey, e := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, e)
+
+		vpBytes := presentCredential(t, afgojwt.NewEd25519Signer(otherPrivKey))
+
+		_, e = newTestPresentation(t, []byte(vpBytes), WithPresPublicKeyFetcher(issuerFetcher))
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "verify SD-JWT holder binding")
+
+		// holder binding is not verified if proof check is disabled
+		_, e = newTestPresentation(t, []byte(vpBytes), WithPresDisabledProofCheck())
+		require.NoError(t, e)
+	})
+
+	t.Run("credential is signed by another issuer", func(t *testing.T) {
+		otherPubKey, _, e := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, e)
+
+		vpBytes := presentCredential(t, afgojwt.NewEd25519Signer(holderPrivKey))
+
+		_, e = newTestPresentation(t, []byte(vpBytes),
+			WithPresPublicKeyFetcher(createDIDKeyFetcher(t, otherPubKey, "76e12ec712ebc6f1c221ebfeb1f")))
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "decode credentials of presentation")
+	})
+}
@@ -0,0 +1,134 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/gowebpki/jcs"
+)
+
+const (
+	// StatusPurposeRevocation is the statusPurpose of a credentialStatus entry used for revocation.
+	StatusPurposeRevocation = "revocation"
+
+	statusPurposeField        = "statusPurpose"
+	statusListCredentialField = "statusListCredential"
+)
+
+// WithRequireStatus makes decoding of the credential fail if it has no credentialStatus, for verifiers accepting
+// revocable credentials only.
+func WithRequireStatus() CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.requireStatus = true
+	}
+}
+
+// StatusChecker resolves the status list bit referenced by a credentialStatus entry,
+// e.g. by fetching the status list credential and reading the bit at statusListIndex (see StatusList2021Checker).
+type StatusChecker interface {
+	// StatusBit returns true if the status bit referenced by the given credentialStatus entry is set.
+	StatusBit(status *TypedID) (bool, error)
+}
+
+// IsRevoked is a convenience over StatusChecker which returns true if the revocation status bit of the credential
+// is set. A credential without credentialStatus, or whose status has a purpose other than revocation, is not revoked.
+// A status without statusPurpose (e.g. RevocationList2020Status) is treated as a revocation status.
+func IsRevoked(vc *Credential, checker StatusChecker) (bool, error) {
+	if vc == nil {
+		return false, errors.New("is revoked: credential is not defined")
+	}
+
+	if checker == nil {
+		return false, errors.New("is revoked: status checker is not defined")
+	}
+
+	if vc.Status == nil {
+		return false, nil
+	}
+
+	if purpose, ok := vc.Status.CustomFields[statusPurposeField]; ok && purpose != StatusPurposeRevocation {
+		return false, nil
+	}
+
+	revoked, err := checker.StatusBit(vc.Status)
+	if err != nil {
+		return false, fmt.Errorf("is revoked: %w", err)
+	}
+
+	return revoked, nil
+}
+
+// DeriveStatusIndex derives a stable status list index within [0, listSize) from the content of the credential,
+// so that issuers can assign status list entries deterministically.
+//
+// The index is derived from the SHA-256 hash of the JSON canonicalization (RFC 8785) of the credential without its
+// proofs and credentialStatus, hence adding a proof or a status entry to the credential does not change its index.
+//
+// The derived index is not unique: different credentials may be mapped to the same index, and the chance of a
+// collision grows quickly as the list fills up. Revoking one of the colliding credentials would revoke all of them,
+// so the caller is responsible for tracking allocated indices and resolving collisions (e.g. by probing for the
+// next free index) before assigning the index to a status entry.
+func DeriveStatusIndex(vc *Credential, listSize int) (int, error) {
+	if vc == nil {
+		return 0, errors.New("derive status index: credential is not defined")
+	}
+
+	if listSize <= 0 {
+		return 0, fmt.Errorf("derive status index: invalid status list size %d", listSize)
+	}
+
+	vcCopy := *vc
+	vcCopy.Proofs = nil
+	vcCopy.Status = nil
+	vcCopy.JWT = ""
+
+	vcJSON, err := json.Marshal(&vcCopy)
+	if err != nil {
+		return 0, fmt.Errorf("derive status index: %w", err)
+	}
+
+	canonical, err := jcs.Transform(vcJSON)
+	if err != nil {
+		return 0, fmt.Errorf("derive status index: canonicalize credential: %w", err)
+	}
+
+	digest := sha256.Sum256(canonical)
+
+	return int(binary.BigEndian.Uint64(digest[:8]) % uint64(listSize)), nil
+}
+
+// validateStatusReference checks that statusListCredential of the credentialStatus entry, if any,
+// is syntactically a valid absolute URI (e.g. an HTTPS URL or a DID URL). The list itself is not resolved.
+func validateStatusReference(status *TypedID) error {
+	if status == nil {
+		return nil
+	}
+
+	ref, ok := status.CustomFields[statusListCredentialField]
+	if !ok {
+		return nil
+	}
+
+	refStr, ok := ref.(string)
+	if !ok {
+		return fmt.Errorf("invalid credentialStatus %q: %s must be a string", status.ID, statusListCredentialField)
+	}
+
+	u, err := url.Parse(refStr)
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "") {
+		return fmt.Errorf("invalid credentialStatus %q: %s %q is not a valid URI",
+			status.ID, statusListCredentialField, refStr)
+	}
+
+	return nil
+}
@@ -0,0 +1,320 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// StatusPurposeSuspension is the statusPurpose of a credentialStatus entry used for suspension.
+	StatusPurposeSuspension = "suspension"
+
+	// StatusList2021EntryType is the type of a credentialStatus entry referencing a StatusList2021 status list.
+	StatusList2021EntryType = "StatusList2021Entry"
+
+	defaultStatusListTimeout = 10 * time.Second
+	maxStatusListSize        = 1 << 20
+
+	statusList2021CredentialType = "StatusList2021Credential"
+	statusList2021Type           = "StatusList2021"
+	statusListIndexField         = "statusListIndex"
+	encodedListField             = "encodedList"
+)
+
+// StatusListResolver fetches a status list credential referenced by the statusListCredential field
+// of a credentialStatus entry, e.g. over HTTP or from a cache.
+type StatusListResolver interface {
+	// Resolve returns the status list credential (JSON or JWT) located at statusListCredential.
+	Resolve(ctx context.Context, statusListCredential string) ([]byte, error)
+}
+
+// HTTPStatusListResolver is a StatusListResolver which fetches status list credentials with HTTP GET.
+type HTTPStatusListResolver struct {
+	client *http.Client
+}
+
+// NewHTTPStatusListResolver creates a new HTTPStatusListResolver. If client is nil, a client with 10 seconds
+// timeout is used. Status list credentials larger than 1 MiB are rejected.
+func NewHTTPStatusListResolver(client *http.Client) *HTTPStatusListResolver {
+	if client == nil {
+		client = &http.Client{Timeout: defaultStatusListTimeout}
+	}
+
+	return &HTTPStatusListResolver{client: client}
+}
+
+// Resolve fetches the status list credential from the statusListCredential URL.
+func (r *HTTPStatusListResolver) Resolve(ctx context.Context, statusListCredential string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, statusListCredential, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("new status list request: %w", err)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch status list: %w", err)
+	}
+
+	defer func() {
+		e := resp.Body.Close()
+		if e != nil {
+			logger.Errorf("closing response body failed [%v]", e)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status list endpoint HTTP failure [%v]", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxStatusListSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("status list: read response body: %w", err)
+	}
+
+	if len(body) > maxStatusListSize {
+		return nil, fmt.Errorf("status list exceeds %d bytes", maxStatusListSize)
+	}
+
+	return body, nil
+}
+
+// StatusResult is the result of checking the credentialStatus of a credential.
+type StatusResult struct {
+	// Purpose is the statusPurpose of the status entry, e.g. StatusPurposeRevocation or StatusPurposeSuspension.
+	Purpose string
+	// Set is true if the status bit of the credential is set in the status list.
+	Set bool
+}
+
+// Revoked returns true if the credential is revoked.
+func (r *StatusResult) Revoked() bool {
+	return r.Set && r.Purpose == StatusPurposeRevocation
+}
+
+// Suspended returns true if the credential is suspended.
+func (r *StatusResult) Suspended() bool {
+	return r.Set && r.Purpose == StatusPurposeSuspension
+}
+
+type statusCheckOpts struct {
+	resolver StatusListResolver
+	vcOpts   []CredentialOpt
+}
+
+// StatusCheckOpt is an option of Credential.CheckStatus.
+type StatusCheckOpt func(opts *statusCheckOpts)
+
+// WithStatusListResolver defines the resolver of status list credentials. If not defined,
+// status list credentials are fetched with HTTPStatusListResolver using its default client.
+func WithStatusListResolver(resolver StatusListResolver) StatusCheckOpt {
+	return func(opts *statusCheckOpts) {
+		opts.resolver = resolver
+	}
+}
+
+// WithStatusListCredentialOpts defines the options of parsing the status list credential, e.g. the public key
+// fetcher and the JSON-LD document loader used to verify its proof.
+func WithStatusListCredentialOpts(vcOpts ...CredentialOpt) StatusCheckOpt {
+	return func(opts *statusCheckOpts) {
+		opts.vcOpts = append(opts.vcOpts, vcOpts...)
+	}
+}
+
+// CheckStatus checks the StatusList2021Entry credentialStatus of the credential with StatusList2021Checker:
+// it resolves the status list credential referenced by statusListCredential, verifies its proof and that it is
+// issued by the issuer of the credential, and reports whether the bit at statusListIndex of its encodedList is set.
+// A credential without credentialStatus has an unset status of no purpose.
+//
+// A status entry without statusPurpose is treated as a revocation status. The statusPurpose of the status list
+// must match the one of the entry. If statusListIndex is beyond the end of the list, the error wraps
+// *StatusListIndexError.
+func (vc *Credential) CheckStatus(ctx context.Context, opts ...StatusCheckOpt) (*StatusResult, error) {
+	if vc.Status == nil {
+		return &StatusResult{}, nil
+	}
+
+	set, err := NewStatusList2021Checker(vc.Issuer.ID, opts...).StatusBitWithContext(ctx, vc.Status)
+	if err != nil {
+		return nil, fmt.Errorf("check credential status: %w", err)
+	}
+
+	purpose, err := statusEntryPurpose(vc.Status)
+	if err != nil {
+		return nil, fmt.Errorf("check credential status: %w", err)
+	}
+
+	return &StatusResult{Purpose: purpose, Set: set}, nil
+}
+
+// StatusList2021Checker is a StatusChecker of StatusList2021Entry credentialStatus entries, e.g. for IsRevoked.
+type StatusList2021Checker struct {
+	issuer string
+	opts   *statusCheckOpts
+}
+
+// NewStatusList2021Checker creates a StatusList2021Checker of the credentials issued by issuer. Only the status
+// list credentials issued by the same issuer are accepted, so that a third party cannot host a list which
+// changes the status of the credentials.
+func NewStatusList2021Checker(issuer string, opts ...StatusCheckOpt) *StatusList2021Checker {
+	checkOpts := &statusCheckOpts{}
+
+	for _, opt := range opts {
+		opt(checkOpts)
+	}
+
+	if checkOpts.resolver == nil {
+		checkOpts.resolver = NewHTTPStatusListResolver(nil)
+	}
+
+	return &StatusList2021Checker{issuer: issuer, opts: checkOpts}
+}
+
+// StatusBit resolves the status list credential referenced by the StatusList2021Entry status, verifies it
+// and returns true if the bit at statusListIndex of its encodedList is set.
+func (c *StatusList2021Checker) StatusBit(status *TypedID) (bool, error) {
+	return c.StatusBitWithContext(context.Background(), status)
+}
+
+// StatusBitWithContext is StatusBit which resolves the status list credential within the given context.
+func (c *StatusList2021Checker) StatusBitWithContext(ctx context.Context, status *TypedID) (bool, error) {
+	if status.Type != StatusList2021EntryType {
+		return false, fmt.Errorf("unsupported credentialStatus type %s", status.Type)
+	}
+
+	purpose, err := statusEntryPurpose(status)
+	if err != nil {
+		return false, err
+	}
+
+	index, err := parseStatusListIndex(status.CustomFields[statusListIndexField])
+	if err != nil {
+		return false, err
+	}
+
+	listURL, ok := status.CustomFields[statusListCredentialField].(string)
+	if !ok || listURL == "" {
+		return false, fmt.Errorf("%s is not defined", statusListCredentialField)
+	}
+
+	listVC, err := c.resolveStatusList(ctx, listURL)
+	if err != nil {
+		return false, err
+	}
+
+	encodedList, err := statusList2021EncodedList(listVC, purpose)
+	if err != nil {
+		return false, fmt.Errorf("status list credential %s: %w", listURL, err)
+	}
+
+	return StatusListBit(encodedList, index)
+}
+
+func (c *StatusList2021Checker) resolveStatusList(ctx context.Context, listURL string) (*Credential, error) {
+	if c.issuer == "" {
+		return nil, errors.New("issuer of the credential is not defined")
+	}
+
+	listBytes, err := c.opts.resolver.Resolve(ctx, listURL)
+	if err != nil {
+		return nil, fmt.Errorf("resolve status list %s: %w", listURL, err)
+	}
+
+	listVC, err := ParseCredential(listBytes, c.opts.vcOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("parse status list credential %s: %w", listURL, err)
+	}
+
+	if listVC.NoProof() {
+		return nil, fmt.Errorf("status list credential %s is not secured by a proof", listURL)
+	}
+
+	if listVC.Issuer.ID != c.issuer {
+		return nil, fmt.Errorf("status list credential %s is issued by %s, not by the credential issuer %s",
+			listURL, listVC.Issuer.ID, c.issuer)
+	}
+
+	return listVC, nil
+}
+
+// statusEntryPurpose returns the statusPurpose of the credentialStatus entry, revocation if it is not defined.
+func statusEntryPurpose(status *TypedID) (string, error) {
+	p, ok := status.CustomFields[statusPurposeField]
+	if !ok {
+		return StatusPurposeRevocation, nil
+	}
+
+	purpose, ok := p.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", statusPurposeField)
+	}
+
+	return purpose, nil
+}
+
+func parseStatusListIndex(value interface{}) (int, error) {
+	var (
+		index int
+		err   error
+	)
+
+	switch v := value.(type) {
+	case string:
+		index, err = strconv.Atoi(v)
+	case float64:
+		index = int(v)
+	case json.Number:
+		index, err = strconv.Atoi(v.String())
+	case nil:
+		err = errors.New("not defined")
+	default:
+		err = fmt.Errorf("unsupported type %T", value)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", statusListIndexField, err)
+	}
+
+	return index, nil
+}
+
+// statusList2021EncodedList returns the encodedList of the StatusList2021 subject of the status list credential
+// with the given purpose.
+func statusList2021EncodedList(listVC *Credential, purpose string) (string, error) {
+	if !containsString(listVC.Types, statusList2021CredentialType) {
+		return "", fmt.Errorf("not a %s", statusList2021CredentialType)
+	}
+
+	subjects, ok := listVC.Subject.([]Subject)
+	if !ok || len(subjects) != 1 {
+		return "", errors.New("status list credential must have a single subject")
+	}
+
+	subject := subjects[0]
+
+	if subjectType, _ := subject.CustomFields["type"].(string); subjectType != statusList2021Type { //nolint:errcheck
+		return "", fmt.Errorf("subject is not a %s", statusList2021Type)
+	}
+
+	if listPurpose, _ := subject.CustomFields[statusPurposeField].(string); listPurpose != purpose { //nolint:errcheck
+		return "", fmt.Errorf("status list purpose %q does not match status purpose %q", listPurpose, purpose)
+	}
+
+	encodedList, ok := subject.CustomFields[encodedListField].(string)
+	if !ok {
+		return "", fmt.Errorf("%s is not defined", encodedListField)
+	}
+
+	return encodedList, nil
+}
@@ -0,0 +1,280 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	utiltime "github.com/hyperledger/aries-framework-go/component/models/util/time"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+const statusListURL = "https://example.com/credentials/status/3"
+
+// mockStatusListResolver is a StatusListResolver backed by in-memory status list credentials.
+type mockStatusListResolver struct {
+	lists map[string][]byte
+}
+
+func (r *mockStatusListResolver) Resolve(_ context.Context, statusListCredential string) ([]byte, error) {
+	list, ok := r.lists[statusListCredential]
+	if !ok {
+		return nil, fmt.Errorf("status list %s not found", statusListCredential)
+	}
+
+	return list, nil
+}
+
+func TestCredential_CheckStatus(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	loader := createTestDocumentLoader(t)
+
+	// bits 94567 and 7 are set
+	bitstring := make([]byte, 16*1024)
+	bitstring[94567/8] = 1 << (7 - 94567%8)
+	bitstring[0] = 0b0000_0001
+
+	newStatusList := func(t *testing.T, purpose string, secured bool) []byte {
+		t.Helper()
+
+		listVC := &Credential{
+			Context: []string{"https://www.w3.org/2018/credentials/v1", "https://w3id.org/vc/status-list/2021/v1"},
+			ID:      statusListURL,
+			Types:   []string{"VerifiableCredential", "StatusList2021Credential"},
+			Issuer:  Issuer{ID: "did:example:12345"},
+			Issued:  utiltime.NewTime(time.Now()),
+			Subject: Subject{
+				ID: statusListURL + "#list",
+				CustomFields: CustomFields{
+					"type":          "StatusList2021",
+					"statusPurpose": purpose,
+					"encodedList":   encodeStatusList(t, bitstring),
+				},
+			},
+		}
+
+		if secured {
+			require.NoError(t, listVC.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureProofValue,
+				Suite:                   sigSuite,
+				VerificationMethod:      "did:example:12345#key1",
+			}, jsonldsig.WithDocumentLoader(loader)))
+		}
+
+		listBytes, err := json.Marshal(listVC)
+		require.NoError(t, err)
+
+		return listBytes
+	}
+
+	newVC := func(t *testing.T, purpose string, index interface{}) *Credential {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer = Issuer{ID: "did:example:12345"}
+		vc.Status = &TypedID{
+			ID:   fmt.Sprintf("%s#%v", statusListURL, index),
+			Type: "StatusList2021Entry",
+			CustomFields: CustomFields{
+				"statusListIndex":      index,
+				"statusListCredential": statusListURL,
+			},
+		}
+
+		if purpose != "" {
+			vc.Status.CustomFields["statusPurpose"] = purpose
+		}
+
+		return vc
+	}
+
+	listOpts := WithStatusListCredentialOpts(
+		WithJSONLDDocumentLoader(loader),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+
+	revocationList := &mockStatusListResolver{lists: map[string][]byte{
+		statusListURL: newStatusList(t, StatusPurposeRevocation, true),
+	}}
+
+	suspensionList := &mockStatusListResolver{lists: map[string][]byte{
+		statusListURL: newStatusList(t, StatusPurposeSuspension, true),
+	}}
+
+	t.Run("revocation", func(t *testing.T) {
+		result, err := newVC(t, "revocation", "94567").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), listOpts)
+		require.NoError(t, err)
+		require.Equal(t, &StatusResult{Purpose: StatusPurposeRevocation, Set: true}, result)
+		require.True(t, result.Revoked())
+		require.False(t, result.Suspended())
+
+		result, err = newVC(t, "revocation", "94566").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), listOpts)
+		require.NoError(t, err)
+		require.False(t, result.Revoked())
+
+		// statusPurpose defaults to revocation, statusListIndex may be a number
+		result, err = newVC(t, "", float64(7)).CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), listOpts)
+		require.NoError(t, err)
+		require.True(t, result.Revoked())
+	})
+
+	t.Run("suspension", func(t *testing.T) {
+		result, err := newVC(t, "suspension", "94567").CheckStatus(context.Background(),
+			WithStatusListResolver(suspensionList), listOpts)
+		require.NoError(t, err)
+		require.True(t, result.Suspended())
+		require.False(t, result.Revoked())
+
+		_, err = newVC(t, "suspension", "94567").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), listOpts)
+		require.EqualError(t, err, "check credential status: status list credential "+statusListURL+
+			`: status list purpose "revocation" does not match status purpose "suspension"`)
+	})
+
+	t.Run("status list checker", func(t *testing.T) {
+		checker := NewStatusList2021Checker("did:example:12345", WithStatusListResolver(revocationList), listOpts)
+
+		revoked, err := IsRevoked(newVC(t, "revocation", "94567"), checker)
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		revoked, err = IsRevoked(newVC(t, "revocation", "94566"), checker)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("status list of another issuer", func(t *testing.T) {
+		vc := newVC(t, "revocation", "94567")
+		vc.Issuer.ID = "did:example:other"
+
+		_, err := vc.CheckStatus(context.Background(), WithStatusListResolver(revocationList), listOpts)
+		require.EqualError(t, err, "check credential status: status list credential "+statusListURL+
+			" is issued by did:example:12345, not by the credential issuer did:example:other")
+
+		_, err = NewStatusList2021Checker("", WithStatusListResolver(revocationList), listOpts).
+			StatusBit(newVC(t, "revocation", "94567").Status)
+		require.EqualError(t, err, "issuer of the credential is not defined")
+	})
+
+	t.Run("credential without status", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Status = nil
+
+		result, err := vc.CheckStatus(context.Background(), WithStatusListResolver(revocationList))
+		require.NoError(t, err)
+		require.False(t, result.Set)
+	})
+
+	t.Run("index out of range", func(t *testing.T) {
+		_, err := newVC(t, "revocation", "200000").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), listOpts)
+		require.Error(t, err)
+
+		var indexErr *StatusListIndexError
+
+		require.True(t, errors.As(err, &indexErr))
+		require.Equal(t, 200000, indexErr.Index)
+	})
+
+	t.Run("status list over HTTP", func(t *testing.T) {
+		listBytes := newStatusList(t, StatusPurposeRevocation, true)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/status/3" {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+
+			_, _ = w.Write(listBytes) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		vc := newVC(t, "revocation", "94567")
+		vc.Status.CustomFields["statusListCredential"] = server.URL + "/status/3"
+
+		result, err := vc.CheckStatus(context.Background(),
+			WithStatusListResolver(NewHTTPStatusListResolver(server.Client())), listOpts)
+		require.NoError(t, err)
+		require.True(t, result.Revoked())
+
+		vc.Status.CustomFields["statusListCredential"] = server.URL + "/status/4"
+
+		_, err = vc.CheckStatus(context.Background(), listOpts)
+		require.ErrorContains(t, err, "status list endpoint HTTP failure [404]")
+	})
+
+	t.Run("status list over HTTP is too large", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(make([]byte, maxStatusListSize+1)) //nolint:errcheck
+		}))
+		defer server.Close()
+
+		_, err := NewHTTPStatusListResolver(server.Client()).Resolve(context.Background(), server.URL)
+		require.EqualError(t, err, fmt.Sprintf("status list exceeds %d bytes", maxStatusListSize))
+
+		require.Equal(t, defaultStatusListTimeout, NewHTTPStatusListResolver(nil).client.Timeout)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		vc := newVC(t, "revocation", "94567")
+		vc.Status.Type = "RevocationList2020Status"
+
+		_, err := vc.CheckStatus(context.Background(), WithStatusListResolver(revocationList))
+		require.EqualError(t, err, "check credential status: unsupported credentialStatus type RevocationList2020Status")
+
+		_, err = newVC(t, "revocation", "x").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList))
+		require.ErrorContains(t, err, "invalid statusListIndex")
+
+		vc = newVC(t, "revocation", "94567")
+		vc.Status.CustomFields["statusListCredential"] = "https://example.com/credentials/status/4"
+
+		_, err = vc.CheckStatus(context.Background(), WithStatusListResolver(revocationList))
+		require.ErrorContains(t, err, "resolve status list https://example.com/credentials/status/4")
+
+		// the proof of the status list is verified
+		_, err = newVC(t, "revocation", "94567").CheckStatus(context.Background(),
+			WithStatusListResolver(revocationList), WithStatusListCredentialOpts(
+				WithJSONLDDocumentLoader(loader),
+				WithPublicKeyFetcher(SingleKey(make([]byte, 32), kms.ED25519))))
+		require.ErrorContains(t, err, "parse status list credential")
+
+		unsecuredList := &mockStatusListResolver{lists: map[string][]byte{
+			statusListURL: newStatusList(t, StatusPurposeRevocation, false),
+		}}
+
+		_, err = newVC(t, "revocation", "94567").CheckStatus(context.Background(),
+			WithStatusListResolver(unsecuredList), listOpts)
+		require.ErrorContains(t, err, "is not secured by a proof")
+	})
+}
@@ -0,0 +1,245 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
+)
+
+// mockStatusList is a StatusChecker backed by a single in-memory status list.
+type mockStatusList struct {
+	bits map[int]bool
+	err  error
+}
+
+func (l *mockStatusList) StatusBit(status *TypedID) (bool, error) {
+	if l.err != nil {
+		return false, l.err
+	}
+
+	idx, err := strconv.Atoi(status.CustomFields["statusListIndex"].(string))
+	if err != nil {
+		return false, err
+	}
+
+	return l.bits[idx], nil
+}
+
+func TestWithRequireStatus(t *testing.T) {
+	t.Run("credential with status", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithRequireStatus())
+		require.NoError(t, err)
+		require.NotNil(t, vc.Status)
+	})
+
+	t.Run("credential without status", func(t *testing.T) {
+		var vcMap map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		delete(vcMap, "credentialStatus")
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		_, err = parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes, WithRequireStatus())
+		require.EqualError(t, err, "credential status is required but missing")
+		require.Nil(t, vc)
+	})
+}
+
+func TestIsRevoked(t *testing.T) {
+	statusList := &mockStatusList{bits: map[int]bool{94567: true}}
+
+	newVC := func(purpose, index string) *Credential {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Status = &TypedID{
+			ID:   "https://example.com/credentials/status/3#" + index,
+			Type: "StatusList2021Entry",
+			CustomFields: CustomFields{
+				"statusListIndex":      index,
+				"statusListCredential": "https://example.com/credentials/status/3",
+			},
+		}
+
+		if purpose != "" {
+			vc.Status.CustomFields["statusPurpose"] = purpose
+		}
+
+		return vc
+	}
+
+	t.Run("revoked credential", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC(StatusPurposeRevocation, "94567"), statusList)
+		require.NoError(t, err)
+		require.True(t, revoked)
+	})
+
+	t.Run("active credential", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC(StatusPurposeRevocation, "94568"), statusList)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("status without purpose is a revocation status", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC("", "94567"), statusList)
+		require.NoError(t, err)
+		require.True(t, revoked)
+	})
+
+	t.Run("suspension bit does not revoke", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC("suspension", "94567"), statusList)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("credential without status", func(t *testing.T) {
+		vc := newVC(StatusPurposeRevocation, "94567")
+		vc.Status = nil
+
+		revoked, err := IsRevoked(vc, statusList)
+		require.NoError(t, err)
+		require.False(t, revoked)
+	})
+
+	t.Run("status checker error", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC(StatusPurposeRevocation, "94567"),
+			&mockStatusList{err: errors.New("status list is not available")})
+		require.EqualError(t, err, "is revoked: status list is not available")
+		require.False(t, revoked)
+	})
+
+	t.Run("status checker is not defined", func(t *testing.T) {
+		revoked, err := IsRevoked(newVC(StatusPurposeRevocation, "94567"), nil)
+		require.EqualError(t, err, "is revoked: status checker is not defined")
+		require.False(t, revoked)
+	})
+
+	t.Run("credential is not defined", func(t *testing.T) {
+		revoked, err := IsRevoked(nil, statusList)
+		require.EqualError(t, err, "is revoked: credential is not defined")
+		require.False(t, revoked)
+	})
+}
+
+func TestDeriveStatusIndex(t *testing.T) {
+	const listSize = 131072
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	idx, err := DeriveStatusIndex(vc, listSize)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, idx, 0)
+	require.Less(t, idx, listSize)
+
+	t.Run("same credential maps to the same index", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			sameVC, e := parseTestCredential(t, []byte(validCredential))
+			require.NoError(t, e)
+
+			sameIdx, e := DeriveStatusIndex(sameVC, listSize)
+			require.NoError(t, e)
+			require.Equal(t, idx, sameIdx)
+		}
+	})
+
+	t.Run("proofs and status do not change the index", func(t *testing.T) {
+		signedVC, e := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, e)
+
+		signedVC.Proofs = []Proof{{"type": "Ed25519Signature2018", "jws": "mock-jws"}}
+		signedVC.Status = &TypedID{
+			ID:   "https://example.com/credentials/status/3#94567",
+			Type: "StatusList2021Entry",
+		}
+
+		signedIdx, e := DeriveStatusIndex(signedVC, listSize)
+		require.NoError(t, e)
+		require.Equal(t, idx, signedIdx)
+	})
+
+	t.Run("index is derived from the credential content", func(t *testing.T) {
+		otherVC, e := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, e)
+
+		otherVC.ID = "http://example.edu/credentials/1873"
+
+		otherIdx, e := DeriveStatusIndex(otherVC, listSize)
+		require.NoError(t, e)
+		require.NotEqual(t, idx, otherIdx)
+	})
+
+	t.Run("invalid list size", func(t *testing.T) {
+		_, e := DeriveStatusIndex(vc, 0)
+		require.EqualError(t, e, "derive status index: invalid status list size 0")
+	})
+
+	t.Run("credential is not defined", func(t *testing.T) {
+		_, e := DeriveStatusIndex(nil, listSize)
+		require.EqualError(t, e, "derive status index: credential is not defined")
+	})
+}
+
+func TestValidateStatusReference(t *testing.T) {
+	newVCBytes := func(statusListCredential interface{}) []byte {
+		vcMap, err := jsonutil.ToMap(validCredential)
+		require.NoError(t, err)
+
+		vcMap["@context"] = append(vcMap["@context"].([]interface{}), "https://w3id.org/vc/status-list/2021/v1")
+		vcMap["credentialStatus"] = map[string]interface{}{
+			"id":                   "https://example.edu/status/24#94567",
+			"type":                 "StatusList2021Entry",
+			"statusPurpose":        StatusPurposeRevocation,
+			"statusListIndex":      "94567",
+			"statusListCredential": statusListCredential,
+		}
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	t.Run("valid status references", func(t *testing.T) {
+		for _, ref := range []string{"https://example.edu/status/24", "did:example:123456#status-list"} {
+			vc, err := parseTestCredential(t, newVCBytes(ref), WithStrictValidation())
+			require.NoError(t, err)
+			require.Equal(t, ref, vc.Status.CustomFields["statusListCredential"])
+		}
+	})
+
+	t.Run("malformed status reference", func(t *testing.T) {
+		for _, ref := range []string{"not a uri", "/status/24", "https://"} {
+			_, err := parseTestCredential(t, newVCBytes(ref), WithStrictValidation())
+			require.Error(t, err)
+			require.EqualError(t, err, `invalid credentialStatus "https://example.edu/status/24#94567": `+
+				`statusListCredential "`+ref+`" is not a valid URI`)
+		}
+
+		_, err := parseTestCredential(t, newVCBytes(24), WithStrictValidation())
+		require.EqualError(t, err, `invalid credentialStatus "https://example.edu/status/24#94567": `+
+			`statusListCredential must be a string`)
+	})
+
+	t.Run("malformed status reference is accepted without strict validation", func(t *testing.T) {
+		vc, err := parseTestCredential(t, newVCBytes("not a uri"))
+		require.NoError(t, err)
+		require.NotNil(t, vc.Status)
+	})
+}
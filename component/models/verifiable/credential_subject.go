@@ -0,0 +1,159 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	validateTag          = "validate"
+	validateRuleRequired = "required"
+)
+
+// SubjectFieldError is a validation error of a single field of the credential subject.
+type SubjectFieldError struct {
+	// Field is the path of the field in the subject JSON, e.g. "degree.type".
+	Field string
+	// Rule is the failed validation rule, e.g. "required".
+	Rule string
+}
+
+// Error returns the field and the failed validation rule.
+func (e *SubjectFieldError) Error() string {
+	return fmt.Sprintf("field %s failed %s validation", e.Field, e.Rule)
+}
+
+// SubjectValidationError is returned by Credential.DecodeSubjectValidated if some of the decoded subject fields
+// are invalid.
+type SubjectValidationError struct {
+	Fields []*SubjectFieldError
+}
+
+// Error returns the errors of all invalid fields.
+func (e *SubjectValidationError) Error() string {
+	fieldErrs := make([]string, len(e.Fields))
+
+	for i, fieldErr := range e.Fields {
+		fieldErrs[i] = fieldErr.Error()
+	}
+
+	return "invalid credential subject: " + strings.Join(fieldErrs, "; ")
+}
+
+// DecodeSubjectValidated decodes the single subject of the credential into target, which must be a pointer to
+// a struct, and validates the decoded fields against their `validate` struct tags. Nested structs are validated
+// as well. The only rule supported for now is "required", which rejects a field left with its zero value.
+//
+// If any field is invalid, the returned error is a *SubjectValidationError listing every invalid field.
+func (vc *Credential) DecodeSubjectValidated(target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return errors.New("decode credential subject: target must be a non-nil pointer to struct")
+	}
+
+	subjectBytes, err := singleSubjectJSON(vc.Subject)
+	if err != nil {
+		return fmt.Errorf("decode credential subject: %w", err)
+	}
+
+	if err = json.Unmarshal(subjectBytes, target); err != nil {
+		return fmt.Errorf("decode credential subject: %w", err)
+	}
+
+	fieldErrs, err := validateStructFields(targetValue.Elem(), "")
+	if err != nil {
+		return fmt.Errorf("decode credential subject: %w", err)
+	}
+
+	if len(fieldErrs) > 0 {
+		return &SubjectValidationError{Fields: fieldErrs}
+	}
+
+	return nil
+}
+
+func singleSubjectJSON(subject interface{}) ([]byte, error) {
+	subjectBytes, err := json.Marshal(subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects []json.RawMessage
+
+	if json.Unmarshal(subjectBytes, &subjects) == nil {
+		if len(subjects) != 1 {
+			return nil, fmt.Errorf("expected single subject, got %d", len(subjects))
+		}
+
+		subjectBytes = subjects[0]
+	}
+
+	var subjectObj map[string]json.RawMessage
+
+	if err = json.Unmarshal(subjectBytes, &subjectObj); err != nil || subjectObj == nil {
+		return nil, errors.New("subject is not an object")
+	}
+
+	return subjectBytes, nil
+}
+
+func validateStructFields(v reflect.Value, path string) ([]*SubjectFieldError, error) {
+	var fieldErrs []*SubjectFieldError
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := joinClaimPath(path, jsonFieldName(field))
+		fieldValue := v.Field(i)
+
+		if rules, ok := field.Tag.Lookup(validateTag); ok {
+			for _, rule := range strings.Split(rules, ",") {
+				switch rule {
+				case validateRuleRequired:
+					if fieldValue.IsZero() {
+						fieldErrs = append(fieldErrs, &SubjectFieldError{Field: fieldPath, Rule: rule})
+					}
+				case "":
+				default:
+					return nil, fmt.Errorf("field %s: unsupported validation rule %q", fieldPath, rule)
+				}
+			}
+		}
+
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			fieldValue = fieldValue.Elem()
+		}
+
+		if fieldValue.Kind() == reflect.Struct {
+			nestedErrs, err := validateStructFields(fieldValue, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+
+			fieldErrs = append(fieldErrs, nestedErrs...)
+		}
+	}
+
+	return fieldErrs, nil
+}
+
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
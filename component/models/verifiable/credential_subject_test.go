@@ -0,0 +1,118 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testDegree struct {
+	Type string `json:"type" validate:"required"`
+	Name string `json:"name,omitempty"`
+}
+
+type testDegreeSubject struct {
+	ID     string      `json:"id" validate:"required"`
+	Name   string      `json:"name" validate:"required"`
+	Degree *testDegree `json:"degree"`
+}
+
+func TestCredential_DecodeSubjectValidated(t *testing.T) {
+	newVC := func(subject interface{}) *Credential {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Subject = subject
+
+		return vc
+	}
+
+	t.Run("valid subject", func(t *testing.T) {
+		vc := newVC([]Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{
+				"name":   "Jayden Doe",
+				"degree": map[string]interface{}{"type": "BachelorDegree", "name": "Bachelor of Science"},
+			},
+		}})
+
+		var subject testDegreeSubject
+
+		require.NoError(t, vc.DecodeSubjectValidated(&subject))
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject.ID)
+		require.Equal(t, "Jayden Doe", subject.Name)
+		require.Equal(t, "BachelorDegree", subject.Degree.Type)
+	})
+
+	t.Run("required fields are missing", func(t *testing.T) {
+		vc := newVC([]Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{
+				"degree": map[string]interface{}{"name": "Bachelor of Science"},
+			},
+		}})
+
+		var subject testDegreeSubject
+
+		err := vc.DecodeSubjectValidated(&subject)
+		require.EqualError(t, err, "invalid credential subject: field name failed required validation; "+
+			"field degree.type failed required validation")
+
+		var validationErr *SubjectValidationError
+
+		require.True(t, errors.As(err, &validationErr))
+		require.Equal(t, []*SubjectFieldError{
+			{Field: "name", Rule: "required"},
+			{Field: "degree.type", Rule: "required"},
+		}, validationErr.Fields)
+	})
+
+	t.Run("subject as map", func(t *testing.T) {
+		vc := newVC(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+
+		var subject testDegreeSubject
+
+		err := vc.DecodeSubjectValidated(&subject)
+		require.EqualError(t, err, "invalid credential subject: field name failed required validation")
+		require.Equal(t, "did:example:ebfeb1f712ebc6f1c276e12ec21", subject.ID)
+	})
+
+	t.Run("several subjects", func(t *testing.T) {
+		vc := newVC([]Subject{{ID: "did:example:1"}, {ID: "did:example:2"}})
+
+		err := vc.DecodeSubjectValidated(&testDegreeSubject{})
+		require.EqualError(t, err, "decode credential subject: expected single subject, got 2")
+	})
+
+	t.Run("subject is not an object", func(t *testing.T) {
+		vc := newVC("did:example:ebfeb1f712ebc6f1c276e12ec21")
+
+		err := vc.DecodeSubjectValidated(&testDegreeSubject{})
+		require.EqualError(t, err, "decode credential subject: subject is not an object")
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		vc := newVC(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+
+		err := vc.DecodeSubjectValidated(testDegreeSubject{})
+		require.EqualError(t, err, "decode credential subject: target must be a non-nil pointer to struct")
+	})
+
+	t.Run("unsupported validation rule", func(t *testing.T) {
+		vc := newVC(map[string]interface{}{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"})
+
+		var subject struct {
+			ID string `json:"id" validate:"url"`
+		}
+
+		err := vc.DecodeSubjectValidated(&subject)
+		require.EqualError(t, err, `decode credential subject: field id: unsupported validation rule "url"`)
+	})
+}
@@ -0,0 +1,54 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+)
+
+// Subjects returns the subjects of the Verifiable Credential whatever form Subject has, e.g. a single subject,
+// an array of subjects or a subject ID. Nil is returned if the credential has no subject or the subject can't
+// be represented as Subject. Use SubjectID to get the ID of a credential with a single subject.
+func (vc *Credential) Subjects() []Subject {
+	switch s := vc.Subject.(type) {
+	case []Subject:
+		return s
+	case Subject:
+		return []Subject{s}
+	}
+
+	subjectBytes, err := subjectToBytes(vc.Subject)
+	if err != nil {
+		return nil
+	}
+
+	subjects, err := parseSubject(subjectBytes)
+	if err != nil {
+		return nil
+	}
+
+	switch s := subjects.(type) {
+	case []Subject:
+		return s
+	case string:
+		return []Subject{{ID: s}}
+	default:
+		return nil
+	}
+}
+
+func isJSONArray(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+}
+
+func wrapJSONArray(data []byte) []byte {
+	wrapped := make([]byte, 0, len(data)+2)
+	wrapped = append(wrapped, '[')
+	wrapped = append(wrapped, data...)
+
+	return append(wrapped, ']')
+}
@@ -0,0 +1,131 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestCredential_Subjects(t *testing.T) {
+	withSubject := func(t *testing.T, subject interface{}) []byte {
+		t.Helper()
+
+		vcMap := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+		vcMap["credentialSubject"] = subject
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		return vcBytes
+	}
+
+	marshalledSubject := func(t *testing.T, vc *Credential) interface{} {
+		t.Helper()
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(vcBytes, &raw))
+
+		return raw["credentialSubject"]
+	}
+
+	alice := map[string]interface{}{"id": "did:example:alice", "name": "Alice"}
+	bob := map[string]interface{}{"id": "did:example:bob", "name": "Bob"}
+
+	t.Run("single object", func(t *testing.T) {
+		vc, err := parseTestCredential(t, withSubject(t, alice))
+		require.NoError(t, err)
+
+		subjects := vc.Subjects()
+		require.Len(t, subjects, 1)
+		require.Equal(t, "did:example:alice", subjects[0].ID)
+
+		subjectID, err := SubjectID(vc.Subject)
+		require.NoError(t, err)
+		require.Equal(t, "did:example:alice", subjectID)
+
+		require.Equal(t, alice, marshalledSubject(t, vc))
+	})
+
+	t.Run("array of one subject", func(t *testing.T) {
+		vc, err := parseTestCredential(t, withSubject(t, []interface{}{alice}))
+		require.NoError(t, err)
+
+		subjects := vc.Subjects()
+		require.Len(t, subjects, 1)
+		require.Equal(t, "did:example:alice", subjects[0].ID)
+
+		require.Equal(t, []interface{}{alice}, marshalledSubject(t, vc))
+	})
+
+	t.Run("array of several subjects", func(t *testing.T) {
+		vc, err := parseTestCredential(t, withSubject(t, []interface{}{alice, bob}))
+		require.NoError(t, err)
+
+		subjects := vc.Subjects()
+		require.Len(t, subjects, 2)
+		require.Equal(t, "did:example:alice", subjects[0].ID)
+		require.Equal(t, "did:example:bob", subjects[1].ID)
+		require.Equal(t, "Bob", subjects[1].CustomFields["name"])
+
+		_, err = SubjectID(vc.Subject)
+		require.EqualError(t, err, "more than one subject is defined")
+
+		require.Equal(t, []interface{}{alice, bob}, marshalledSubject(t, vc))
+	})
+
+	t.Run("subject of other types", func(t *testing.T) {
+		vc := &Credential{Subject: "did:example:alice"}
+		require.Equal(t, []Subject{{ID: "did:example:alice"}}, vc.Subjects())
+
+		vc.Subject = []map[string]interface{}{alice, bob}
+		require.Len(t, vc.Subjects(), 2)
+
+		vc.Subject = nil
+		require.Empty(t, vc.Subjects())
+	})
+
+	t.Run("signature of array of one subject", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		sigSuite := ed25519signature2018.New(
+			suite.WithSigner(signer),
+			suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+		vc, err := parseTestCredential(t, withSubject(t, []interface{}{alice}))
+		require.NoError(t, err)
+
+		require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   sigSuite,
+			VerificationMethod:      vc.Issuer.ID + "#key1",
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t))))
+
+		vcBytes, err := vc.MarshalJSON()
+		require.NoError(t, err)
+
+		vc, err = parseTestCredential(t, vcBytes,
+			WithEmbeddedSignatureSuites(sigSuite),
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{alice}, marshalledSubject(t, vc))
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
+	utiltime "github.com/hyperledger/aries-framework-go/component/models/util/time"
 )
 
 const singleCredentialSubject = `
@@ -129,6 +130,33 @@ func TestParseCredential(t *testing.T) {
 	})
 }
 
+func TestParseCredentialWithEmptyProof(t *testing.T) {
+	for name, proof := range map[string]interface{}{
+		"empty object": map[string]interface{}{},
+		"empty array":  []interface{}{},
+	} {
+		t.Run(name, func(t *testing.T) {
+			vcMap := map[string]interface{}{}
+			require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+			vcMap["proof"] = proof
+
+			vcBytes, err := json.Marshal(vcMap)
+			require.NoError(t, err)
+
+			vc, err := parseTestCredential(t, vcBytes)
+			require.NoError(t, err)
+			require.Empty(t, vc.Proofs)
+			require.Empty(t, vc.ProofDetails())
+			require.True(t, vc.NoProof())
+
+			vcBytes, err = vc.MarshalJSON()
+			require.NoError(t, err)
+			require.NotContains(t, string(vcBytes), `"proof"`)
+		})
+	}
+}
+
 func TestParseCredentialWithoutIssuanceDate(t *testing.T) {
 	t.Run("test creation of new Verifiable Credential with disabled issuance date check", func(t *testing.T) {
 		schema := JSONSchemaLoader(WithDisableRequiredField("issuanceDate"))
@@ -545,6 +573,91 @@ func TestValidateVerCredExpirationDate(t *testing.T) {
 	}
 }
 
+func TestValidateVerCredDatesOrder(t *testing.T) {
+	t.Run("test verifiable credential with correctly ordered dates", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithStrictValidation())
+		require.NoError(t, err)
+		require.NoError(t, vc.validateDatesOrder())
+
+		vc.Expired = vc.Issued
+		require.NoError(t, vc.validateDatesOrder())
+
+		vc.Expired = nil
+		require.NoError(t, vc.validateDatesOrder())
+	})
+
+	t.Run("test verifiable credential with expiration date before issuance date", func(t *testing.T) {
+		var raw rawCredential
+
+		require.NoError(t, json.Unmarshal([]byte(validCredential), &raw))
+		raw.Issued, raw.Expired = raw.Expired, raw.Issued
+		bytes, err := json.Marshal(raw)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, bytes, WithStrictValidation())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "violated dates order: expirationDate 2010-01-01T19:23:24Z "+
+			"is before issuanceDate 2020-01-01T19:23:24Z")
+		require.Nil(t, vc)
+
+		// without strict validation inverted dates are accepted
+		vc, err = parseTestCredential(t, bytes)
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+}
+
+func TestCredential_ValidateTypeContexts(t *testing.T) {
+	loader := createTestDocumentLoader(t)
+
+	t.Run("all types are defined", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential), WithStrictValidation())
+		require.NoError(t, err)
+
+		vc.Types = append(vc.Types, "UniversityDegreeCredential")
+
+		require.NoError(t, vc.ValidateTypeContexts(WithJSONLDDocumentLoader(loader)))
+	})
+
+	t.Run("type without its defining context", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Context = []string{baseContext}
+		vc.Types = append(vc.Types, "UniversityDegreeCredential")
+
+		require.EqualError(t, vc.ValidateTypeContexts(WithJSONLDDocumentLoader(loader)),
+			"violated @context constraint: type UniversityDegreeCredential is not defined by the credential contexts")
+
+		// the context can be provided externally
+		require.NoError(t, vc.ValidateTypeContexts(WithJSONLDDocumentLoader(loader),
+			WithExternalJSONLDContext("https://www.w3.org/2018/credentials/examples/v1")))
+	})
+
+	t.Run("strict validation of credential with undefined type", func(t *testing.T) {
+		vcJSON := `{
+  "@context": "https://www.w3.org/2018/credentials/v1",
+  "id": "http://example.edu/credentials/1872",
+  "type": ["VerifiableCredential", "UniversityDegreeCredential"],
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21"
+  },
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+
+		vc, err := parseTestCredential(t, []byte(vcJSON), WithStrictValidation())
+		require.EqualError(t, err,
+			"violated @context constraint: type UniversityDegreeCredential is not defined by the credential contexts")
+		require.Nil(t, vc)
+
+		// without strict validation the type is accepted
+		vc, err = parseTestCredential(t, []byte(vcJSON))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+}
+
 func TestValidateVerCredStatus(t *testing.T) {
 	t.Run("test verifiable credential with empty credential status", func(t *testing.T) {
 		var raw rawCredential
@@ -797,6 +910,282 @@ func TestCredential_MarshalJSON(t *testing.T) {
 	})
 }
 
+func TestCredential_MarshalJSONOmitEmpty(t *testing.T) {
+	t.Run("empty credentialSchema and null proof are omitted", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Proofs = []Proof{nil}
+		vc.CustomFields = CustomFields{
+			"credentialSchema": []interface{}{},
+			"validUntil":       nil,
+		}
+		vc.Subject = []Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{
+				"name":   "Jayden Doe",
+				"degree": map[string]interface{}{},
+			},
+		}}
+
+		byteCred, err := vc.MarshalJSON()
+		require.NoError(t, err)
+		require.Contains(t, string(byteCred), `"credentialSchema":[]`)
+		require.Contains(t, string(byteCred), `"proof":null`)
+
+		byteCred, err = vc.MarshalJSONOmitEmpty()
+		require.NoError(t, err)
+
+		var credMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(byteCred, &credMap))
+		require.NotContains(t, credMap, "credentialSchema")
+		require.NotContains(t, credMap, "proof")
+		require.NotContains(t, credMap, "validUntil")
+		// claims are not pruned
+		require.Equal(t, map[string]interface{}{
+			"id":     "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"name":   "Jayden Doe",
+			"degree": map[string]interface{}{},
+		}, credMap["credentialSubject"])
+
+		vc2, err := parseTestCredential(t, byteCred)
+		require.NoError(t, err)
+		require.Equal(t, vc.ID, vc2.ID)
+		require.Empty(t, vc2.Proofs)
+	})
+
+	t.Run("numbers keep their precision", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.CustomFields = CustomFields{"accountNumber": json.Number("9007199254740993")}
+
+		byteCred, err := vc.MarshalJSONOmitEmpty()
+		require.NoError(t, err)
+		require.Contains(t, string(byteCred), `"accountNumber":9007199254740993`)
+	})
+
+	t.Run("required members are kept", func(t *testing.T) {
+		vc := &Credential{
+			Context: []string{baseContext},
+			Types:   []string{vcType},
+			Subject: map[string]interface{}{},
+			Issuer:  Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"},
+			Issued:  utiltime.NewTime(time.Now()),
+		}
+
+		byteCred, err := vc.MarshalJSONOmitEmpty()
+		require.NoError(t, err)
+
+		var credMap map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(byteCred, &credMap))
+		require.Contains(t, credMap, "credentialSubject")
+		require.Contains(t, credMap, "issuanceDate")
+	})
+
+	t.Run("JWT credential is marshalled as is", func(t *testing.T) {
+		vc := &Credential{JWT: "header.payload.signature"}
+
+		byteCred, err := vc.MarshalJSONOmitEmpty()
+		require.NoError(t, err)
+		require.Equal(t, `"header.payload.signature"`, string(byteCred))
+	})
+}
+
+func TestIssuer_NameForLang(t *testing.T) {
+	vcMap := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(validCredential), &vcMap))
+
+	vcMap["issuer"] = map[string]interface{}{
+		"id": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+		"name": []interface{}{
+			map[string]interface{}{"@value": "Example University", "@language": "en"},
+			map[string]interface{}{"@value": "Université Exemple", "@language": "fr"},
+		},
+	}
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, vcBytes, WithJSONLDValidation())
+	require.NoError(t, err)
+
+	require.Equal(t, "Example University", vc.Issuer.NameForLang("en"))
+	require.Equal(t, "Université Exemple", vc.Issuer.NameForLang("FR"))
+	require.Empty(t, vc.Issuer.NameForLang("de"))
+
+	t.Run("single language-tagged name", func(t *testing.T) {
+		issuer := Issuer{CustomFields: CustomFields{
+			"name": map[string]interface{}{"@value": "Example University", "@language": "en"},
+		}}
+
+		require.Equal(t, "Example University", issuer.NameForLang("en"))
+		require.Empty(t, issuer.NameForLang("fr"))
+	})
+
+	t.Run("plain name is used for any language", func(t *testing.T) {
+		issuer := Issuer{CustomFields: CustomFields{"name": "Example University"}}
+
+		require.Equal(t, "Example University", issuer.NameForLang("en"))
+		require.Equal(t, "Example University", issuer.NameForLang("fr"))
+	})
+
+	t.Run("untagged name is the fallback", func(t *testing.T) {
+		issuer := Issuer{CustomFields: CustomFields{"name": []interface{}{
+			map[string]interface{}{"@value": "Université Exemple", "@language": "fr"},
+			map[string]interface{}{"@value": "Example University"},
+		}}}
+
+		require.Equal(t, "Université Exemple", issuer.NameForLang("fr"))
+		require.Equal(t, "Example University", issuer.NameForLang("en"))
+	})
+
+	t.Run("issuer without name", func(t *testing.T) {
+		issuer := Issuer{ID: "did:example:76e12ec712ebc6f1c221ebfeb1f"}
+
+		require.Empty(t, issuer.NameForLang("en"))
+	})
+}
+
+func TestCredential_FlattenSubject(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	t.Run("UniversityDegree subject", func(t *testing.T) {
+		vc.Subject = []Subject{{
+			ID: "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			CustomFields: CustomFields{
+				"name":   "Jayden Doe",
+				"spouse": "did:example:c276e12ec21ebfeb1f712ebc6f1",
+				"degree": map[string]interface{}{
+					"type":       "BachelorDegree",
+					"university": "MIT",
+				},
+			},
+		}}
+
+		flat, err := vc.FlattenSubject()
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"id":                "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"name":              "Jayden Doe",
+			"spouse":            "did:example:c276e12ec21ebfeb1f712ebc6f1",
+			"degree.type":       "BachelorDegree",
+			"degree.university": "MIT",
+		}, flat)
+	})
+
+	t.Run("arrays and non-string values", func(t *testing.T) {
+		vc.Subject = map[string]interface{}{
+			"id": "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"alumniOf": []interface{}{
+				map[string]interface{}{"name": "MIT"},
+				"Example University",
+			},
+			"graduationYear": 2010,
+			"gpa":            3.75,
+			"honors":         true,
+			"minor":          nil,
+		}
+
+		flat, err := vc.FlattenSubject()
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"id":               "did:example:ebfeb1f712ebc6f1c276e12ec21",
+			"alumniOf[0].name": "MIT",
+			"alumniOf[1]":      "Example University",
+			"graduationYear":   "2010",
+			"gpa":              "3.75",
+			"honors":           "true",
+			"minor":            "",
+		}, flat)
+	})
+
+	t.Run("multiple subjects", func(t *testing.T) {
+		vc.Subject = []Subject{
+			{ID: "did:example:1", CustomFields: CustomFields{"name": "Jayden Doe"}},
+			{ID: "did:example:2", CustomFields: CustomFields{"name": "Morgan Doe"}},
+		}
+
+		flat, err := vc.FlattenSubject()
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{
+			"[0].id":   "did:example:1",
+			"[0].name": "Jayden Doe",
+			"[1].id":   "did:example:2",
+			"[1].name": "Morgan Doe",
+		}, flat)
+	})
+
+	t.Run("subject ID only", func(t *testing.T) {
+		vc.Subject = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+		flat, err := vc.FlattenSubject()
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{"id": "did:example:ebfeb1f712ebc6f1c276e12ec21"}, flat)
+	})
+
+	t.Run("no subject", func(t *testing.T) {
+		vc.Subject = nil
+
+		flat, err := vc.FlattenSubject()
+		require.NoError(t, err)
+		require.Empty(t, flat)
+	})
+
+	t.Run("subject of unsupported format", func(t *testing.T) {
+		vc.Subject = func() {}
+
+		flat, err := vc.FlattenSubject()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "flatten credential subject")
+		require.Nil(t, flat)
+	})
+}
+
+func TestCredential_ToIssuanceRequest(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vc.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+	t.Run("with suite option", func(t *testing.T) {
+		body, err := vc.ToIssuanceRequest(IssuanceOptions{
+			Type:               "Ed25519Signature2020",
+			VerificationMethod: "did:example:76e12ec712ebc6f1c221ebfeb1f#key-1",
+		})
+		require.NoError(t, err)
+
+		var request map[string]json.RawMessage
+
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.Len(t, request, 2)
+
+		vcCopy := *vc
+		vcCopy.Proofs = nil
+
+		require.JSONEq(t, string(vcCopy.byteJSON(t)), string(request["credential"]))
+		require.JSONEq(t, `{"type":"Ed25519Signature2020",`+
+			`"verificationMethod":"did:example:76e12ec712ebc6f1c221ebfeb1f#key-1"}`, string(request["options"]))
+
+		// the credential itself is not changed
+		require.Len(t, vc.Proofs, 1)
+	})
+
+	t.Run("without options", func(t *testing.T) {
+		body, err := vc.ToIssuanceRequest(IssuanceOptions{})
+		require.NoError(t, err)
+
+		var request map[string]json.RawMessage
+
+		require.NoError(t, json.Unmarshal(body, &request))
+		require.Len(t, request, 1)
+		require.Contains(t, request, "credential")
+	})
+}
+
 func TestWithPublicKeyFetcher(t *testing.T) {
 	credentialOpt := WithPublicKeyFetcher(SingleKey([]byte("test pubKey"), kms.ED25519))
 	require.NotNil(t, credentialOpt)
@@ -1729,6 +2118,66 @@ func TestParseCredentialFromRaw_PreserveDates(t *testing.T) {
 	require.Equal(t, rawMap["expirationDate"], "2030-01-01T00:00:00.000Z")
 }
 
+func TestParseCredentialWithDateOnlyValidFrom(t *testing.T) {
+	vcMap, err := jsonutil.ToMap(validCredential)
+	require.NoError(t, err)
+
+	vcMap["validFrom"] = "2024-01-01"
+
+	vcBytes, err := json.Marshal(vcMap)
+	require.NoError(t, err)
+
+	t.Run("date-only validFrom is decoded as midnight UTC", func(t *testing.T) {
+		vc, err := parseTestCredential(t, vcBytes, WithDateOnlyValidFrom())
+		require.NoError(t, err)
+		require.NotNil(t, vc.ValidFrom)
+		require.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), vc.ValidFrom.Time)
+		require.NotContains(t, vc.CustomFields, "validFrom")
+
+		// date-only format is preserved
+		rawMap, err := jsonutil.ToMap(vc.byteJSON(t))
+		require.NoError(t, err)
+		require.Equal(t, "2024-01-01", rawMap["validFrom"])
+	})
+
+	t.Run("date-only validFrom is kept as custom field only by default", func(t *testing.T) {
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Nil(t, vc.ValidFrom)
+		require.Equal(t, "2024-01-01", vc.CustomFields["validFrom"])
+	})
+
+	t.Run("RFC3339 validFrom", func(t *testing.T) {
+		vcMap["validFrom"] = "2024-01-01T10:00:00.123Z"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes)
+		require.NoError(t, err)
+		require.Equal(t, time.Date(2024, time.January, 1, 10, 0, 0, 123000000, time.UTC), vc.ValidFrom.Time)
+		require.NotContains(t, vc.CustomFields, "validFrom")
+
+		vc.ValidFrom = utiltime.NewTime(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC))
+
+		rawMap, err := jsonutil.ToMap(vc.byteJSON(t))
+		require.NoError(t, err)
+		require.Equal(t, "2025-01-01T00:00:00Z", rawMap["validFrom"])
+	})
+
+	t.Run("invalid validFrom", func(t *testing.T) {
+		vcMap["validFrom"] = "not a date"
+
+		vcBytes, err := json.Marshal(vcMap)
+		require.NoError(t, err)
+
+		vc, err := parseTestCredential(t, vcBytes, WithDateOnlyValidFrom())
+		require.NoError(t, err)
+		require.Nil(t, vc.ValidFrom)
+		require.Equal(t, "not a date", vc.CustomFields["validFrom"])
+	})
+}
+
 func TestCredential_validateCredential(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,48 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrCredentialExpired is returned when expirationDate of the credential is before the time given by
+	// WithCredExpirationCheck.
+	ErrCredentialExpired = errors.New("credential is expired")
+
+	// ErrCredentialNotYetValid is returned when issuanceDate (or validFrom) of the credential is after the time
+	// given by WithCredExpirationCheck.
+	ErrCredentialNotYetValid = errors.New("credential is not yet valid")
+)
+
+// WithCredExpirationCheck makes decoding of the credential fail with ErrCredentialExpired if its expirationDate
+// is before now, or with ErrCredentialNotYetValid if its issuanceDate or validFrom is after now. The clock is
+// given explicitly, e.g. to verify a historical credential as of a past time.
+func WithCredExpirationCheck(now time.Time) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.validityTime = &now
+	}
+}
+
+func (vc *Credential) checkValidityPeriod(now time.Time) error {
+	if vc.Expired != nil && vc.Expired.Time.Before(now) {
+		return fmt.Errorf("%w: expirationDate %s", ErrCredentialExpired, vc.Expired.FormatToString())
+	}
+
+	if vc.Issued != nil && vc.Issued.Time.After(now) {
+		return fmt.Errorf("%w: issuanceDate %s", ErrCredentialNotYetValid, vc.Issued.FormatToString())
+	}
+
+	if vc.ValidFrom != nil && vc.ValidFrom.Time.After(now) {
+		return fmt.Errorf("%w: validFrom %s", ErrCredentialNotYetValid, vc.ValidFrom.FormatToString())
+	}
+
+	return nil
+}
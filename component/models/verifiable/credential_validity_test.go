@@ -0,0 +1,42 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithCredExpirationCheck(t *testing.T) {
+	t.Run("credential is valid", func(t *testing.T) {
+		vc, err := parseTestCredential(t, []byte(validCredential),
+			WithCredExpirationCheck(time.Date(2015, time.June, 1, 0, 0, 0, 0, time.UTC)))
+		require.NoError(t, err)
+		require.NotNil(t, vc)
+	})
+
+	t.Run("credential is expired", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential),
+			WithCredExpirationCheck(time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)))
+		require.ErrorIs(t, err, ErrCredentialExpired)
+		require.EqualError(t, err, "credential is expired: expirationDate 2020-01-01T19:23:24Z")
+	})
+
+	t.Run("credential is not yet valid", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential),
+			WithCredExpirationCheck(time.Date(2009, time.January, 1, 0, 0, 0, 0, time.UTC)))
+		require.ErrorIs(t, err, ErrCredentialNotYetValid)
+		require.EqualError(t, err, "credential is not yet valid: issuanceDate 2010-01-01T19:23:24Z")
+	})
+
+	t.Run("validity period is not checked by default", func(t *testing.T) {
+		_, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+	})
+}
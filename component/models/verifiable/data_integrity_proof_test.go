@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -16,13 +17,13 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/jwkkid"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity"
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/ecdsa2019"
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/suite/eddsa2022"
 	"github.com/hyperledger/aries-framework-go/component/models/did"
 	kmsapi "github.com/hyperledger/aries-framework-go/spi/kms"
 	vdrspi "github.com/hyperledger/aries-framework-go/spi/vdr"
 )
 
-func Test_DataIntegrity_SignVerify(t *testing.T) {
-	vcJSON := `
+const dataIntegrityTestCredential = `
 {
   "@context": [
     "https://www.w3.org/2018/credentials/v1",
@@ -47,6 +48,8 @@ func Test_DataIntegrity_SignVerify(t *testing.T) {
 }
 `
 
+func Test_DataIntegrity_SignVerify(t *testing.T) {
+
 	kms, err := createKMS()
 	require.NoError(t, err)
 
@@ -101,7 +104,7 @@ func Test_DataIntegrity_SignVerify(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Run("credential", func(t *testing.T) {
-		vc, e := parseTestCredential(t, []byte(vcJSON), WithDisabledProofCheck(), WithStrictValidation())
+		vc, e := parseTestCredential(t, []byte(dataIntegrityTestCredential), WithDisabledProofCheck(), WithStrictValidation())
 		require.NoError(t, e)
 
 		e = vc.AddDataIntegrityProof(signContext, signer)
@@ -113,6 +116,39 @@ func Test_DataIntegrity_SignVerify(t *testing.T) {
 		_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(verifier), WithStrictValidation())
 		require.NoError(t, e)
 
+		t.Run("fail if proof purpose mismatch", func(t *testing.T) {
+			_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(verifier),
+				WithCredProofPurpose("authentication"))
+			require.ErrorContains(t, e,
+				"check embedded proof: proof proofPurpose assertionMethod does not match expected one")
+
+			_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(verifier),
+				WithCredProofPurpose("assertionMethod"))
+			require.NoError(t, e)
+		})
+
+		t.Run("fail if proof is created in the future", func(t *testing.T) {
+			futureVC, e := parseTestCredential(t, []byte(dataIntegrityTestCredential), WithDisabledProofCheck())
+			require.NoError(t, e)
+
+			created := time.Now().AddDate(10, 0, 0)
+			futureContext := *signContext
+			futureContext.Created = &created
+
+			require.NoError(t, futureVC.AddDataIntegrityProof(&futureContext, signer))
+
+			futureVCBytes, e := futureVC.MarshalJSON()
+			require.NoError(t, e)
+
+			_, e = parseTestCredential(t, futureVCBytes, WithDataIntegrityVerifier(verifier),
+				WithRejectFutureProofs(time.Minute))
+			require.ErrorContains(t, e, "is in the future")
+
+			_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(verifier),
+				WithRejectFutureProofs(time.Minute))
+			require.NoError(t, e)
+		})
+
 		t.Run("fail if not provided verifier", func(t *testing.T) {
 			_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(nil))
 			require.Error(t, e)
@@ -136,6 +172,21 @@ func Test_DataIntegrity_SignVerify(t *testing.T) {
 		)
 		require.NoError(t, e)
 
+		t.Run("fail if challenge or domain mismatch", func(t *testing.T) {
+			_, e = newTestPresentation(t, vpBytes,
+				WithPresDataIntegrityVerifier(verifier),
+				WithPresExpectedChallenge("other-challenge"),
+			)
+			require.EqualError(t, e,
+				"check embedded proof: proof challenge mock-challenge does not match expected one")
+
+			_, e = newTestPresentation(t, vpBytes,
+				WithPresDataIntegrityVerifier(verifier),
+				WithPresExpectedDomain("other-domain"),
+			)
+			require.EqualError(t, e, "check embedded proof: proof domain mock-domain does not match expected one")
+		})
+
 		t.Run("fail if not provided verifier", func(t *testing.T) {
 			_, e = parseTestCredential(t, vpBytes)
 			require.Error(t, e)
@@ -184,6 +235,133 @@ func Test_DataIntegrity_SignVerify(t *testing.T) {
 	})
 }
 
+func Test_DataIntegrity_Cryptosuites(t *testing.T) {
+	kms, err := createKMS()
+	require.NoError(t, err)
+
+	cr, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	docLoader := createTestDocumentLoader(t)
+
+	newVM := func(keyType kmsapi.KeyType, signingDID string) *did.VerificationMethod {
+		_, keyBytes, e := kms.CreateAndExportPubKeyBytes(keyType)
+		require.NoError(t, e)
+
+		key, e := jwkkid.BuildJWK(keyBytes, keyType)
+		require.NoError(t, e)
+
+		vm, e := did.NewVerificationMethodFromJWK(signingDID+"#key-1", "JsonWebKey2020", signingDID, key)
+		require.NoError(t, e)
+
+		return vm
+	}
+
+	const (
+		eddsaDID = "did:foo:eddsa"
+		ecdsaDID = "did:foo:ecdsa"
+	)
+
+	vms := map[string]*did.VerificationMethod{
+		eddsaDID: newVM(kmsapi.ED25519Type, eddsaDID),
+		ecdsaDID: newVM(kmsapi.ECDSAP256IEEEP1363, ecdsaDID),
+	}
+
+	resolver := resolveFunc(func(id string) (*did.DocResolution, error) {
+		return makeMockDIDResolution(id, vms[id], did.AssertionMethod), nil
+	})
+
+	signer, err := dataintegrity.NewSigner(&dataintegrity.Options{DIDResolver: resolver},
+		eddsa2022.NewSignerInitializer(&eddsa2022.SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     eddsa2022.WithLocalKMSSigner(kms, cr),
+		}),
+		ecdsa2019.NewSignerInitializer(&ecdsa2019.SignerInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SignerGetter:     ecdsa2019.WithLocalKMSSigner(kms, cr),
+			SuiteType:        ecdsa2019.SuiteTypeRDFC,
+		}),
+	)
+	require.NoError(t, err)
+
+	// a single verifier dispatches DataIntegrityProof proofs on their cryptosuite
+	verifier, err := dataintegrity.NewVerifier(&dataintegrity.Options{DIDResolver: resolver},
+		eddsa2022.NewVerifierInitializer(&eddsa2022.VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+		}),
+		ecdsa2019.NewVerifierInitializer(&ecdsa2019.VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SuiteType:        ecdsa2019.SuiteTypeRDFC,
+		}),
+	)
+	require.NoError(t, err)
+
+	ecdsaOnlyVerifier, err := dataintegrity.NewVerifier(&dataintegrity.Options{DIDResolver: resolver},
+		ecdsa2019.NewVerifierInitializer(&ecdsa2019.VerifierInitializerOptions{
+			LDDocumentLoader: docLoader,
+			SuiteType:        ecdsa2019.SuiteTypeRDFC,
+		}),
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		cryptoSuite string
+		signingDID  string
+	}{
+		{cryptoSuite: eddsa2022.SuiteType, signingDID: eddsaDID},
+		{cryptoSuite: ecdsa2019.SuiteTypeRDFC, signingDID: ecdsaDID},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.cryptoSuite, func(t *testing.T) {
+			vc, e := parseTestCredential(t, []byte(dataIntegrityTestCredential), WithDisabledProofCheck())
+			require.NoError(t, e)
+
+			e = vc.AddDataIntegrityProof(&DataIntegrityProofContext{
+				SigningKeyID: tc.signingDID + "#key-1",
+				CryptoSuite:  tc.cryptoSuite,
+			}, signer)
+			require.NoError(t, e)
+
+			require.Len(t, vc.Proofs, 1)
+			require.Equal(t, "DataIntegrityProof", vc.Proofs[0]["type"])
+			require.Equal(t, tc.cryptoSuite, vc.Proofs[0]["cryptosuite"])
+
+			vcBytes, e := vc.MarshalJSON()
+			require.NoError(t, e)
+
+			_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(verifier))
+			require.NoError(t, e)
+
+			t.Run("tampered credential", func(t *testing.T) {
+				tampered := strings.Replace(string(vcBytes), "2020-01-17T15:14:09.724Z", "2021-01-17T15:14:09.724Z", 1)
+				require.NotEqual(t, string(vcBytes), tampered)
+
+				_, e = parseTestCredential(t, []byte(tampered), WithDataIntegrityVerifier(verifier))
+				require.Error(t, e)
+				require.Contains(t, e.Error(), "failed to verify "+tc.cryptoSuite+" DI proof")
+			})
+		})
+	}
+
+	t.Run("cryptosuite not supported by verifier", func(t *testing.T) {
+		vc, e := parseTestCredential(t, []byte(dataIntegrityTestCredential), WithDisabledProofCheck())
+		require.NoError(t, e)
+
+		e = vc.AddDataIntegrityProof(&DataIntegrityProofContext{
+			SigningKeyID: eddsaDID + "#key-1",
+			CryptoSuite:  eddsa2022.SuiteType,
+		}, signer)
+		require.NoError(t, e)
+
+		vcBytes, e := vc.MarshalJSON()
+		require.NoError(t, e)
+
+		_, e = parseTestCredential(t, vcBytes, WithDataIntegrityVerifier(ecdsaOnlyVerifier))
+		require.ErrorIs(t, e, dataintegrity.ErrUnsupportedSuite)
+	})
+}
+
 type resolveFunc func(id string) (*did.DocResolution, error)
 
 func (f resolveFunc) Resolve(id string, opts ...vdrspi.DIDMethodOption) (*did.DocResolution, error) {
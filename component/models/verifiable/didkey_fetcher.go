@@ -0,0 +1,80 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose/jwk/jwksupport"
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+const (
+	didKeyPrefix = "did:key:"
+
+	ed25519VerificationKey2018 = "Ed25519VerificationKey2018"
+	jsonWebKey2020             = "JsonWebKey2020"
+)
+
+// DIDKeyResolverFetcher returns a PublicKeyFetcher which derives the public key of a did:key issuer directly from
+// the multibase-encoded key material of the DID, without any network call. Ed25519 and P-256 keys are supported.
+func DIDKeyResolverFetcher() PublicKeyFetcher {
+	return resolveDIDKey
+}
+
+func resolveDIDKey(issuerID, keyID string) (*verifier.PublicKey, error) {
+	if !strings.HasPrefix(issuerID, didKeyPrefix) {
+		return nil, fmt.Errorf("%s is not a did:key", issuerID)
+	}
+
+	methodID := strings.TrimPrefix(issuerID, didKeyPrefix)
+
+	// the key of did:key is identified by the fingerprint itself
+	if fragment := strings.TrimPrefix(keyID, "#"); fragment != "" && fragment != methodID {
+		return nil, fmt.Errorf("public key with KID %s is not found for DID %s", keyID, issuerID)
+	}
+
+	pubKeyBytes, code, err := fingerprint.PubKeyFromFingerprint(methodID)
+	if err != nil {
+		return nil, fmt.Errorf("resolve did:key %s: %w", issuerID, err)
+	}
+
+	switch code {
+	case fingerprint.ED25519PubKeyMultiCodec:
+		return &verifier.PublicKey{
+			Type:  ed25519VerificationKey2018,
+			Value: pubKeyBytes,
+		}, nil
+	case fingerprint.P256PubKeyMultiCodec:
+		return p256PublicKey(pubKeyBytes)
+	default:
+		return nil, fmt.Errorf("resolve did:key %s: unsupported key multicodec code [0x%x]", issuerID, code)
+	}
+}
+
+func p256PublicKey(pubKeyBytes []byte) (*verifier.PublicKey, error) {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), pubKeyBytes)
+	if x == nil {
+		return nil, errors.New("resolve did:key: invalid compressed P-256 public key")
+	}
+
+	j, err := jwksupport.JWKFromKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y})
+	if err != nil {
+		return nil, fmt.Errorf("resolve did:key: create JWK: %w", err)
+	}
+
+	return &verifier.PublicKey{
+		Type:  jsonWebKey2020,
+		Value: elliptic.Marshal(elliptic.P256(), x, y), //nolint:staticcheck
+		JWK:   j,
+	}, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"crypto/elliptic"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/util/fingerprint"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestDIDKeyResolverFetcher(t *testing.T) {
+	createDIDKeyJWT := func(t *testing.T, signer Signer, alg JWSAlgorithm, didKey, keyID string) []byte {
+		t.Helper()
+
+		vc, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		vc.Issuer.ID = didKey
+
+		jwtClaims, err := vc.JWTClaims(true)
+		require.NoError(t, err)
+
+		vcJWT, err := jwtClaims.MarshalJWS(alg, signer, keyID)
+		require.NoError(t, err)
+
+		return []byte(vcJWT)
+	}
+
+	t.Run("Ed25519", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		didKey, keyID := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+		vc, err := parseTestCredential(t, createDIDKeyJWT(t, signer, EdDSA, didKey, keyID),
+			WithPublicKeyFetcher(DIDKeyResolverFetcher()))
+		require.NoError(t, err)
+		require.Equal(t, didKey, vc.Issuer.ID)
+	})
+
+	t.Run("P-256", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ECDSAP256TypeIEEEP1363)
+		require.NoError(t, err)
+
+		x, y := elliptic.Unmarshal(elliptic.P256(), signer.PublicKeyBytes()) //nolint:staticcheck
+		require.NotNil(t, x)
+
+		didKey, keyID := fingerprint.CreateDIDKeyByCode(fingerprint.P256PubKeyMultiCodec,
+			elliptic.MarshalCompressed(elliptic.P256(), x, y))
+
+		vc, err := parseTestCredential(t, createDIDKeyJWT(t, signer, ES256, didKey, keyID),
+			WithPublicKeyFetcher(DIDKeyResolverFetcher()))
+		require.NoError(t, err)
+		require.Equal(t, didKey, vc.Issuer.ID)
+	})
+
+	t.Run("key of another DID", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		didKey, _ := fingerprint.CreateDIDKey(signer.PublicKeyBytes())
+
+		otherSigner, err := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		otherDIDKey, _ := fingerprint.CreateDIDKey(otherSigner.PublicKeyBytes())
+		keyID := didKey + "#" + strings.TrimPrefix(otherDIDKey, "did:key:")
+
+		_, err = parseTestCredential(t, createDIDKeyJWT(t, otherSigner, EdDSA, didKey, keyID),
+			WithPublicKeyFetcher(DIDKeyResolverFetcher()))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "is not found for DID "+didKey)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := DIDKeyResolverFetcher()("did:example:76e12ec712ebc6f1c221ebfeb1f", "keys-1")
+		require.EqualError(t, err, "did:example:76e12ec712ebc6f1c221ebfeb1f is not a did:key")
+
+		_, err = DIDKeyResolverFetcher()("did:key:invalid", "")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "resolve did:key did:key:invalid")
+
+		// BLS12-381 G2 key
+		didKey, _ := fingerprint.CreateDIDKeyByCode(fingerprint.BLS12381g2PubKeyMultiCodec, make([]byte, 96))
+		_, err = DIDKeyResolverFetcher()(didKey, "")
+		require.EqualError(t, err, "resolve did:key "+didKey+": unsupported key multicodec code [0xeb]")
+	})
+}
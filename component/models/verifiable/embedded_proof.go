@@ -6,10 +6,12 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
 	jsonld "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
@@ -54,6 +56,38 @@ type embeddedProofCheckOpts struct {
 
 	ldpSuites []verifier.SignatureSuite
 
+	// suiteRegistry provides suites for the proof types not accepted by ldpSuites.
+	suiteRegistry *SuiteRegistry
+
+	// proofQuorum is a minimal number of valid proofs, all proofs must be valid if not set.
+	proofQuorum int
+
+	// proofVerificationMode defines how the proofs are verified if proofQuorum is not set.
+	proofVerificationMode ProofVerificationMode
+
+	// unknownProofPolicy defines how the proofs of unsupported types are handled.
+	unknownProofPolicy UnknownProofPolicy
+
+	// expectedChallenge is a challenge the linked data proofs must have, not checked if empty.
+	expectedChallenge string
+
+	// expectedDomain is a domain the linked data proofs must have, not checked if empty.
+	expectedDomain string
+
+	// expectedProofPurpose is a proof purpose the linked data proofs must have, not checked if empty.
+	expectedProofPurpose string
+
+	// proofPurposeResolver resolves the controller documents of the verification methods to check that
+	// they allow the expected proof purpose, not checked if nil.
+	proofPurposeResolver Resolver
+
+	// expectedBBSNonce is a nonce the BBS+ derived proofs must be bound to, not checked if nil.
+	expectedBBSNonce []byte
+
+	// rejectFutureProofs rejects the proofs created later than now plus futureProofSkew.
+	rejectFutureProofs bool
+	futureProofSkew    time.Duration
+
 	dataIntegrityOpts *verifyDataIntegrityOpts
 
 	jsonldCredentialOpts
@@ -73,7 +107,11 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 	delete(jsonldDoc, "jwt")
 
 	proofElement, ok := jsonldDoc["proof"]
-	if !ok || proofElement == nil {
+	if !ok || proofElement == nil || isEmptyProof(proofElement) {
+		if opts.expectedBBSNonce != nil {
+			return fmt.Errorf("check embedded proof: %w", errBBSDerivedProofMissing)
+		}
+
 		// do not make a check if there is no proof defined as proof presence is not mandatory
 		return nil
 	}
@@ -83,11 +121,24 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 		return fmt.Errorf("check embedded proof: %w", err)
 	}
 
+	if opts.unknownProofPolicy != UnknownProofError {
+		proofs, err = filterUnknownProofs(proofs, opts)
+		if err != nil {
+			return fmt.Errorf("check embedded proof: %w", err)
+		}
+
+		jsonldDoc["proof"] = proofsToElement(proofs)
+	}
+
 	if len(opts.externalContext) > 0 {
 		// Use external contexts for check of the linked data proofs to enrich JSON-LD context vocabulary.
 		jsonldDoc["@context"] = jsonld.AppendExternalContexts(jsonldDoc["@context"], opts.externalContext...)
 	}
 
+	if err = checkProofFields(proofs, documentDID(jsonldDoc), opts); err != nil {
+		return fmt.Errorf("check embedded proof: %w", err)
+	}
+
 	if len(proofs) > 0 {
 		typeStr, ok := proofs[0]["type"]
 		if ok && typeStr == models.DataIntegrityProof {
@@ -109,7 +160,8 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 		return errors.New("public key fetcher is not defined")
 	}
 
-	err = checkLinkedDataProof(jsonldDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts)
+	err = checkLinkedDataProof(jsonldDoc, ldpSuites, opts.publicKeyFetcher, &opts.jsonldCredentialOpts,
+		opts.proofQuorum, opts.proofVerificationMode)
 	if err != nil {
 		return fmt.Errorf("check embedded proof: %w", err)
 	}
@@ -117,6 +169,63 @@ func checkEmbeddedProof(docBytes []byte, opts *embeddedProofCheckOpts) error { /
 	return nil
 }
 
+// checkProofFields checks the fields of the proofs against the expected ones. It applies to the proofs
+// of every type, Data Integrity proofs included. Relative verification methods are resolved against baseDID.
+func checkProofFields(proofs []map[string]interface{}, baseDID string, opts *embeddedProofCheckOpts) error {
+	if opts.expectedChallenge != "" {
+		if err := checkProofField(proofs, "challenge", opts.expectedChallenge); err != nil {
+			return err
+		}
+	}
+
+	if opts.expectedDomain != "" {
+		if err := checkProofField(proofs, "domain", opts.expectedDomain); err != nil {
+			return err
+		}
+	}
+
+	if opts.expectedProofPurpose != "" {
+		if err := checkProofPurpose(proofs, opts.expectedProofPurpose, opts.proofPurposeResolver, baseDID); err != nil {
+			return err
+		}
+	}
+
+	if opts.rejectFutureProofs {
+		if err := checkProofsCreated(proofs, time.Now().Add(opts.futureProofSkew)); err != nil {
+			return err
+		}
+	}
+
+	if opts.expectedBBSNonce != nil {
+		if err := checkBBSNonce(proofs, opts.expectedBBSNonce); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkProofField checks that the field (e.g. challenge or domain) of every proof has the expected value.
+func checkProofField(proofs []map[string]interface{}, field, expected string) error {
+	for _, p := range proofs {
+		value, ok := p[field]
+		if !ok {
+			return fmt.Errorf("proof %s is missing", field)
+		}
+
+		valueStr, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("proof %s is not a string", field)
+		}
+
+		if valueStr != expected {
+			return fmt.Errorf("proof %s %s does not match expected one", field, valueStr)
+		}
+	}
+
+	return nil
+}
+
 // nolint:gocyclo
 func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]verifier.SignatureSuite, error) {
 	ldpSuites := opts.ldpSuites
@@ -124,7 +233,15 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	for i := range proofs {
 		t, err := getProofType(proofs[i])
 		if err != nil {
-			return nil, fmt.Errorf("check embedded proof: %w", err)
+			// the registry provides suites only for the proof types which are not built in.
+			registered, ok := registeredSuite(safeStringValue(proofs[i]["type"]), opts)
+			if !ok {
+				return nil, fmt.Errorf("check embedded proof: %w", err)
+			}
+
+			ldpSuites = append(ldpSuites, registered)
+
+			continue
 		}
 
 		if len(opts.ldpSuites) == 0 {
@@ -159,6 +276,77 @@ func getSuites(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([
 	return ldpSuites, nil
 }
 
+// registeredSuite returns the suite registered for the proof type unless it's accepted by the suites of options.
+func registeredSuite(proofType string, opts *embeddedProofCheckOpts) (verifier.SignatureSuite, bool) {
+	if opts.suiteRegistry == nil {
+		return nil, false
+	}
+
+	for _, s := range opts.ldpSuites {
+		if s.Accept(proofType) {
+			return nil, false
+		}
+	}
+
+	return opts.suiteRegistry.Lookup(proofType)
+}
+
+// checkProofsCreated checks that no proof is created after the latest acceptable time.
+func checkProofsCreated(proofs []map[string]interface{}, latest time.Time) error {
+	for _, p := range proofs {
+		createdStr, ok := p["created"].(string)
+		if !ok {
+			continue
+		}
+
+		created, err := time.Parse(time.RFC3339Nano, createdStr)
+		if err != nil {
+			return fmt.Errorf("parse proof created: %w", err)
+		}
+
+		if created.After(latest) {
+			return fmt.Errorf("proof created %s is in the future", createdStr)
+		}
+	}
+
+	return nil
+}
+
+var errBBSDerivedProofMissing = errors.New("BBS+ derived proof bound to the expected nonce is missing")
+
+// checkBBSNonce checks that there is at least one BBS+ derived proof and every such proof is bound to the expected
+// nonce.
+func checkBBSNonce(proofs []map[string]interface{}, expected []byte) error {
+	derived := 0
+
+	for _, p := range proofs {
+		if safeStringValue(p["type"]) != bbsBlsSignatureProof2020 {
+			continue
+		}
+
+		derived++
+
+		if _, ok := p["nonce"]; !ok {
+			return errors.New("proof nonce is missing")
+		}
+
+		nonce, err := getNonce(p)
+		if err != nil {
+			return fmt.Errorf("decode proof nonce: %w", err)
+		}
+
+		if !bytes.Equal(nonce, expected) {
+			return errors.New("proof nonce does not match expected one")
+		}
+	}
+
+	if derived == 0 {
+		return errBBSDerivedProofMissing
+	}
+
+	return nil
+}
+
 func getNonce(proof map[string]interface{}) ([]byte, error) {
 	if nonce, ok := proof["nonce"]; ok {
 		n, err := base64.StdEncoding.DecodeString(nonce.(string))
@@ -172,6 +360,43 @@ func getNonce(proof map[string]interface{}) ([]byte, error) {
 	return []byte{}, nil
 }
 
+// isEmptyProof checks if the proof is defined as an empty object or array, i.e. the document is unsigned.
+func isEmptyProof(proofElement interface{}) bool {
+	switch p := proofElement.(type) {
+	case map[string]interface{}:
+		return len(p) == 0
+	case []interface{}:
+		return len(p) == 0
+	default:
+		return false
+	}
+}
+
+// dropEmptyProof removes the empty top-level proof from the document, so it's validated and decoded as unsigned one.
+// Other members of the document are kept as is. A document which is not a JSON object is returned as is.
+func dropEmptyProof(docBytes []byte) ([]byte, error) {
+	var doc map[string]json.RawMessage
+
+	if err := json.Unmarshal(docBytes, &doc); err != nil {
+		return docBytes, nil //nolint:nilerr
+	}
+
+	proofBytes, ok := doc["proof"]
+	if !ok {
+		return docBytes, nil
+	}
+
+	var proofElement interface{}
+
+	if err := json.Unmarshal(proofBytes, &proofElement); err != nil || !isEmptyProof(proofElement) {
+		return docBytes, nil //nolint:nilerr
+	}
+
+	delete(doc, "proof")
+
+	return json.Marshal(doc)
+}
+
 func getProofs(proofElement interface{}) ([]map[string]interface{}, error) {
 	switch p := proofElement.(type) {
 	case map[string]interface{}:
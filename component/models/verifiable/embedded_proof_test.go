@@ -71,6 +71,11 @@ func Test_checkEmbeddedProof(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Empty proof is treated as absent", func(t *testing.T) {
+		r.NoError(checkEmbeddedProof([]byte(`{"proof":{}}`), defaultOpts))
+		r.NoError(checkEmbeddedProof([]byte(`{"proof":[]}`), defaultOpts))
+	})
+
 	t.Run("Happy path - two proofs", func(t *testing.T) {
 		vc, publicKeyFetcher := createVCWithTwoLinkedDataProofs(t)
 		vcBytes := vc.byteJSON(t)
@@ -208,3 +213,37 @@ func Test_getSuites(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, suites, 4)
 }
+
+func Test_dropEmptyProof(t *testing.T) {
+	t.Run("empty top-level proof is dropped", func(t *testing.T) {
+		doc, err := dropEmptyProof([]byte(`{"id":"vc","proof":{}}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"vc"}`, string(doc))
+
+		doc, err = dropEmptyProof([]byte(`{"id":"vc","proof":[]}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"id":"vc"}`, string(doc))
+	})
+
+	t.Run("other members are kept intact", func(t *testing.T) {
+		doc, err := dropEmptyProof([]byte(`{"credentialSubject":{"proof":{},"n":9007199254740993},"proof":[]}`))
+		require.NoError(t, err)
+		require.Equal(t, `{"credentialSubject":{"proof":{},"n":9007199254740993}}`, string(doc))
+	})
+
+	t.Run("nested empty proof claim is not dropped", func(t *testing.T) {
+		docBytes := []byte(`{"credentialSubject":{"proof":{}}}`)
+
+		doc, err := dropEmptyProof(docBytes)
+		require.NoError(t, err)
+		require.Equal(t, docBytes, doc)
+	})
+
+	t.Run("non-empty proof and non-object document are kept", func(t *testing.T) {
+		for _, docBytes := range [][]byte{[]byte(`{"proof":{"type":"Ed25519Signature2018"}}`), []byte(`"jwt"`)} {
+			doc, err := dropEmptyProof(docBytes)
+			require.NoError(t, err)
+			require.Equal(t, docBytes, doc)
+		}
+	})
+}
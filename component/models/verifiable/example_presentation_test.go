@@ -466,25 +466,12 @@ func ExamplePresentation_MarshalledCredentials() {
 
 	// Decode VP from JWS.
 	// Note that VC-s inside will be decoded as well. If they are JWS, their signature is verified
-	// and thus we need to make sure the public key fetcher can retrieve the public key.
+	// and thus we need to make sure the credential key fetcher can retrieve the issuer public key.
 	vp, err = verifiable.ParsePresentation(
 		[]byte(vpJWS),
-		verifiable.WithPresPublicKeyFetcher(func(issuerID, keyID string) (*verifier.PublicKey, error) {
-			switch keyID {
-			case "i-kid":
-				return &verifier.PublicKey{
-					Type:  kms.ED25519,
-					Value: issuerPubKey,
-				}, nil
-			case "h-kid":
-				return &verifier.PublicKey{
-					Type:  kms.ED25519,
-					Value: holderPubKey,
-				}, nil
-			default:
-				return nil, fmt.Errorf("unexpected key: %s", keyID)
-			}
-		}), verifiable.WithPresJSONLDDocumentLoader(getJSONLDDocumentLoader()))
+		verifiable.WithPresHolderKeyFetcher(verifiable.SingleKey(holderPubKey, kms.ED25519)),
+		verifiable.WithPresCredentialKeyFetcher(verifiable.SingleKey(issuerPubKey, kms.ED25519)),
+		verifiable.WithPresJSONLDDocumentLoader(getJSONLDDocumentLoader()))
 	if err != nil {
 		panic(fmt.Errorf("failed to decode VP JWS: %w", err))
 	}
@@ -564,6 +551,7 @@ func ExamplePresentation_AddLinkedDataProof() {
 		Suite:                   ed25519signature2018.New(suite.WithSigner(issuerSigner)),
 		SignatureRepresentation: verifiable.SignatureJWS,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}, jsonld.WithDocumentLoader(getJSONLDDocumentLoader()))
 	if err != nil {
 		panic(fmt.Errorf("failed to add linked data proof: %w", err))
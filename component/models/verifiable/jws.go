@@ -6,13 +6,22 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/hyperledger/aries-framework-go/component/kmscrypto/doc/jose"
 	"github.com/hyperledger/aries-framework-go/component/models/jwt"
 )
 
+const (
+	rsa2048SignatureSize = 256
+	p256SignatureSize    = 64
+	p384SignatureSize    = 96
+	p521SignatureSize    = 132
+)
+
 // Signer defines signer interface which is used to sign VC JWT.
 type Signer interface {
 	Sign(data []byte) ([]byte, error)
@@ -52,23 +61,135 @@ func (v noVerifier) Verify(_ jose.Headers, _, _, _ []byte) error {
 	return nil
 }
 
+type marshalJWSOpts struct {
+	omitEmptyKID bool
+}
+
+// MarshalJWSOpt is an option of MarshalJWS.
+type MarshalJWSOpt func(opts *marshalJWSOpts)
+
+// WithOmitEmptyKID omits the "kid" JWS header when MarshalJWS is given an empty key ID,
+// instead of emitting an empty "kid" which is rejected by some verifiers.
+func WithOmitEmptyKID() MarshalJWSOpt {
+	return func(opts *marshalJWSOpts) {
+		opts.omitEmptyKID = true
+	}
+}
+
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	algName, err := signatureAlg.Name()
+func marshalJWS(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...MarshalJWSOpt) (string, error) {
+	jwsOpts := &marshalJWSOpts{}
+
+	for _, opt := range opts {
+		opt(jwsOpts)
+	}
+
+	token, err := newSignedJWT(jwtClaims, signatureAlg, signer, keyID, jwsOpts)
 	if err != nil {
 		return "", err
 	}
 
-	headers := map[string]interface{}{
-		jose.HeaderKeyID: keyID,
+	return token.Serialize(false)
+}
+
+func newSignedJWT(jwtClaims interface{}, signatureAlg JWSAlgorithm, signer Signer,
+	keyID string, opts *marshalJWSOpts) (*jwt.JSONWebToken, error) {
+	algName, err := signatureAlg.Name()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = checkSignerAlg(signer, signatureAlg); err != nil {
+		return nil, err
+	}
+
+	headers := map[string]interface{}{}
+
+	if keyID != "" || !opts.omitEmptyKID {
+		headers[jose.HeaderKeyID] = keyID
+	}
+
+	return jwt.NewSigned(jwtClaims, headers, GetJWTSigner(signer, algName))
+}
+
+// checkSignerAlg checks that the signer key is of signatureAlg, so that a broken signature is not produced.
+// Signers which do not know their algorithm (empty Alg()) are not checked.
+func checkSignerAlg(signer Signer, signatureAlg JWSAlgorithm) error {
+	signerAlgName := signer.Alg()
+	if signerAlgName == "" {
+		return nil
 	}
 
-	token, err := jwt.NewSigned(jwtClaims, headers, GetJWTSigner(signer, algName))
+	signerAlg, err := ParseJWSAlgorithm(signerAlgName)
+	if err != nil || signerAlg != signatureAlg {
+		algName, _ := signatureAlg.Name() //nolint:errcheck
+
+		return fmt.Errorf("signer key of %s algorithm does not match %s signature algorithm", signerAlgName, algName)
+	}
+
+	return nil
+}
+
+// signingInputSigner captures JWS signing input instead of producing a signature.
+type signingInputSigner struct {
+	signingInput []byte
+}
+
+func (s *signingInputSigner) Sign(data []byte) ([]byte, error) {
+	s.signingInput = data
+
+	return nil, nil
+}
+
+func (s *signingInputSigner) Alg() string {
+	return ""
+}
+
+// jwsSigningInput returns JWS signing input (base64url(header) + "." + base64url(payload)) of JWT claims
+// and base64url encoded JWS header.
+func jwsSigningInput(jwtClaims interface{}, signatureAlg JWSAlgorithm, keyID string) ([]byte, string, error) {
+	signer := &signingInputSigner{}
+
+	_, err := newSignedJWT(jwtClaims, signatureAlg, signer, keyID, &marshalJWSOpts{})
 	if err != nil {
-		return "", err
+		return nil, "", err
 	}
 
-	return token.Serialize(false)
+	header, _, _ := strings.Cut(string(signer.signingInput), ".")
+
+	return signer.signingInput, header, nil
+}
+
+// estimateJWSSize returns the length of compact JWS of JWT claims with a dummy signature of signatureAlg size.
+func estimateJWSSize(jwtClaims interface{}, signatureAlg JWSAlgorithm, keyID string) (int, error) {
+	sigSize, err := signatureSize(signatureAlg)
+	if err != nil {
+		return 0, err
+	}
+
+	signingInput, _, err := jwsSigningInput(jwtClaims, signatureAlg, keyID)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(signingInput) + len(".") + base64.RawURLEncoding.EncodedLen(sigSize), nil
+}
+
+// signatureSize returns the typical size in bytes of a JWS signature of signatureAlg.
+func signatureSize(signatureAlg JWSAlgorithm) (int, error) {
+	switch signatureAlg {
+	case RS256, PS256:
+		return rsa2048SignatureSize, nil
+	case EdDSA, ECDSASecp256k1, ECDSASecp256r1:
+		return p256SignatureSize, nil
+	case ECDSASecp384r1:
+		return p384SignatureSize, nil
+	case ECDSASecp521r1:
+		return p521SignatureSize, nil
+	default:
+		return 0, fmt.Errorf("unsupported algorithm: %v", signatureAlg)
+	}
 }
 
 func unmarshalJWS(rawJwt string, checkProof bool, fetcher PublicKeyFetcher, claims interface{}) (jose.Headers, error) {
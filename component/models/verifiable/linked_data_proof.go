@@ -7,6 +7,7 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -23,6 +24,9 @@ const (
 
 type keyResolverAdapter struct {
 	pubKeyFetcher PublicKeyFetcher
+	// baseDID is the DID relative verification methods (e.g. "#key-1") are resolved against,
+	// i.e. the issuer of a credential or the holder of a presentation.
+	baseDID string
 }
 
 func (k *keyResolverAdapter) Resolve(id string) (*verifier.PublicKey, error) {
@@ -31,9 +35,19 @@ func (k *keyResolverAdapter) Resolve(id string) (*verifier.PublicKey, error) {
 	if len(idSplit) != resolveIDParts {
 		return nil, fmt.Errorf("wrong id %s to resolve", idSplit)
 	}
-	// idSplit[0] is didID
+
+	// idSplit[0] is didID, empty for relative id
 	// idSplit[1] is keyID
-	pubKey, err := k.pubKeyFetcher(idSplit[0], fmt.Sprintf("#%s", idSplit[1]))
+	didID := idSplit[0]
+	if didID == "" {
+		if k.baseDID == "" {
+			return nil, fmt.Errorf("no DID to resolve relative id %s against", id)
+		}
+
+		didID = k.baseDID
+	}
+
+	pubKey, err := k.pubKeyFetcher(didID, fmt.Sprintf("#%s", idSplit[1]))
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +55,23 @@ func (k *keyResolverAdapter) Resolve(id string) (*verifier.PublicKey, error) {
 	return pubKey, nil
 }
 
+// documentDID returns the DID relative verification methods of the document proofs are resolved against:
+// issuer of the credential or holder of the presentation.
+func documentDID(doc map[string]interface{}) string {
+	switch issuer := doc["issuer"].(type) {
+	case string:
+		return issuer
+	case map[string]interface{}:
+		id, _ := issuer["id"].(string)
+
+		return id
+	}
+
+	holder, _ := doc["holder"].(string)
+
+	return holder
+}
+
 // SignatureRepresentation is a signature value holder type (e.g. "proofValue" or "jws").
 type SignatureRepresentation int
 
@@ -57,32 +88,116 @@ type LinkedDataProofContext struct {
 	SignatureType           string                  // required
 	Suite                   signer.SignatureSuite   // required
 	SignatureRepresentation SignatureRepresentation // required
-	Created                 *time.Time              // optional
+	Created                 *time.Time              // optional, the current time by default
 	VerificationMethod      string                  // optional
 	Challenge               string                  // optional
 	Domain                  string                  // optional
 	Purpose                 string                  // optional
 	// CapabilityChain must be an array. Each element is either a string or an object.
 	CapabilityChain []interface{}
+	// AllowIssuerMismatch disables the check that the DID of VerificationMethod matches the issuer DID
+	// when adding a proof to a credential.
+	AllowIssuerMismatch bool
+	// ReplaceProofsOfType removes the existing proofs of SignatureType before adding the new proof,
+	// e.g. when the document was modified and these proofs are stale.
+	ReplaceProofsOfType bool
 }
 
 func checkLinkedDataProof(jsonldBytes map[string]interface{}, suites []verifier.SignatureSuite,
-	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts) error {
-	documentVerifier, err := verifier.New(&keyResolverAdapter{pubKeyFetcher}, suites...)
+	pubKeyFetcher PublicKeyFetcher, jsonldOpts *jsonldCredentialOpts, proofQuorum int,
+	mode ProofVerificationMode) error {
+	keyResolver := &keyResolverAdapter{pubKeyFetcher: pubKeyFetcher, baseDID: documentDID(jsonldBytes)}
+
+	documentVerifier, err := verifier.New(keyResolver, suites...)
 	if err != nil {
 		return fmt.Errorf("create new signature verifier: %w", err)
 	}
 
 	processorOpts := mapJSONLDProcessorOpts(jsonldOpts)
 
-	err = documentVerifier.VerifyObject(jsonldBytes, processorOpts...)
+	if proofQuorum <= 0 && mode == AtLeastOne {
+		proofQuorum = 1
+	}
+
+	if proofQuorum <= 0 && mode != RequireAll {
+		err = documentVerifier.VerifyObject(jsonldBytes, processorOpts...)
+		if err != nil {
+			return fmt.Errorf("check linked data proof: %w", err)
+		}
+
+		return nil
+	}
+
+	results, err := documentVerifier.VerifyObjectProofs(jsonldBytes, processorOpts...)
 	if err != nil {
 		return fmt.Errorf("check linked data proof: %w", err)
 	}
 
+	if proofQuorum <= 0 {
+		return checkAllProofs(results)
+	}
+
+	return checkProofQuorum(results, proofQuorum)
+}
+
+// checkProofQuorum checks that at least proofQuorum of the proofs are valid.
+func checkProofQuorum(results []verifier.ProofResult, proofQuorum int) error {
+	var (
+		valid int
+		errs  []error
+	)
+
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+
+			continue
+		}
+
+		valid++
+	}
+
+	if valid < proofQuorum {
+		return fmt.Errorf("check linked data proof: %d of %d proofs are valid while %d are required: %w",
+			valid, len(results), proofQuorum, errors.Join(errs...)) // nolint:typecheck
+	}
+
 	return nil
 }
 
+// checkAllProofs checks that all the proofs are valid, returning the result of each proof
+// in ProofVerificationError otherwise.
+func checkAllProofs(results []verifier.ProofResult) error {
+	for _, result := range results {
+		if result.Err != nil {
+			return fmt.Errorf("check linked data proof: %w", &ProofVerificationError{Results: results})
+		}
+	}
+
+	return nil
+}
+
+// ProofVerificationError is returned when some of the linked data proofs are invalid
+// in RequireAll proof verification mode.
+type ProofVerificationError struct {
+	// Results holds the verification result of each proof, in the order of the proofs.
+	Results []verifier.ProofResult
+}
+
+// Error returns the errors of the invalid proofs.
+func (e *ProofVerificationError) Error() string {
+	var errs []error
+
+	for _, result := range e.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+
+	return fmt.Sprintf("%d of %d proofs are invalid: %v", len(errs), len(e.Results),
+		errors.Join(errs...)) // nolint:typecheck
+}
+
 func mapJSONLDProcessorOpts(jsonldOpts *jsonldCredentialOpts) []ldprocessor.Opts {
 	var processorOpts []ldprocessor.Opts
 
@@ -130,6 +245,19 @@ func addLinkedDataProof(context *LinkedDataProofContext, jsonldBytes []byte,
 	return proofs, nil
 }
 
+// proofsNotOfType returns the proofs which are not of the given proof type.
+func proofsNotOfType(proofs []Proof, proofType string) []Proof {
+	var kept []Proof
+
+	for _, p := range proofs {
+		if p["type"] != proofType {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept
+}
+
 func mapContext(context *LinkedDataProofContext) *signer.Context {
 	return &signer.Context{
 		SignatureType:           context.SignatureType,
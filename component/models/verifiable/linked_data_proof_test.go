@@ -33,6 +33,26 @@ func Test_keyResolverAdapter_Resolve(t *testing.T) {
 		require.Equal(t, []byte(pubKey), resolvedPubKey.Value)
 	})
 
+	t.Run("relative id is resolved against base DID", func(t *testing.T) {
+		kra := &keyResolverAdapter{
+			pubKeyFetcher: func(issuerID, keyID string) (*verifier.PublicKey, error) {
+				require.Equal(t, "did:example:issuer", issuerID)
+				require.Equal(t, "#key-1", keyID)
+
+				return &verifier.PublicKey{Value: []byte("public key")}, nil
+			},
+			baseDID: "did:example:issuer",
+		}
+
+		resolvedPubKey, err := kra.Resolve("#key-1")
+		require.NoError(t, err)
+		require.Equal(t, []byte("public key"), resolvedPubKey.Value)
+
+		kra.baseDID = ""
+		_, err = kra.Resolve("#key-1")
+		require.EqualError(t, err, "no DID to resolve relative id #key-1 against")
+	})
+
 	t.Run("error wrong key format", func(t *testing.T) {
 		kra := &keyResolverAdapter{pubKeyFetcher: func(issuerID, keyID string) (*verifier.PublicKey, error) {
 			return nil, nil
@@ -165,6 +185,7 @@ func prepareVCWithEd25519LDP(t *testing.T, vcJSON string, signer Signer) *Creden
 		SignatureRepresentation: SignatureJWS,
 		Created:                 &created,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
 	require.NoError(t, err)
 
@@ -187,6 +208,7 @@ func prepareVCWithSecp256k1LDP(t *testing.T, vcJSON string, signer Signer) *Cred
 		Suite:                   ed25519SignerSuite,
 		SignatureRepresentation: SignatureJWS,
 		VerificationMethod:      "did:example:123456#key1",
+		AllowIssuerMismatch:     true,
 	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
 	require.NoError(t, err)
 
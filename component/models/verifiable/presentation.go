@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 
 	jsonld "github.com/piprate/json-gold/ld"
 	"github.com/xeipuuv/gojsonschema"
@@ -210,10 +211,11 @@ func WithCredentials(cs ...*Credential) CreatePresentationOpt {
 }
 
 // WithJWTCredentials sets the provided base64url encoded JWT credentials into the presentation.
+// SD-JWT credentials may be given in combined format, i.e. with disclosures and optional holder binding.
 func WithJWTCredentials(cs ...string) CreatePresentationOpt {
 	return func(p *Presentation) error {
 		for _, c := range cs {
-			if !jose.IsCompactJWS(c) {
+			if isSDJWT, _, _, _ := isJWTVC(c); !jose.IsCompactJWS(c) && !isSDJWT {
 				return errors.New("credential is not base64url encoded JWT")
 			}
 
@@ -288,6 +290,128 @@ func (vp *Presentation) MarshalledCredentials() ([]MarshalledCredential, error)
 	return mCreds, nil
 }
 
+// MergePresentations merges two presentations into a new one. Contexts (including custom ones) and types are
+// united, credentials are
+// concatenated skipping the ones of b having the same id as a credential already present. Proofs are not copied,
+// so the merged presentation has to be signed again.
+func MergePresentations(a, b *Presentation) (*Presentation, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("merge presentations: presentation is not defined")
+	}
+
+	if a.Holder != "" && b.Holder != "" && a.Holder != b.Holder {
+		return nil, fmt.Errorf("merge presentations: holders differ: %s and %s", a.Holder, b.Holder)
+	}
+
+	merged := &Presentation{
+		Context:       unionStrings(a.Context, b.Context),
+		CustomContext: unionCustomContext(a.CustomContext, b.CustomContext),
+		ID:            a.ID,
+		Type:          unionStrings(a.Type, b.Type),
+		Holder:        a.Holder,
+		credentials:   append([]interface{}{}, a.credentials...),
+	}
+
+	if merged.Holder == "" {
+		merged.Holder = b.Holder
+	}
+
+	ids := make(map[string]struct{})
+
+	for _, c := range a.credentials {
+		if id := credentialIDOf(c); id != "" {
+			ids[id] = struct{}{}
+		}
+	}
+
+	for _, c := range b.credentials {
+		id := credentialIDOf(c)
+		if id != "" {
+			if _, ok := ids[id]; ok {
+				continue
+			}
+
+			ids[id] = struct{}{}
+		}
+
+		merged.credentials = append(merged.credentials, c)
+	}
+
+	if len(a.CustomFields) > 0 || len(b.CustomFields) > 0 {
+		merged.CustomFields = make(CustomFields, len(a.CustomFields)+len(b.CustomFields))
+
+		for k, v := range b.CustomFields {
+			merged.CustomFields[k] = v
+		}
+
+		for k, v := range a.CustomFields {
+			merged.CustomFields[k] = v
+		}
+	}
+
+	return merged, nil
+}
+
+// credentialIDOf returns id of the credential enclosed into presentation.
+// JWT credential without known id is identified by the JWT itself.
+func credentialIDOf(cred interface{}) string {
+	switch c := cred.(type) {
+	case *Credential:
+		if c.ID == "" {
+			return c.JWT
+		}
+
+		return c.ID
+	case map[string]interface{}:
+		id, _ := c["id"].(string)
+
+		return id
+	case string:
+		return c
+	default:
+		return ""
+	}
+}
+
+func unionStrings(a, b []string) []string {
+	res := make([]string, 0, len(a)+len(b))
+	seen := make(map[string]struct{}, len(a)+len(b))
+
+	for _, s := range append(append([]string{}, a...), b...) {
+		if _, ok := seen[s]; ok {
+			continue
+		}
+
+		seen[s] = struct{}{}
+		res = append(res, s)
+	}
+
+	return res
+}
+
+// unionCustomContext concatenates custom contexts skipping the entries of b deeply equal to an entry of a.
+func unionCustomContext(a, b []interface{}) []interface{} {
+	res := append([]interface{}{}, a...)
+
+	for _, ctx := range b {
+		dup := false
+
+		for _, existing := range res {
+			if reflect.DeepEqual(existing, ctx) {
+				dup = true
+
+				break
+			}
+		}
+
+		if !dup {
+			res = append(res, ctx)
+		}
+	}
+
+	return res
+}
+
 func (vp *Presentation) raw() (*rawPresentation, error) {
 	proof, err := proofsToRaw(vp.Proofs)
 	if err != nil {
@@ -352,14 +476,23 @@ func (rp *rawPresentation) UnmarshalJSON(data []byte) error {
 
 // presentationOpts holds options for the Verifiable Presentation decoding.
 type presentationOpts struct {
-	publicKeyFetcher    PublicKeyFetcher
-	disabledProofCheck  bool
-	ldpSuites           []verifier.SignatureSuite
-	strictValidation    bool
-	requireVC           bool
-	requireProof        bool
-	disableJSONLDChecks bool
-	verifyDataIntegrity *verifyDataIntegrityOpts
+	publicKeyFetcher     PublicKeyFetcher
+	holderKeyFetcher     PublicKeyFetcher
+	credKeyFetcher       PublicKeyFetcher
+	disabledProofCheck   bool
+	ldpSuites            []verifier.SignatureSuite
+	strictValidation     bool
+	requireVC            bool
+	requireProof         bool
+	disableJSONLDChecks  bool
+	proofQuorum          int
+	verifyDataIntegrity  *verifyDataIntegrityOpts
+	definitionID         string
+	expectedAudience     string
+	expectedChallenge    string
+	expectedDomain       string
+	expectedProofPurpose string
+	proofPurposeResolver Resolver
 
 	jsonldCredentialOpts
 }
@@ -375,6 +508,38 @@ func WithPresPublicKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
 	}
 }
 
+// WithPresHolderKeyFetcher sets the public key fetcher used to verify the proof of Verifiable Presentation
+// made by its holder. It takes precedence over WithPresPublicKeyFetcher for the presentation proof.
+func WithPresHolderKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.holderKeyFetcher = fetcher
+	}
+}
+
+// WithPresCredentialKeyFetcher sets the public key fetcher used to verify the proofs of credentials enclosed
+// into Verifiable Presentation. It takes precedence over WithPresPublicKeyFetcher for the credential proofs.
+func WithPresCredentialKeyFetcher(fetcher PublicKeyFetcher) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.credKeyFetcher = fetcher
+	}
+}
+
+func (o *presentationOpts) holderPublicKeyFetcher() PublicKeyFetcher {
+	if o.holderKeyFetcher != nil {
+		return o.holderKeyFetcher
+	}
+
+	return o.publicKeyFetcher
+}
+
+func (o *presentationOpts) credentialPublicKeyFetcher() PublicKeyFetcher {
+	if o.credKeyFetcher != nil {
+		return o.credKeyFetcher
+	}
+
+	return o.publicKeyFetcher
+}
+
 // WithPresEmbeddedSignatureSuites defines the suites which are used to check embedded linked data proof of VP.
 func WithPresEmbeddedSignatureSuites(suites ...verifier.SignatureSuite) PresentationOpt {
 	return func(opts *presentationOpts) {
@@ -389,6 +554,14 @@ func WithPresDisabledProofCheck() PresentationOpt {
 	}
 }
 
+// WithPresProofQuorum sets a minimal number of valid embedded linked data proofs for the VP to be accepted
+// (e.g. for a VP signed by several holders). By default, all the proofs must be valid.
+func WithPresProofQuorum(quorum int) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.proofQuorum = quorum
+	}
+}
+
 // WithPresStrictValidation enabled strict JSON-LD validation of VP.
 // In case of JSON-LD validation, the comparison of JSON-LD VP document after compaction with original VP one is made.
 // In case of mismatch a validation exception is raised.
@@ -398,8 +571,43 @@ func WithPresStrictValidation() PresentationOpt {
 	}
 }
 
-// WithPresJSONLDDocumentLoader defines custom JSON-LD document loader. If not defined, when decoding VP
-// a new document loader will be created using CachingJSONLDLoader() if JSON-LD validation is made.
+// WithPresExpectedDefinitionID requires the presentation_submission of VP to respond to the presentation
+// definition with the given id (see JWTPresClaims.WithPresentationDefinitionID).
+func WithPresExpectedDefinitionID(id string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.definitionID = id
+	}
+}
+
+// WithPresExpectedAudience option is for checking that the "aud" claim of Verifiable Presentation in JWT form
+// contains did, e.g. the DID of the verifier. If it does not, or the presentation is not a JWT, the error returned
+// by ParsePresentation wraps ErrAudienceMismatch.
+func WithPresExpectedAudience(did string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.expectedAudience = did
+	}
+}
+
+// WithPresExpectedChallenge option is for checking that every linked data proof of Verifiable Presentation has
+// the challenge sent by the verifier, which prevents replay of the presentation. A presentation without proof
+// is rejected. For a presentation in JWS form the challenge is checked against the "nonce" claim.
+func WithPresExpectedChallenge(challenge string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.expectedChallenge = challenge
+	}
+}
+
+// WithPresExpectedDomain option is for checking that every linked data proof of Verifiable Presentation has
+// the domain of the verifier. A presentation without proof is rejected. For a presentation in JWS form
+// the domain is checked against the "aud" claim.
+func WithPresExpectedDomain(domain string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.expectedDomain = domain
+	}
+}
+
+// WithPresJSONLDDocumentLoader defines custom JSON-LD document loader used to resolve @context documents when
+// decoding VP and its credentials. If not defined, @context documents are fetched from the network.
 func WithPresJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) PresentationOpt {
 	return func(opts *presentationOpts) {
 		opts.jsonldDocumentLoader = documentLoader
@@ -458,11 +666,58 @@ func ParsePresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, e
 		return nil, fmt.Errorf("verifiableCredential is required")
 	}
 
+	if vpOpts.definitionID != "" {
+		if err = checkPresentationDefinitionID(p, vpOpts.definitionID); err != nil {
+			return nil, err
+		}
+	}
+
+	if vpOpts.expectedAudience != "" {
+		if err = checkPresentationAudience(vpData, vpOpts.expectedAudience); err != nil {
+			return nil, err
+		}
+	}
+
 	p.JWT = vpJWT
 
 	return p, nil
 }
 
+// VerifyPresentation parses and verifies Verifiable Presentation and all the credentials enclosed into it.
+// It returns the presentation together with decoded credentials in their order in the presentation.
+// The credentials are verified using the public key fetcher, embedded signature suites and JSON-LD document loader
+// of the presentation options.
+func VerifyPresentation(vpData []byte, opts ...PresentationOpt) (*Presentation, []*Credential, error) {
+	vp, err := ParsePresentation(vpData, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("verify presentation: %w", err)
+	}
+
+	credOpts := credentialOptsOfPresentation(getPresentationOpts(opts))
+
+	creds := make([]*Credential, 0, len(vp.credentials))
+
+	for i, c := range vp.credentials {
+		// credentials in string format (e.g. JWT) are already verified while parsing presentation.
+		vc, ok := c.(*Credential)
+		if !ok {
+			vcBytes, e := json.Marshal(c)
+			if e != nil {
+				return nil, nil, fmt.Errorf("verify presentation: marshal credential %d: %w", i, e)
+			}
+
+			vc, e = ParseCredential(vcBytes, credOpts...)
+			if e != nil {
+				return nil, nil, fmt.Errorf("verify presentation: credential %d: %w", i, e)
+			}
+		}
+
+		creds = append(creds, vc)
+	}
+
+	return vp, creds, nil
+}
+
 func getPresentationOpts(opts []PresentationOpt) *presentationOpts {
 	vpOpts := defaultPresentationOpts()
 
@@ -506,6 +761,22 @@ func newPresentation(vpRaw *rawPresentation, vpOpts *presentationOpts) (*Present
 	}, nil
 }
 
+// credentialOptsOfPresentation returns options to parse credentials enclosed into presentation.
+func credentialOptsOfPresentation(opts *presentationOpts) []CredentialOpt {
+	credOpts := []CredentialOpt{
+		WithPublicKeyFetcher(opts.credentialPublicKeyFetcher()),
+		WithEmbeddedSignatureSuites(opts.ldpSuites...),
+		WithJSONLDDocumentLoader(opts.jsonldCredentialOpts.jsonldDocumentLoader),
+		WithSDJWTHolderBindingCheck(),
+	}
+
+	if opts.disabledProofCheck {
+		credOpts = append(credOpts, WithDisabledProofCheck())
+	}
+
+	return credOpts
+}
+
 // decodeCredentials decodes credential(s) embedded into presentation.
 // It must be one of the following:
 // 1) string - it could be credential decoded into e.g. JWS.
@@ -524,17 +795,7 @@ func decodeCredentials(rawCred interface{}, opts *presentationOpts) ([]interface
 		if sCred, ok := cred.(string); ok {
 			bCred := []byte(sCred)
 
-			credOpts := []CredentialOpt{
-				WithPublicKeyFetcher(opts.publicKeyFetcher),
-				WithEmbeddedSignatureSuites(opts.ldpSuites...),
-				WithJSONLDDocumentLoader(opts.jsonldCredentialOpts.jsonldDocumentLoader),
-			}
-
-			if opts.disabledProofCheck {
-				credOpts = append(credOpts, WithDisabledProofCheck())
-			}
-
-			vc, err := ParseCredential(bCred, credOpts...)
+			vc, err := ParseCredential(bCred, credentialOptsOfPresentation(opts)...)
 
 			return vc, err
 		}
@@ -614,27 +875,26 @@ func validateVPJSONSchema(data []byte) error {
 //nolint:gocyclo
 func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *rawPresentation, string, error) {
 	vpStr := string(unQuote(vpData))
+	holderKeyFetcher := vpOpts.holderPublicKeyFetcher()
 
 	if jwt.IsJWS(vpStr) {
-		if !vpOpts.disabledProofCheck && vpOpts.publicKeyFetcher == nil {
+		if !vpOpts.disabledProofCheck && holderKeyFetcher == nil {
 			return nil, nil, "", errors.New("public key fetcher is not defined")
 		}
 
-		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, vpOpts.publicKeyFetcher)
+		vcDataFromJwt, rawCred, err := decodeVPFromJWS(vpStr, !vpOpts.disabledProofCheck, holderKeyFetcher)
 		if err != nil {
 			return nil, nil, "", fmt.Errorf("decoding of Verifiable Presentation from JWS: %w", err)
 		}
 
+		if err = checkPresentationJWTChallenge(vpStr, vpOpts); err != nil {
+			return nil, nil, "", err
+		}
+
 		return vcDataFromJwt, rawCred, vpStr, nil
 	}
 
-	embeddedProofCheckOpts := &embeddedProofCheckOpts{
-		dataIntegrityOpts:    vpOpts.verifyDataIntegrity,
-		publicKeyFetcher:     vpOpts.publicKeyFetcher,
-		disabledProofCheck:   vpOpts.disabledProofCheck,
-		ldpSuites:            vpOpts.ldpSuites,
-		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
-	}
+	embeddedProofCheckOpts := getPresEmbeddedProofCheckOpts(vpOpts)
 
 	if jwt.IsJWTUnsecured(vpStr) {
 		rawBytes, rawPres, err := decodeVPFromUnsecuredJWT(vpStr)
@@ -646,6 +906,10 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 			return nil, nil, "", err
 		}
 
+		if err := checkExpectedProofPresent(rawPres, vpOpts); err != nil {
+			return nil, nil, "", err
+		}
+
 		return rawBytes, rawPres, "", nil
 	}
 
@@ -664,9 +928,50 @@ func decodeRawPresentation(vpData []byte, vpOpts *presentationOpts) ([]byte, *ra
 		return nil, nil, "", errors.New("embedded proof is missing")
 	}
 
+	if err = checkExpectedProofPresent(vpRaw, vpOpts); err != nil {
+		return nil, nil, "", err
+	}
+
 	return vpData, vpRaw, "", err
 }
 
+// checkExpectedProofPresent rejects a presentation without embedded proof, or with an empty one, if the proof
+// challenge or domain is expected, as such presentation could be replayed.
+func checkExpectedProofPresent(vpRaw *rawPresentation, vpOpts *presentationOpts) error {
+	if vpOpts.expectedChallenge == "" && vpOpts.expectedDomain == "" {
+		return nil
+	}
+
+	var proofElement interface{}
+
+	if len(vpRaw.Proof) > 0 {
+		if err := json.Unmarshal(vpRaw.Proof, &proofElement); err != nil {
+			return fmt.Errorf("decode embedded proof: %w", err)
+		}
+	}
+
+	if proofElement == nil || isEmptyProof(proofElement) {
+		return errors.New("embedded proof with expected challenge or domain is missing")
+	}
+
+	return nil
+}
+
+func getPresEmbeddedProofCheckOpts(vpOpts *presentationOpts) *embeddedProofCheckOpts {
+	return &embeddedProofCheckOpts{
+		dataIntegrityOpts:    vpOpts.verifyDataIntegrity,
+		publicKeyFetcher:     vpOpts.holderPublicKeyFetcher(),
+		disabledProofCheck:   vpOpts.disabledProofCheck,
+		ldpSuites:            vpOpts.ldpSuites,
+		proofQuorum:          vpOpts.proofQuorum,
+		expectedChallenge:    vpOpts.expectedChallenge,
+		expectedDomain:       vpOpts.expectedDomain,
+		expectedProofPurpose: vpOpts.expectedProofPurpose,
+		proofPurposeResolver: vpOpts.proofPurposeResolver,
+		jsonldCredentialOpts: vpOpts.jsonldCredentialOpts,
+	}
+}
+
 func decodeVPFromJSON(vpData []byte) (*rawPresentation, error) {
 	// unmarshal VP from JSON
 	raw := new(rawPresentation)
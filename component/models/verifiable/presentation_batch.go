@@ -0,0 +1,58 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+// PresentationResult is the outcome of verification of a single presentation of VerifyPresentations.
+type PresentationResult struct {
+	// Presentation is the verified presentation, nil if verification failed.
+	Presentation *Presentation
+	// Credentials are the verified credentials enclosed into the presentation.
+	Credentials []*Credential
+	// Err is the verification error, nil if verification succeeded.
+	Err error
+}
+
+// VerifyPresentations verifies the presentations and the credentials enclosed into them (see VerifyPresentation).
+// Results are returned in the order of inputs.
+//
+// The JSON-LD document loader and the public key fetchers of the presentation options are shared by all
+// the presentations, and documents and keys they resolve are cached for the time of the call, so that a key of
+// an issuer or holder is resolved once.
+//
+// A presentation failing verification does not fail the call: its error is reported in PresentationResult.Err.
+// The returned error is reserved for failures to set up the shared verification state (the caching document loader),
+// in which case no presentation is verified and the results are nil.
+func VerifyPresentations(inputs [][]byte, opts ...PresentationOpt) ([]PresentationResult, error) {
+	vpOpts := getPresentationOpts(opts)
+
+	loader, err := newSharedDocumentLoader(vpOpts.jsonldDocumentLoader)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(append([]PresentationOpt{}, opts...), WithPresJSONLDDocumentLoader(loader))
+
+	if vpOpts.publicKeyFetcher != nil {
+		opts = append(opts, WithPresPublicKeyFetcher(newCachingKeyFetcher(vpOpts.publicKeyFetcher)))
+	}
+
+	if vpOpts.holderKeyFetcher != nil {
+		opts = append(opts, WithPresHolderKeyFetcher(newCachingKeyFetcher(vpOpts.holderKeyFetcher)))
+	}
+
+	if vpOpts.credKeyFetcher != nil {
+		opts = append(opts, WithPresCredentialKeyFetcher(newCachingKeyFetcher(vpOpts.credKeyFetcher)))
+	}
+
+	results := make([]PresentationResult, len(inputs))
+
+	for i, vpData := range inputs {
+		results[i].Presentation, results[i].Credentials, results[i].Err = VerifyPresentation(vpData, opts...)
+	}
+
+	return results, nil
+}
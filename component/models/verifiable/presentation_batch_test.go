@@ -0,0 +1,89 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestVerifyPresentations(t *testing.T) {
+	const holder = "did:example:holder"
+
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vcJWT := createEdDSAJWS(t, []byte(validCredential), issuerSigner, false)
+
+	vp, err := NewPresentation(WithJWTCredentials(string(vcJWT)))
+	require.NoError(t, err)
+
+	vp.Holder = holder
+
+	claims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	vpJWT, err := claims.MarshalJWS(EdDSA, holderSigner, holder+"#key1")
+	require.NoError(t, err)
+
+	var (
+		mu      sync.Mutex
+		fetches = map[string]int{}
+	)
+
+	fetcher := func(issuerID, keyID string) (*verifier.PublicKey, error) {
+		mu.Lock()
+		fetches[issuerID+keyID]++
+		mu.Unlock()
+
+		if issuerID == holder {
+			return &verifier.PublicKey{Type: kms.ED25519, Value: holderSigner.PublicKeyBytes()}, nil
+		}
+
+		return &verifier.PublicKey{Type: kms.ED25519, Value: issuerSigner.PublicKeyBytes()}, nil
+	}
+
+	inputs := make([][]byte, 50)
+	for i := range inputs {
+		inputs[i] = []byte(vpJWT)
+	}
+
+	inputs[7] = []byte("invalid")
+
+	results, err := VerifyPresentations(inputs, WithPresPublicKeyFetcher(fetcher),
+		WithPresJSONLDDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+	require.Len(t, results, len(inputs))
+
+	for i, r := range results {
+		if i == 7 {
+			require.Error(t, r.Err)
+			require.Nil(t, r.Presentation)
+
+			continue
+		}
+
+		require.NoError(t, r.Err)
+		require.Equal(t, holder, r.Presentation.Holder)
+		require.Len(t, r.Credentials, 1)
+	}
+
+	// one fetch per unique key: the holder key and the issuer key
+	require.Len(t, fetches, 2)
+
+	for key, n := range fetches {
+		require.Equal(t, 1, n, key)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/component/models/jwt"
+)
+
+// VerifyControlledBinding checks that the credentials enclosed into the presentation were issued to its holder,
+// who proves control of the holder DID:
+//   - the holder DID is resolved using resolver, and every key which signed the presentation (verificationMethod of
+//     the linked data proofs or "kid" of the JWT) must be an authentication method of the holder DID document;
+//   - each enclosed credential must have at least one subject whose id equals the holder.
+//
+// The signatures themselves are not verified, it's expected that the presentation was parsed with proof check.
+func (vp *Presentation) VerifyControlledBinding(resolver Resolver) error {
+	if resolver == nil {
+		return errors.New("verify controlled binding: DID resolver is not defined")
+	}
+
+	if vp.Holder == "" {
+		return errors.New("verify controlled binding: presentation holder is not defined")
+	}
+
+	keyIDs, err := vp.signingKeyIDs()
+	if err != nil {
+		return fmt.Errorf("verify controlled binding: %w", err)
+	}
+
+	if len(keyIDs) == 0 {
+		return errors.New("verify controlled binding: presentation is not signed")
+	}
+
+	docResolution, err := resolver.Resolve(vp.Holder)
+	if err != nil {
+		return fmt.Errorf("verify controlled binding: resolve holder DID %s: %w", vp.Holder, err)
+	}
+
+	if docResolution == nil || docResolution.DIDDocument == nil {
+		return fmt.Errorf("verify controlled binding: holder DID %s resolved to no DID document", vp.Holder)
+	}
+
+	authKeys := make(map[string]struct{})
+
+	for _, auth := range docResolution.DIDDocument.VerificationMethods(did.Authentication)[did.Authentication] {
+		authKeys[absoluteKeyID(vp.Holder, auth.VerificationMethod.ID)] = struct{}{}
+	}
+
+	for _, keyID := range keyIDs {
+		if _, ok := authKeys[absoluteKeyID(vp.Holder, keyID)]; !ok {
+			return fmt.Errorf("verify controlled binding: key %s is not an authentication method of holder %s",
+				keyID, vp.Holder)
+		}
+	}
+
+	for i, cred := range vp.credentials {
+		subjectIDs, err := credentialSubjectIDs(cred)
+		if err != nil {
+			return fmt.Errorf("verify controlled binding: credential %d: %w", i, err)
+		}
+
+		if !containsString(subjectIDs, vp.Holder) {
+			return fmt.Errorf("verify controlled binding: credential %d: no subject matches holder %s", i, vp.Holder)
+		}
+	}
+
+	return nil
+}
+
+// signingKeyIDs returns IDs of the keys which signed the presentation.
+func (vp *Presentation) signingKeyIDs() ([]string, error) {
+	if vp.JWT != "" {
+		token, _, err := jwt.Parse(vp.JWT,
+			jwt.WithSignatureVerifier(&noVerifier{}),
+			jwt.WithIgnoreClaimsMapDecoding(true),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("parse presentation JWT: %w", err)
+		}
+
+		if kid, ok := token.Headers.KeyID(); ok && kid != "" {
+			return []string{kid}, nil
+		}
+
+		return nil, errors.New("presentation JWT has no kid header")
+	}
+
+	var keyIDs []string
+
+	for _, proof := range vp.Proofs {
+		vm, ok := proof["verificationMethod"].(string)
+		if !ok || vm == "" {
+			return nil, errors.New("presentation proof has no verificationMethod")
+		}
+
+		keyIDs = append(keyIDs, vm)
+	}
+
+	return keyIDs, nil
+}
+
+// absoluteKeyID resolves key ID relative to the DID document, e.g. "#key-1", against the DID.
+func absoluteKeyID(didID, keyID string) string {
+	if strings.HasPrefix(keyID, "#") {
+		return didID + keyID
+	}
+
+	return keyID
+}
+
+// credentialSubjectIDs returns ids of the subjects of the credential enclosed into presentation.
+func credentialSubjectIDs(cred interface{}) ([]string, error) {
+	var subject interface{}
+
+	switch c := cred.(type) {
+	case *Credential:
+		subjectBytes, err := subjectToBytes(c.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(subjectBytes) == 0 {
+			return nil, nil
+		}
+
+		if err = json.Unmarshal(subjectBytes, &subject); err != nil {
+			return nil, err
+		}
+	case string:
+		vcJSON, err := JWTVCToJSON([]byte(c))
+		if err != nil {
+			return nil, err
+		}
+
+		var vcMap map[string]interface{}
+
+		if err = json.Unmarshal(vcJSON, &vcMap); err != nil {
+			return nil, err
+		}
+
+		subject = vcMap["credentialSubject"]
+	case map[string]interface{}:
+		subject = c["credentialSubject"]
+	default:
+		return nil, fmt.Errorf("unsupported credential type %T", cred)
+	}
+
+	return subjectIDsOf(subject), nil
+}
+
+func subjectIDsOf(subject interface{}) []string {
+	switch s := subject.(type) {
+	case string:
+		return []string{s}
+	case map[string]interface{}:
+		if id, ok := s["id"].(string); ok {
+			return []string{id}
+		}
+	case []interface{}:
+		var ids []string
+
+		for _, sub := range s {
+			ids = append(ids, subjectIDsOf(sub)...)
+		}
+
+		return ids
+	}
+
+	return nil
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,118 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+	"github.com/hyperledger/aries-framework-go/spi/vdr"
+)
+
+type mockFailingResolver struct{}
+
+func (m *mockFailingResolver) Resolve(string, ...vdr.DIDMethodOption) (*did.DocResolution, error) {
+	return nil, errors.New("resolve error")
+}
+
+func TestPresentation_VerifyControlledBinding(t *testing.T) {
+	const holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+	authKey := did.NewVerificationMethodFromBytes(holder+"#key-1", "Ed25519VerificationKey2018", holder,
+		[]byte("auth key"))
+	assertionKey := did.NewVerificationMethodFromBytes(holder+"#key-2", "Ed25519VerificationKey2018", holder,
+		[]byte("assertion key"))
+
+	resolver := &mockResolver{didDoc: &did.Doc{
+		Context:            []string{did.ContextV1},
+		ID:                 holder,
+		VerificationMethod: []did.VerificationMethod{*authKey, *assertionKey},
+		Authentication:     []did.Verification{*did.NewReferencedVerification(authKey, did.Authentication)},
+		AssertionMethod:    []did.Verification{*did.NewReferencedVerification(assertionKey, did.AssertionMethod)},
+	}}
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	newVP := func(verificationMethod string, creds ...*Credential) *Presentation {
+		vp, e := NewPresentation(WithCredentials(creds...))
+		require.NoError(t, e)
+
+		vp.Holder = holder
+		vp.Proofs = []Proof{{"type": "Ed25519Signature2018", "verificationMethod": verificationMethod}}
+
+		return vp
+	}
+
+	t.Run("correctly controlled binding", func(t *testing.T) {
+		require.NoError(t, newVP(holder+"#key-1", vc).VerifyControlledBinding(resolver))
+		require.NoError(t, newVP("#key-1", vc).VerifyControlledBinding(resolver))
+	})
+
+	t.Run("correctly controlled binding of JWT presentation", func(t *testing.T) {
+		signer, e := newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, e)
+
+		vcJWT := createEdDSAJWS(t, []byte(validCredential), signer, false)
+
+		vp, e := NewPresentation(WithJWTCredentials(string(vcJWT)))
+		require.NoError(t, e)
+
+		vp.Holder = holder
+
+		claims, e := vp.JWTClaims(nil, false)
+		require.NoError(t, e)
+
+		vp.JWT, e = claims.MarshalJWS(EdDSA, signer, holder+"#key-1")
+		require.NoError(t, e)
+
+		require.NoError(t, vp.VerifyControlledBinding(resolver))
+
+		vp.JWT, e = claims.MarshalJWS(EdDSA, signer, holder+"#key-2")
+		require.NoError(t, e)
+
+		require.EqualError(t, vp.VerifyControlledBinding(resolver), "verify controlled binding: key "+
+			holder+"#key-2 is not an authentication method of holder "+holder)
+	})
+
+	t.Run("presentation signed with non-authentication key", func(t *testing.T) {
+		err = newVP(holder+"#key-2", vc).VerifyControlledBinding(resolver)
+		require.EqualError(t, err, "verify controlled binding: key "+holder+
+			"#key-2 is not an authentication method of holder "+holder)
+	})
+
+	t.Run("credential is not issued to holder", func(t *testing.T) {
+		otherVC := *vc
+		otherVC.Subject = "did:example:other"
+
+		err = newVP(holder+"#key-1", vc, &otherVC).VerifyControlledBinding(resolver)
+		require.EqualError(t, err, "verify controlled binding: credential 1: no subject matches holder "+holder)
+	})
+
+	t.Run("invalid presentation", func(t *testing.T) {
+		vp := newVP(holder+"#key-1", vc)
+
+		require.EqualError(t, vp.VerifyControlledBinding(nil), "verify controlled binding: DID resolver is not defined")
+
+		require.EqualError(t, vp.VerifyControlledBinding(&mockFailingResolver{}),
+			"verify controlled binding: resolve holder DID "+holder+": resolve error")
+
+		require.EqualError(t, vp.VerifyControlledBinding(&mockResolver{}),
+			"verify controlled binding: holder DID "+holder+" resolved to no DID document")
+
+		vp.Proofs = nil
+		require.EqualError(t, vp.VerifyControlledBinding(resolver), "verify controlled binding: presentation is not signed")
+
+		vp.Holder = ""
+		require.EqualError(t, vp.VerifyControlledBinding(resolver),
+			"verify controlled binding: presentation holder is not defined")
+	})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+)
+
+// ValidateContexts checks that the presentation declares the contexts its credentials rely on, otherwise the terms
+// of the credentials could be dropped when the presentation is processed as JSON-LD. A credential which carries its
+// own @context is self-sufficient. A credential without @context (or with an empty one) relies on the presentation,
+// so @context of the presentation has to declare the base credentials context.
+// JWT credentials carry their own contexts inside the signed payload, so they are not checked.
+func (vp *Presentation) ValidateContexts() error {
+	declaresBase := false
+
+	for _, ctx := range vp.Context {
+		if ctx == baseContext {
+			declaresBase = true
+		}
+	}
+
+	for i, cred := range vp.credentials {
+		ownContext, err := hasOwnContext(cred)
+		if err != nil {
+			return fmt.Errorf("validate contexts: credential %d: %w", i, err)
+		}
+
+		if !ownContext && !declaresBase {
+			return fmt.Errorf("validate contexts: context %s of credential %d is not declared by presentation",
+				baseContext, i)
+		}
+	}
+
+	return nil
+}
+
+// hasOwnContext checks if the credential enclosed into presentation carries its own contexts.
+func hasOwnContext(cred interface{}) (bool, error) {
+	switch c := cred.(type) {
+	case *Credential:
+		return c.JWT != "" || len(c.Context) > 0 || len(c.CustomContext) > 0, nil
+	case string:
+		return true, nil
+	case map[string]interface{}:
+		rawContext, ok := c["@context"]
+		if !ok {
+			return false, nil
+		}
+
+		contexts, customContexts, err := decodeContext(rawContext)
+		if err != nil {
+			return false, err
+		}
+
+		return len(contexts) > 0 || len(customContexts) > 0, nil
+	default:
+		return false, fmt.Errorf("unsupported credential type %T", cred)
+	}
+}
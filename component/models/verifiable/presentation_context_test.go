@@ -0,0 +1,78 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresentation_ValidateContexts(t *testing.T) {
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	t.Run("credential carries its own contexts", func(t *testing.T) {
+		vp, err := NewPresentation(WithCredentials(vc))
+		require.NoError(t, err)
+
+		// the examples context of the credential is not declared by the presentation
+		require.Equal(t, []string{baseContext}, vp.Context)
+		require.NoError(t, vp.ValidateContexts())
+
+		vp.Context = nil
+		require.NoError(t, vp.ValidateContexts())
+
+		vp.credentials = []interface{}{map[string]interface{}{"@context": []interface{}{baseContext}}}
+		require.NoError(t, vp.ValidateContexts())
+	})
+
+	t.Run("credential relies on presentation context", func(t *testing.T) {
+		contextless := []interface{}{
+			map[string]interface{}{"id": "http://example.edu/credentials/1872"},
+			map[string]interface{}{"id": "http://example.edu/credentials/1872", "@context": []interface{}{}},
+			&Credential{ID: "http://example.edu/credentials/1872"},
+		}
+
+		for _, cred := range contextless {
+			vp, err := NewPresentation()
+			require.NoError(t, err)
+
+			vp.credentials = append(vp.credentials, cred)
+			require.NoError(t, vp.ValidateContexts())
+
+			vp.Context = nil
+			require.EqualError(t, vp.ValidateContexts(),
+				"validate contexts: context https://www.w3.org/2018/credentials/v1 of credential 0 "+
+					"is not declared by presentation")
+		}
+	})
+
+	t.Run("invalid credential context", func(t *testing.T) {
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+
+		vp.credentials = append(vp.credentials, map[string]interface{}{"@context": 42})
+		require.EqualError(t, vp.ValidateContexts(),
+			"validate contexts: credential 0: credential context of unknown type")
+	})
+
+	t.Run("JWT credentials are not checked", func(t *testing.T) {
+		vp, err := NewPresentation(WithJWTCredentials("header.payload.signature"))
+		require.NoError(t, err)
+
+		require.NoError(t, vp.ValidateContexts())
+	})
+
+	t.Run("unsupported credential", func(t *testing.T) {
+		vp, err := NewPresentation()
+		require.NoError(t, err)
+
+		vp.credentials = append(vp.credentials, 42)
+		require.EqualError(t, vp.ValidateContexts(), "validate contexts: credential 0: unsupported credential type int")
+	})
+}
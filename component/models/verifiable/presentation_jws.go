@@ -6,8 +6,16 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 // MarshalJWS serializes JWT presentation claims into signed form (JWS).
-func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string) (string, error) {
-	return marshalJWS(jpc, signatureAlg, signer, keyID)
+func (jpc *JWTPresClaims) MarshalJWS(signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...MarshalJWSOpt) (string, error) {
+	return marshalJWS(jpc, signatureAlg, signer, keyID, opts...)
+}
+
+// SigningInput returns JWS signing input of JWT presentation claims, i.e. base64url(header) + "." +
+// base64url(payload), together with base64url encoded JWS header. It allows to sign the JWT externally;
+// the token is then assembled as signing input + "." + base64url(signature).
+func (jpc *JWTPresClaims) SigningInput(signatureAlg JWSAlgorithm, keyID string) ([]byte, string, error) {
+	return jwsSigningInput(jpc, signatureAlg, keyID)
 }
 
 func unmarshalPresJWSClaims(vpJWT string, checkProof bool, fetcher PublicKeyFetcher) (*JWTPresClaims, error) {
@@ -33,6 +33,73 @@ func TestJWTPresClaims_MarshalJWS(t *testing.T) {
 	require.Equal(t, vp.stringJSON(t), rawVC.stringJSON(t))
 }
 
+func TestJWTPresClaims_MarshalJWS_Algorithms(t *testing.T) {
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	claims, err := newJWTPresClaims(vp, []string{}, false)
+	require.NoError(t, err)
+
+	tests := []struct {
+		alg        JWSAlgorithm
+		keyType    kms.KeyType
+		pubKeyType string
+		algHeader  string
+	}{
+		{alg: ES256, keyType: kms.ECDSAP256TypeIEEEP1363, pubKeyType: kms.ECDSAP256IEEEP1363, algHeader: "ES256"},
+		{alg: ES384, keyType: kms.ECDSAP384TypeIEEEP1363, pubKeyType: kms.ECDSAP384IEEEP1363, algHeader: "ES384"},
+		{alg: ES512, keyType: kms.ECDSAP521TypeIEEEP1363, pubKeyType: kms.ECDSAP521IEEEP1363, algHeader: "ES512"},
+		{alg: RS256, keyType: kms.RSARS256Type, pubKeyType: kms.RSARS256, algHeader: "RS256"},
+		{alg: PS256, keyType: kms.RSAPS256Type, pubKeyType: kms.RSAPS256, algHeader: "PS256"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.algHeader, func(t *testing.T) {
+			alg, err := ParseJWSAlgorithm(tc.algHeader)
+			require.NoError(t, err)
+			require.Equal(t, tc.alg, alg)
+
+			signer, err := newCryptoSigner(tc.keyType)
+			require.NoError(t, err)
+
+			jws, err := claims.MarshalJWS(tc.alg, signer, "did:123#key1")
+			require.NoError(t, err)
+
+			token, err := jwt.ParseSigned(jws)
+			require.NoError(t, err)
+			require.Equal(t, tc.algHeader, token.Headers[0].Algorithm)
+
+			_, rawVP, err := decodeVPFromJWS(jws, true, SingleKey(signer.PublicKeyBytes(), tc.pubKeyType))
+			require.NoError(t, err)
+			require.Equal(t, vp.stringJSON(t), rawVP.stringJSON(t))
+		})
+	}
+
+	t.Run("legacy ES521 name", func(t *testing.T) {
+		alg, err := ParseJWSAlgorithm("ES521")
+		require.NoError(t, err)
+		require.Equal(t, ES512, alg)
+
+		_, err = ParseJWSAlgorithm("HS256")
+		require.EqualError(t, err, "unsupported algorithm: HS256")
+	})
+
+	t.Run("key does not match algorithm", func(t *testing.T) {
+		signer, err := newCryptoSigner(kms.ECDSAP384TypeIEEEP1363)
+		require.NoError(t, err)
+
+		jws, err := claims.MarshalJWS(ES256, signer, "did:123#key1")
+		require.EqualError(t, err, "signer key of ES384 algorithm does not match ES256 signature algorithm")
+		require.Empty(t, jws)
+
+		signer, err = newCryptoSigner(kms.ED25519Type)
+		require.NoError(t, err)
+
+		_, err = claims.MarshalJWS(RS256, signer, "did:123#key1")
+		require.EqualError(t, err, "signer key of EdDSA algorithm does not match RS256 signature algorithm")
+	})
+}
+
 type invalidPresClaims struct {
 	*jwt.Claims
 
@@ -7,11 +7,17 @@ package verifiable
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/hyperledger/aries-framework-go/component/models/jwt"
 )
 
+const (
+	presentationSubmissionField = "presentation_submission"
+	definitionIDField           = "definition_id"
+)
+
 // JWTPresClaims is JWT Claims extension by Verifiable Presentation (with custom "vp" claim).
 type JWTPresClaims struct {
 	*jwt.Claims
@@ -70,6 +76,126 @@ func newJWTPresClaims(vp *Presentation, audience []string, minimizeVP bool) (*JW
 	return presClaims, nil
 }
 
+// WithPresentationDefinitionID sets the id of the presentation definition the VP responds to as definition_id of
+// the presentation_submission of "vp" claim, creating the presentation_submission if the VP has none.
+func (jpc *JWTPresClaims) WithPresentationDefinitionID(id string) *JWTPresClaims {
+	if jpc.Presentation.CustomFields == nil {
+		jpc.Presentation.CustomFields = make(CustomFields)
+	}
+
+	submission, ok := jpc.Presentation.CustomFields[presentationSubmissionField].(map[string]interface{})
+	if !ok {
+		submission = make(map[string]interface{})
+	} else {
+		// copy not to change the presentation the claims were created from.
+		submissionCopy := make(map[string]interface{}, len(submission)+1)
+		for k, v := range submission {
+			submissionCopy[k] = v
+		}
+
+		submission = submissionCopy
+	}
+
+	submission[definitionIDField] = id
+
+	customFields := make(CustomFields, len(jpc.Presentation.CustomFields))
+	for k, v := range jpc.Presentation.CustomFields {
+		customFields[k] = v
+	}
+
+	customFields[presentationSubmissionField] = submission
+	jpc.Presentation.CustomFields = customFields
+
+	return jpc
+}
+
+// checkPresentationDefinitionID checks that presentation_submission of VP responds to the presentation definition
+// with the given id.
+func checkPresentationDefinitionID(vp *Presentation, id string) error {
+	submission, ok := vp.CustomFields[presentationSubmissionField].(map[string]interface{})
+	if !ok {
+		return errors.New("presentation definition id: presentation_submission is missing")
+	}
+
+	definitionID, _ := submission[definitionIDField].(string) //nolint:errcheck
+	if definitionID != id {
+		return fmt.Errorf("presentation definition id: expected %q, got %q", id, definitionID)
+	}
+
+	return nil
+}
+
+// ErrAudienceMismatch is returned when the "aud" claim of Verifiable Presentation JWT does not contain
+// the expected audience (see WithPresExpectedAudience).
+var ErrAudienceMismatch = errors.New("presentation audience mismatch")
+
+// checkPresentationAudience checks that "aud" claim of VP in JWT form contains the expected audience.
+// The JWT is expected to be verified already.
+func checkPresentationAudience(vpData []byte, audience string) error {
+	vpStr := string(unQuote(vpData))
+
+	var (
+		claims *JWTPresClaims
+		err    error
+	)
+
+	switch {
+	case jwt.IsJWS(vpStr):
+		claims, err = unmarshalPresJWSClaims(vpStr, false, nil)
+	case jwt.IsJWTUnsecured(vpStr):
+		claims, err = unmarshalUnsecuredJWTPresClaims(vpStr)
+	default:
+		return fmt.Errorf("%w: presentation is not a JWT", ErrAudienceMismatch)
+	}
+
+	if err != nil {
+		return fmt.Errorf("check presentation audience: %w", err)
+	}
+
+	if claims.Claims == nil || !claims.Audience.Contains(audience) {
+		return fmt.Errorf("%w: %q is not in the audience of the presentation", ErrAudienceMismatch, audience)
+	}
+
+	return nil
+}
+
+// checkPresentationJWTChallenge checks that "nonce" and "aud" claims of VP in JWS form carry the expected challenge
+// and domain, as they are not carried by an embedded proof. The JWT is expected to be verified already.
+func checkPresentationJWTChallenge(vpJWT string, vpOpts *presentationOpts) error {
+	if vpOpts.expectedChallenge == "" && vpOpts.expectedDomain == "" {
+		return nil
+	}
+
+	_, claimsRaw, err := jwt.Parse(vpJWT,
+		jwt.WithSignatureVerifier(&noVerifier{}),
+		jwt.WithIgnoreClaimsMapDecoding(true),
+	)
+	if err != nil {
+		return fmt.Errorf("check presentation JWT challenge: %w", err)
+	}
+
+	claims := struct {
+		*jwt.Claims
+		Nonce interface{} `json:"nonce,omitempty"`
+	}{Claims: &jwt.Claims{}}
+
+	if err = json.Unmarshal(claimsRaw, &claims); err != nil {
+		return fmt.Errorf("check presentation JWT challenge: %w", err)
+	}
+
+	if vpOpts.expectedChallenge != "" {
+		if nonce, ok := claims.Nonce.(string); !ok || nonce != vpOpts.expectedChallenge {
+			return fmt.Errorf("presentation JWT nonce %v does not match expected challenge", claims.Nonce)
+		}
+	}
+
+	if vpOpts.expectedDomain != "" && !claims.Audience.Contains(vpOpts.expectedDomain) {
+		return fmt.Errorf("presentation JWT audience %v does not contain expected domain", claims.Audience)
+	}
+
+	return nil
+}
+
 // JWTPresClaimsUnmarshaller parses JWT of certain type to JWT Claims containing "vp" (Presentation) claim.
 type JWTPresClaimsUnmarshaller func(vpJWT string) (*JWTPresClaims, error)
 
@@ -0,0 +1,140 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/aries-framework-go/component/models/jwt"
+)
+
+// PresentationJWTClaimsField is the member of the JSON form of a presentation which holds the JWT claims
+// other than "vp" (e.g. "aud", "nonce", "exp"), so that they survive the conversion between JWT and JSON forms.
+const PresentationJWTClaimsField = "jwtClaims"
+
+const (
+	jwtIssuerClaim = "iss"
+	jwtIDClaim     = "jti"
+	jwtVPClaim     = "vp"
+)
+
+// PresentationJWTToJSON converts presentation JWT (JWS or unsecured JWT) into JSON form. The signature is NOT
+// verified, use ParsePresentation for this.
+//
+// The JSON form is the "vp" claim, with holder and id taken from "iss" and "jti" claims if not defined. All the other
+// JWT claims are kept as is in PresentationJWTClaimsField member, numbers are kept with their original precision.
+func PresentationJWTToJSON(vpJWT string) ([]byte, error) {
+	_, claimsRaw, err := jwt.Parse(vpJWT,
+		jwt.WithSignatureVerifier(&noVerifier{}),
+		jwt.WithIgnoreClaimsMapDecoding(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parse presentation JWT: %w", err)
+	}
+
+	claims, err := decodeJSONObject(claimsRaw)
+	if err != nil {
+		return nil, fmt.Errorf("decode presentation JWT claims: %w", err)
+	}
+
+	vp, ok := claims[jwtVPClaim].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("presentation JWT has no \"vp\" claim")
+	}
+
+	delete(claims, jwtVPClaim)
+
+	copyIfMissing(vp, "holder", claims[jwtIssuerClaim])
+	copyIfMissing(vp, "id", claims[jwtIDClaim])
+
+	if len(claims) > 0 {
+		vp[PresentationJWTClaimsField] = claims
+	}
+
+	return json.Marshal(vp)
+}
+
+// JSONToPresentationJWT converts JSON form of presentation into JWT, which is the reverse of PresentationJWTToJSON.
+// The JWT is signed as JWS with signer, or unsecured if signer is nil.
+//
+// The members of PresentationJWTClaimsField are put as JWT claims, the other members are put into "vp" claim.
+// Holder and id are carried by "iss" and "jti" claims, they are kept in "vp" claim only if they differ.
+func JSONToPresentationJWT(vpJSON []byte, signatureAlg JWSAlgorithm, signer Signer, keyID string,
+	opts ...MarshalJWSOpt) (string, error) {
+	vp, err := decodeJSONObject(vpJSON)
+	if err != nil {
+		return "", fmt.Errorf("decode presentation JSON: %w", err)
+	}
+
+	claims := map[string]interface{}{}
+
+	if rawClaims, ok := vp[PresentationJWTClaimsField]; ok {
+		if claims, ok = rawClaims.(map[string]interface{}); !ok {
+			return "", fmt.Errorf("%s of presentation is not an object", PresentationJWTClaimsField)
+		}
+
+		delete(vp, PresentationJWTClaimsField)
+	}
+
+	moveToClaim(vp, "holder", claims, jwtIssuerClaim)
+	moveToClaim(vp, "id", claims, jwtIDClaim)
+
+	claims[jwtVPClaim] = vp
+
+	// pass the claims as JSON, so that json.Number values are kept as numbers
+	claimsBytes, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("marshal presentation JWT claims: %w", err)
+	}
+
+	if signer == nil {
+		return marshalUnsecuredJWT(nil, claimsBytes)
+	}
+
+	return marshalJWS(claimsBytes, signatureAlg, signer, keyID, opts...)
+}
+
+func decodeJSONObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var obj map[string]interface{}
+
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+
+	if obj == nil {
+		return nil, errors.New("not a JSON object")
+	}
+
+	return obj, nil
+}
+
+func copyIfMissing(obj map[string]interface{}, member string, value interface{}) {
+	if _, ok := obj[member]; !ok && value != nil {
+		obj[member] = value
+	}
+}
+
+// moveToClaim sets the claim to the string member of obj if the claim is not defined yet, and removes the member
+// from obj if it duplicates the claim.
+func moveToClaim(obj map[string]interface{}, member string, claims map[string]interface{}, claim string) {
+	value, ok := obj[member].(string)
+	if !ok {
+		return
+	}
+
+	copyIfMissing(claims, claim, value)
+
+	if claimValue, _ := claims[claim].(string); claimValue == value { //nolint:errcheck
+		delete(obj, member)
+	}
+}
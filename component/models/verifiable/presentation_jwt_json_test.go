@@ -0,0 +1,124 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestPresentationJWTToJSON(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	jwtPayload := func(t *testing.T, vpJWT string) string {
+		t.Helper()
+
+		parts := strings.Split(vpJWT, ".")
+		require.Len(t, parts, 3)
+
+		payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+
+		return string(payload)
+	}
+
+	t.Run("JWT to JSON and back", func(t *testing.T) {
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		claims, err := vp.JWTClaims([]string{"did:example:verifier"}, true)
+		require.NoError(t, err)
+
+		claims.Expiry = jwt.NewNumericDate(time.Unix(1893456000, 0))
+
+		vpJWT, err := claims.MarshalJWS(EdDSA, signer, "did:example:holder#key1")
+		require.NoError(t, err)
+
+		vpJSON, err := PresentationJWTToJSON(vpJWT)
+		require.NoError(t, err)
+
+		// the JSON form is a presentation with holder and id
+		vpFromJSON, err := newTestPresentation(t, vpJSON)
+		require.NoError(t, err)
+		require.Equal(t, vp.Holder, vpFromJSON.Holder)
+		require.Equal(t, vp.ID, vpFromJSON.ID)
+		require.Equal(t, map[string]interface{}{
+			"aud": "did:example:verifier",
+			"exp": float64(1893456000),
+			"iss": vp.Holder,
+			"jti": vp.ID,
+		}, vpFromJSON.CustomFields[PresentationJWTClaimsField])
+
+		convertedJWT, err := JSONToPresentationJWT(vpJSON, EdDSA, signer, "did:example:holder#key1")
+		require.NoError(t, err)
+		require.JSONEq(t, jwtPayload(t, vpJWT), jwtPayload(t, convertedJWT))
+
+		_, err = newTestPresentation(t, []byte(convertedJWT),
+			WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.NoError(t, err)
+	})
+
+	t.Run("JSON to JWT and back", func(t *testing.T) {
+		vpJSON := `{
+  "@context": ["https://www.w3.org/2018/credentials/v1"],
+  "id": "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5",
+  "type": ["VerifiablePresentation"],
+  "holder": "did:example:holder",
+  "verifiableCredential": [{"id": "http://example.edu/credentials/1872", "custom": {"n": 12345678901234567890}}],
+  "jwtClaims": {
+    "iss": "did:example:holder",
+    "aud": ["did:example:verifier1", "did:example:verifier2"],
+    "nonce": "n-0S6_WzA2Mj",
+    "exp": 1893456000,
+    "nbf": 1262304000.5
+  }
+}`
+
+		for _, s := range []Signer{nil, signer} {
+			vpJWT, err := JSONToPresentationJWT([]byte(vpJSON), EdDSA, s, "did:example:holder#key1")
+			require.NoError(t, err)
+
+			payload := jwtPayload(t, vpJWT)
+			require.Contains(t, payload, `"iss":"did:example:holder"`)
+			require.Contains(t, payload, `"jti":"urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5"`)
+			require.Contains(t, payload, `"nonce":"n-0S6_WzA2Mj"`)
+			require.NotContains(t, payload, `"holder"`)
+
+			convertedJSON, err := PresentationJWTToJSON(vpJWT)
+			require.NoError(t, err)
+			require.JSONEq(t, strings.Replace(vpJSON, `"iss": "did:example:holder",`,
+				`"iss": "did:example:holder", "jti": "urn:uuid:3978344f-8596-4c3a-a978-8fcaba3903c5",`, 1),
+				string(convertedJSON))
+			require.Contains(t, string(convertedJSON), "12345678901234567890")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := PresentationJWTToJSON("not a JWT")
+		require.ErrorContains(t, err, "parse presentation JWT")
+
+		unsecuredJWT, err := marshalUnsecuredJWT(nil, map[string]interface{}{"iss": "did:example:holder"})
+		require.NoError(t, err)
+
+		_, err = PresentationJWTToJSON(unsecuredJWT)
+		require.EqualError(t, err, `presentation JWT has no "vp" claim`)
+
+		_, err = JSONToPresentationJWT([]byte("[]"), EdDSA, nil, "")
+		require.ErrorContains(t, err, "decode presentation JSON")
+
+		_, err = JSONToPresentationJWT([]byte(`{"jwtClaims": "aud"}`), EdDSA, nil, "")
+		require.EqualError(t, err, "jwtClaims of presentation is not an object")
+	})
+}
@@ -10,6 +10,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/spi/kms"
 )
 
 func TestNewJWTPresClaims(t *testing.T) {
@@ -56,3 +58,123 @@ func TestNewJWTPresClaims(t *testing.T) {
 		require.Equal(t, vp.Holder, claims.Presentation.Holder)
 	})
 }
+
+func TestJWTPresClaims_WithPresentationDefinitionID(t *testing.T) {
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kms.RSARS256Type)
+	require.NoError(t, err)
+
+	claims, err := vp.JWTClaims([]string{}, false)
+	require.NoError(t, err)
+
+	jws, err := claims.WithPresentationDefinitionID("32f54163-7166-48f1-93d8-ff217bdb0653").
+		MarshalJWS(RS256, signer, "did:123#key1")
+	require.NoError(t, err)
+
+	// the presentation the claims are created from is not changed
+	require.NotContains(t, vp.CustomFields, "presentation_submission")
+
+	t.Run("definition id round-trips", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(jws),
+			WithPresPublicKeyFetcher(holderPublicKeyFetcher(signer.PublicKeyBytes())),
+			WithPresExpectedDefinitionID("32f54163-7166-48f1-93d8-ff217bdb0653"))
+		require.NoError(t, err)
+		require.Equal(t, map[string]interface{}{"definition_id": "32f54163-7166-48f1-93d8-ff217bdb0653"},
+			vpParsed.CustomFields["presentation_submission"])
+	})
+
+	t.Run("definition id mismatch", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(jws),
+			WithPresPublicKeyFetcher(holderPublicKeyFetcher(signer.PublicKeyBytes())),
+			WithPresExpectedDefinitionID("other-definition"))
+		require.EqualError(t, err, `presentation definition id: expected "other-definition", `+
+			`got "32f54163-7166-48f1-93d8-ff217bdb0653"`)
+		require.Nil(t, vpParsed)
+	})
+
+	t.Run("presentation submission is missing", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(validPresentation),
+			WithPresExpectedDefinitionID("32f54163-7166-48f1-93d8-ff217bdb0653"))
+		require.EqualError(t, err, "presentation definition id: presentation_submission is missing")
+		require.Nil(t, vpParsed)
+	})
+
+	t.Run("existing presentation submission is kept", func(t *testing.T) {
+		vpWithSubmission, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		vpWithSubmission.CustomFields = CustomFields{
+			"presentation_submission": map[string]interface{}{"id": "a30e3b91-fb77-4d22-95fa-871689c322e2"},
+		}
+
+		claims, err := vpWithSubmission.JWTClaims([]string{}, false)
+		require.NoError(t, err)
+
+		claims.WithPresentationDefinitionID("32f54163-7166-48f1-93d8-ff217bdb0653")
+
+		require.Equal(t, map[string]interface{}{
+			"id":            "a30e3b91-fb77-4d22-95fa-871689c322e2",
+			"definition_id": "32f54163-7166-48f1-93d8-ff217bdb0653",
+		}, claims.Presentation.CustomFields["presentation_submission"])
+		require.Equal(t, map[string]interface{}{"id": "a30e3b91-fb77-4d22-95fa-871689c322e2"},
+			vpWithSubmission.CustomFields["presentation_submission"])
+	})
+}
+
+func TestWithPresExpectedAudience(t *testing.T) {
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	keyFetcher := WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519))
+
+	newVPJWS := func(t *testing.T, audience ...string) []byte {
+		t.Helper()
+
+		claims, err := vp.JWTClaims(audience, true)
+		require.NoError(t, err)
+
+		jws, err := claims.MarshalJWS(EdDSA, signer, "did:example:holder#key1")
+		require.NoError(t, err)
+
+		return []byte(jws)
+	}
+
+	t.Run("audience matches", func(t *testing.T) {
+		_, err := newTestPresentation(t, newVPJWS(t, "did:example:verifier"), keyFetcher,
+			WithPresExpectedAudience("did:example:verifier"))
+		require.NoError(t, err)
+
+		// any entry of an array audience matches
+		_, err = newTestPresentation(t, newVPJWS(t, "did:example:other", "did:example:verifier"), keyFetcher,
+			WithPresExpectedAudience("did:example:verifier"))
+		require.NoError(t, err)
+
+		claims, err := vp.JWTClaims([]string{"did:example:verifier"}, true)
+		require.NoError(t, err)
+
+		unsecuredJWT, err := claims.MarshalUnsecuredJWT()
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, []byte(unsecuredJWT), WithPresExpectedAudience("did:example:verifier"))
+		require.NoError(t, err)
+	})
+
+	t.Run("audience does not match", func(t *testing.T) {
+		vp, err := newTestPresentation(t, newVPJWS(t, "did:example:other"), keyFetcher,
+			WithPresExpectedAudience("did:example:verifier"))
+		require.ErrorIs(t, err, ErrAudienceMismatch)
+		require.Nil(t, vp)
+
+		_, err = newTestPresentation(t, newVPJWS(t), keyFetcher, WithPresExpectedAudience("did:example:verifier"))
+		require.ErrorIs(t, err, ErrAudienceMismatch)
+
+		_, err = newTestPresentation(t, []byte(validPresentation), WithPresExpectedAudience("did:example:verifier"))
+		require.ErrorIs(t, err, ErrAudienceMismatch)
+		require.EqualError(t, err, "presentation audience mismatch: presentation is not a JWT")
+	})
+}
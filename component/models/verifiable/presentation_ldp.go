@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package verifiable
 
 import (
+	"errors"
 	"fmt"
 
 	ldprocessor "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
@@ -27,3 +28,32 @@ func (vp *Presentation) AddLinkedDataProof(context *LinkedDataProofContext, json
 
 	return nil
 }
+
+// VerifyProofCoverage checks that the linked data proofs of the Verifiable Presentation cover its current content,
+// in particular the exact set of credentials enclosed into it, e.g. to detect a credential added to the presentation
+// after it was signed. The proofs are verified against the presentation as it is now, using the public key fetcher,
+// embedded signature suites and JSON-LD document loader of the options.
+// A presentation in JWT form is covered by the JWT signature, so no check is made for it.
+func (vp *Presentation) VerifyProofCoverage(opts ...PresentationOpt) error {
+	if vp.JWT != "" {
+		return nil
+	}
+
+	if len(vp.Proofs) == 0 {
+		return errors.New("verify proof coverage: presentation has no embedded proof")
+	}
+
+	vpBytes, err := vp.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("verify proof coverage: %w", err)
+	}
+
+	vpOpts := getPresentationOpts(opts)
+	vpOpts.disabledProofCheck = false
+
+	if err = checkEmbeddedProof(vpBytes, getPresEmbeddedProofCheckOpts(vpOpts)); err != nil {
+		return fmt.Errorf("verify proof coverage: signed document does not match presentation: %w", err)
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	ldprocessor "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
 	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 )
@@ -57,6 +58,143 @@ func TestParsePresentationFromLinkedDataProof(t *testing.T) {
 	require.Nil(t, vcWithLdp)
 }
 
+func TestParsePresentation_ExpectedChallengeAndDomain(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	createVP := func(t *testing.T, challenge, domain string) []byte {
+		t.Helper()
+
+		vp, err := newTestPresentation(t, []byte(validPresentation))
+		require.NoError(t, err)
+
+		if challenge != "" || domain != "" {
+			require.NoError(t, vp.AddLinkedDataProof(&LinkedDataProofContext{
+				SignatureType:           "Ed25519Signature2018",
+				SignatureRepresentation: SignatureJWS,
+				Suite:                   ss,
+				VerificationMethod:      "did:example:123456#key1",
+				Challenge:               challenge,
+				Domain:                  domain,
+			}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t))))
+		}
+
+		vpBytes, err := json.Marshal(vp)
+		require.NoError(t, err)
+
+		return vpBytes
+	}
+
+	opts := []PresentationOpt{
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithPresExpectedChallenge("challenge-1"),
+		WithPresExpectedDomain("verifier.example.com"),
+	}
+
+	t.Run("challenge and domain match", func(t *testing.T) {
+		vp, err := newTestPresentation(t, createVP(t, "challenge-1", "verifier.example.com"), opts...)
+		require.NoError(t, err)
+		require.Equal(t, "challenge-1", vp.Proofs[0]["challenge"])
+		require.Equal(t, "verifier.example.com", vp.Proofs[0]["domain"])
+	})
+
+	t.Run("challenge does not match", func(t *testing.T) {
+		vp, err := newTestPresentation(t, createVP(t, "challenge-2", "verifier.example.com"), opts...)
+		require.EqualError(t, err, "check embedded proof: proof challenge challenge-2 does not match expected one")
+		require.Nil(t, vp)
+	})
+
+	t.Run("domain does not match", func(t *testing.T) {
+		_, err := newTestPresentation(t, createVP(t, "challenge-1", "other.example.com"), opts...)
+		require.EqualError(t, err, "check embedded proof: proof domain other.example.com does not match expected one")
+
+		_, err = newTestPresentation(t, createVP(t, "challenge-1", ""), opts...)
+		require.EqualError(t, err, "check embedded proof: proof domain is missing")
+	})
+
+	t.Run("challenge or domain is not a string", func(t *testing.T) {
+		for field, value := range map[string]interface{}{"challenge": 5, "domain": map[string]interface{}{}} {
+			vpMap, err := jsonutil.ToMap(createVP(t, "challenge-1", "verifier.example.com"))
+			require.NoError(t, err)
+
+			vpMap["proof"].(map[string]interface{})[field] = value
+
+			vpBytes, err := json.Marshal(vpMap)
+			require.NoError(t, err)
+
+			_, err = newTestPresentation(t, vpBytes, opts...)
+			require.EqualError(t, err, "check embedded proof: proof "+field+" is not a string")
+		}
+	})
+
+	t.Run("proof is missing", func(t *testing.T) {
+		_, err := newTestPresentation(t, createVP(t, "", ""), opts...)
+		require.EqualError(t, err, "embedded proof with expected challenge or domain is missing")
+	})
+
+	t.Run("proof is empty", func(t *testing.T) {
+		for _, proof := range []string{`{}`, `[]`} {
+			vpMap, err := jsonutil.ToMap(createVP(t, "", ""))
+			require.NoError(t, err)
+
+			vpMap["proof"] = json.RawMessage(proof)
+
+			vpBytes, err := json.Marshal(vpMap)
+			require.NoError(t, err)
+
+			_, err = newTestPresentation(t, vpBytes, opts...)
+			require.EqualError(t, err, "embedded proof with expected challenge or domain is missing")
+		}
+	})
+
+	t.Run("JWS presentation", func(t *testing.T) {
+		createJWS := func(t *testing.T, jwtClaims map[string]interface{}) []byte {
+			t.Helper()
+
+			vpMap, err := jsonutil.ToMap(createVP(t, "", ""))
+			require.NoError(t, err)
+
+			vpMap[PresentationJWTClaimsField] = jwtClaims
+
+			vpBytes, err := json.Marshal(vpMap)
+			require.NoError(t, err)
+
+			vpJWT, err := JSONToPresentationJWT(vpBytes, EdDSA, signer, "did:example:123456#key1")
+			require.NoError(t, err)
+
+			return []byte(vpJWT)
+		}
+
+		_, err := newTestPresentation(t, createJWS(t, map[string]interface{}{
+			"nonce": "challenge-1",
+			"aud":   []string{"verifier.example.com"},
+		}), opts...)
+		require.NoError(t, err)
+
+		_, err = newTestPresentation(t, createJWS(t, map[string]interface{}{
+			"nonce": "challenge-2",
+			"aud":   "verifier.example.com",
+		}), opts...)
+		require.EqualError(t, err, "presentation JWT nonce challenge-2 does not match expected challenge")
+
+		_, err = newTestPresentation(t, createJWS(t, map[string]interface{}{
+			"aud": "verifier.example.com",
+		}), opts...)
+		require.EqualError(t, err, "presentation JWT nonce <nil> does not match expected challenge")
+
+		_, err = newTestPresentation(t, createJWS(t, map[string]interface{}{
+			"nonce": "challenge-1",
+			"aud":   "other.example.com",
+		}), opts...)
+		require.EqualError(t, err,
+			"presentation JWT audience [other.example.com] does not contain expected domain")
+	})
+}
+
 func TestPresentation_AddLinkedDataProof(t *testing.T) {
 	r := require.New(t)
 
@@ -97,3 +235,209 @@ func TestPresentation_AddLinkedDataProof(t *testing.T) {
 		r.Equal("Ed25519Signature2018", newVPProof["type"])
 	})
 }
+
+func TestParsePresentationWithMultipleLinkedDataProofs(t *testing.T) {
+	r := require.New(t)
+
+	holder1, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	holder2, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	r.NoError(err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(holder1)),
+		VerificationMethod:      "did:example:123456#key1",
+	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ed25519signature2018.New(suite.WithSigner(holder2)),
+		VerificationMethod:      "did:example:123456#key2",
+	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vpBytes, err := json.Marshal(vp)
+	r.NoError(err)
+
+	// key2 resolves to the key of holder1, so the proof made by holder2 is invalid.
+	fetcher := func(_, _ string) (*verifier.PublicKey, error) {
+		return &verifier.PublicKey{Type: kms.ED25519, Value: holder1.PublicKeyBytes()}, nil
+	}
+
+	t.Run("all proofs are required by default", func(t *testing.T) {
+		vpWithLdp, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher))
+		r.Error(err)
+		r.Contains(err.Error(), "check embedded proof")
+		r.Nil(vpWithLdp)
+	})
+
+	t.Run("require all proofs", func(t *testing.T) {
+		vpWithLdp, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher),
+			WithPresProofQuorum(2))
+		r.Error(err)
+		r.Contains(err.Error(), "1 of 2 proofs are valid while 2 are required")
+		r.Nil(vpWithLdp)
+	})
+
+	t.Run("quorum of valid proofs is reached", func(t *testing.T) {
+		vpWithLdp, err := newTestPresentation(t, vpBytes, WithPresPublicKeyFetcher(fetcher),
+			WithPresProofQuorum(1))
+		r.NoError(err)
+		r.Len(vpWithLdp.Proofs, 2)
+	})
+}
+
+func TestPresentation_VerifyProofCoverage(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	r.NoError(err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ss,
+		VerificationMethod:      "did:example:123456#key1",
+	}, ldprocessor.WithDocumentLoader(createTestDocumentLoader(t)))
+	r.NoError(err)
+
+	vpBytes, err := json.Marshal(vp)
+	r.NoError(err)
+
+	verifyOpts := []PresentationOpt{
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+		WithPresJSONLDDocumentLoader(createTestDocumentLoader(t)),
+	}
+
+	t.Run("proof covers the credentials", func(t *testing.T) {
+		vpWithLdp, err := newTestPresentation(t, vpBytes, verifyOpts...)
+		r.NoError(err)
+
+		r.NoError(vpWithLdp.VerifyProofCoverage(verifyOpts...))
+	})
+
+	t.Run("credential injected after signing", func(t *testing.T) {
+		vpWithLdp, err := newTestPresentation(t, vpBytes, verifyOpts...)
+		r.NoError(err)
+
+		extraVC, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		vpWithLdp.AddCredentials(extraVC)
+
+		err = vpWithLdp.VerifyProofCoverage(verifyOpts...)
+		r.Error(err)
+		r.Contains(err.Error(), "verify proof coverage: signed document does not match presentation")
+	})
+
+	t.Run("presentation without proof", func(t *testing.T) {
+		unsignedVP, err := newTestPresentation(t, []byte(validPresentation))
+		r.NoError(err)
+
+		r.EqualError(unsignedVP.VerifyProofCoverage(verifyOpts...),
+			"verify proof coverage: presentation has no embedded proof")
+	})
+}
+
+func TestVerifyPresentation(t *testing.T) {
+	r := require.New(t)
+
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	r.NoError(err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	loader := ldprocessor.WithDocumentLoader(createTestDocumentLoader(t))
+
+	vc1, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	vc2, err := parseTestCredential(t, []byte(validCredential))
+	r.NoError(err)
+
+	vc2.ID = "http://example.edu/credentials/2"
+
+	for _, vc := range []*Credential{vc1, vc2} {
+		err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureJWS,
+			Suite:                   ss,
+			VerificationMethod:      vc.Issuer.ID + "#key1",
+		}, loader)
+		r.NoError(err)
+	}
+
+	vp, err := NewPresentation(WithCredentials(vc1, vc2))
+	r.NoError(err)
+
+	err = vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ss,
+		VerificationMethod:      "did:example:holder#key1",
+	}, loader)
+	r.NoError(err)
+
+	vpBytes, err := json.Marshal(vp)
+	r.NoError(err)
+
+	opts := []PresentationOpt{
+		WithPresJSONLDDocumentLoader(createTestDocumentLoader(t)),
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+	}
+
+	t.Run("presentation and credentials are verified", func(t *testing.T) {
+		vpVerified, creds, err := VerifyPresentation(vpBytes, opts...)
+		r.NoError(err)
+		r.NotNil(vpVerified)
+		r.Len(creds, 2)
+		r.Equal(vc1.ID, creds[0].ID)
+		r.Equal(vc2.ID, creds[1].ID)
+		r.Len(creds[0].Proofs, 1)
+	})
+
+	t.Run("credential with invalid proof", func(t *testing.T) {
+		vcTampered, err := parseTestCredential(t, []byte(validCredential))
+		r.NoError(err)
+
+		vcTampered.Proofs = vc1.Proofs
+		vcTampered.ID = "http://example.edu/credentials/tampered"
+
+		vpTampered, err := NewPresentation(WithCredentials(vc2, vcTampered))
+		r.NoError(err)
+
+		vpTamperedBytes, err := json.Marshal(vpTampered)
+		r.NoError(err)
+
+		vpVerified, creds, err := VerifyPresentation(vpTamperedBytes, opts...)
+		r.Error(err)
+		r.Contains(err.Error(), "verify presentation: credential 1")
+		r.Nil(vpVerified)
+		r.Nil(creds)
+	})
+
+	t.Run("invalid presentation", func(t *testing.T) {
+		vpVerified, creds, err := VerifyPresentation([]byte("not a presentation"), opts...)
+		r.Error(err)
+		r.Contains(err.Error(), "verify presentation")
+		r.Nil(vpVerified)
+		r.Nil(creds)
+	})
+}
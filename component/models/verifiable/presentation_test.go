@@ -19,6 +19,7 @@ import (
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
 	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	jsonutil "github.com/hyperledger/aries-framework-go/component/models/util/json"
 	"github.com/hyperledger/aries-framework-go/spi/kms"
 )
 
@@ -517,6 +518,89 @@ func TestNewPresentation(t *testing.T) {
 	r.EqualError(err, "credential is not base64url encoded JWT")
 }
 
+func TestMergePresentations(t *testing.T) {
+	r := require.New(t)
+
+	sharedVC, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	r.NoError(err)
+
+	otherVC, err := parseTestCredential(t, []byte(validCredential), WithDisabledProofCheck())
+	r.NoError(err)
+
+	otherVC.ID = "http://example.edu/credentials/other"
+
+	a, err := NewPresentation(WithCredentials(sharedVC))
+	r.NoError(err)
+
+	a.Holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+	a.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+	b, err := NewPresentation(WithCredentials(sharedVC, otherVC))
+	r.NoError(err)
+
+	b.Context = append(b.Context, "https://www.w3.org/2018/credentials/examples/v1")
+	b.Type = append(b.Type, "CredentialManagerPresentation")
+	b.Proofs = []Proof{{"type": "Ed25519Signature2018"}}
+
+	t.Run("merge presentations with a shared credential", func(t *testing.T) {
+		merged, err := MergePresentations(a, b)
+		r.NoError(err)
+
+		r.Equal([]string{
+			"https://www.w3.org/2018/credentials/v1",
+			"https://www.w3.org/2018/credentials/examples/v1",
+		}, merged.Context)
+		r.Equal([]string{"VerifiablePresentation", "CredentialManagerPresentation"}, merged.Type)
+		r.Equal(a.Holder, merged.Holder)
+		r.Equal([]interface{}{sharedVC, otherVC}, merged.Credentials())
+		r.Empty(merged.Proofs)
+
+		// source presentations are not changed
+		r.Len(a.Credentials(), 1)
+		r.Len(a.Proofs, 1)
+	})
+
+	t.Run("merge presentations with raw credentials", func(t *testing.T) {
+		vcMap, err := jsonutil.ToMap(sharedVC)
+		r.NoError(err)
+
+		c := &Presentation{credentials: []interface{}{vcMap}}
+
+		merged, err := MergePresentations(a, c)
+		r.NoError(err)
+		r.Equal([]interface{}{sharedVC}, merged.Credentials())
+	})
+
+	t.Run("merge presentations with shared custom contexts", func(t *testing.T) {
+		sharedCtx := map[string]interface{}{"image": "http://schema.org/image"}
+		otherCtx := map[string]interface{}{"name": "http://schema.org/name"}
+
+		c := &Presentation{CustomContext: []interface{}{sharedCtx}}
+		d := &Presentation{CustomContext: []interface{}{
+			map[string]interface{}{"image": "http://schema.org/image"}, otherCtx,
+		}}
+
+		merged, err := MergePresentations(c, d)
+		r.NoError(err)
+		r.Equal([]interface{}{sharedCtx, otherCtx}, merged.CustomContext)
+	})
+
+	t.Run("holders differ", func(t *testing.T) {
+		c := &Presentation{Holder: "did:example:other"}
+
+		merged, err := MergePresentations(a, c)
+		r.Error(err)
+		r.Contains(err.Error(), "holders differ")
+		r.Nil(merged)
+	})
+
+	t.Run("presentation is not defined", func(t *testing.T) {
+		merged, err := MergePresentations(a, nil)
+		r.EqualError(err, "merge presentations: presentation is not defined")
+		r.Nil(merged)
+	})
+}
+
 func TestPresentation_decodeCredentials(t *testing.T) {
 	r := require.New(t)
 
@@ -563,6 +647,78 @@ func TestWithPresPublicKeyFetcher(t *testing.T) {
 	require.NotNil(t, opts.publicKeyFetcher)
 }
 
+func TestWithPresHolderAndCredentialKeyFetchers(t *testing.T) {
+	issuerSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	holderSigner, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	vcClaims, err := vc.JWTClaims(false)
+	require.NoError(t, err)
+
+	vcJWS, err := vcClaims.MarshalJWS(EdDSA, issuerSigner, vc.Issuer.ID+"#issuer-key")
+	require.NoError(t, err)
+
+	vp, err := NewPresentation(WithJWTCredentials(vcJWS))
+	require.NoError(t, err)
+
+	vp.Holder = "did:example:ebfeb1f712ebc6f1c276e12ec21"
+
+	vpClaims, err := vp.JWTClaims(nil, false)
+	require.NoError(t, err)
+
+	vpJWS, err := vpClaims.MarshalJWS(EdDSA, holderSigner, vp.Holder+"#holder-key")
+	require.NoError(t, err)
+
+	holderFetcher := SingleKey(holderSigner.PublicKeyBytes(), kms.ED25519)
+	issuerFetcher := SingleKey(issuerSigner.PublicKeyBytes(), kms.ED25519)
+
+	t.Run("holder and credential proofs are verified by distinct fetchers", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresHolderKeyFetcher(holderFetcher),
+			WithPresCredentialKeyFetcher(issuerFetcher))
+		require.NoError(t, err)
+		require.Len(t, vpParsed.Credentials(), 1)
+	})
+
+	t.Run("distinct fetchers take precedence over the common one", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresPublicKeyFetcher(SingleKey([]byte("unused key"), kms.ED25519)),
+			WithPresHolderKeyFetcher(holderFetcher),
+			WithPresCredentialKeyFetcher(issuerFetcher))
+		require.NoError(t, err)
+		require.Len(t, vpParsed.Credentials(), 1)
+	})
+
+	t.Run("credential proof is not verified by holder fetcher", func(t *testing.T) {
+		_, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresHolderKeyFetcher(holderFetcher),
+			WithPresCredentialKeyFetcher(holderFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decode credentials of presentation")
+	})
+
+	t.Run("holder proof is not verified by credential fetcher", func(t *testing.T) {
+		_, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresHolderKeyFetcher(issuerFetcher),
+			WithPresCredentialKeyFetcher(issuerFetcher))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "decoding of Verifiable Presentation from JWS")
+	})
+
+	t.Run("common fetcher is used for what is not set", func(t *testing.T) {
+		vpParsed, err := newTestPresentation(t, []byte(vpJWS),
+			WithPresPublicKeyFetcher(issuerFetcher),
+			WithPresHolderKeyFetcher(holderFetcher))
+		require.NoError(t, err)
+		require.Len(t, vpParsed.Credentials(), 1)
+	})
+}
+
 func TestWithPresEmbeddedSignatureSuites(t *testing.T) {
 	ss := ed25519signature2018.New()
 
@@ -0,0 +1,119 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+)
+
+// WithCredProofPurpose validates that every linked data proof of the credential has the given proof purpose,
+// e.g. "assertionMethod".
+func WithCredProofPurpose(purpose string) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.expectedProofPurpose = purpose
+	}
+}
+
+// WithCredProofPurposeResolver makes the proof purpose check set by WithCredProofPurpose also resolve the DID of
+// the proof verification method and check that the method is listed under the matching verification relationship.
+func WithCredProofPurposeResolver(resolver Resolver) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.proofPurposeResolver = resolver
+	}
+}
+
+// WithPresProofPurpose validates that every linked data proof of Verifiable Presentation has the given proof
+// purpose, e.g. "authentication".
+func WithPresProofPurpose(purpose string) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.expectedProofPurpose = purpose
+	}
+}
+
+// WithPresProofPurposeResolver makes the proof purpose check set by WithPresProofPurpose also resolve the DID of
+// the proof verification method and check that the method is listed under the matching verification relationship.
+func WithPresProofPurposeResolver(resolver Resolver) PresentationOpt {
+	return func(opts *presentationOpts) {
+		opts.proofPurposeResolver = resolver
+	}
+}
+
+// checkProofPurpose checks that every proof has the expected purpose and, if the resolver is given,
+// that the controller document of the proof verification method allows the key to be used for this purpose.
+// Relative verification methods (e.g. "#key-1") are resolved against baseDID.
+func checkProofPurpose(proofs []map[string]interface{}, purpose string, resolver Resolver, baseDID string) error {
+	if err := checkProofField(proofs, "proofPurpose", purpose); err != nil {
+		return err
+	}
+
+	if resolver == nil {
+		return nil
+	}
+
+	relationship, ok := proofPurposeRelationship(purpose)
+	if !ok {
+		return fmt.Errorf("proof purpose %s has no verification relationship", purpose)
+	}
+
+	for _, p := range proofs {
+		verificationMethod, _ := p["verificationMethod"].(string)
+
+		if err := checkKeyRelationship(resolver, verificationMethod, baseDID, relationship); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func proofPurposeRelationship(purpose string) (did.VerificationRelationship, bool) {
+	for _, rel := range []did.VerificationRelationship{
+		did.Authentication, did.AssertionMethod, did.CapabilityDelegation, did.CapabilityInvocation, did.KeyAgreement,
+	} {
+		if relationshipName(rel) == purpose {
+			return rel, true
+		}
+	}
+
+	return did.VerificationRelationshipGeneral, false
+}
+
+func checkKeyRelationship(resolver Resolver, verificationMethod, baseDID string,
+	rel did.VerificationRelationship) error {
+	controller, _, _ := strings.Cut(verificationMethod, "#")
+	if controller == "" {
+		if baseDID == "" {
+			return fmt.Errorf("no DID to resolve relative verification method %s against", verificationMethod)
+		}
+
+		controller = baseDID
+		verificationMethod = baseDID + verificationMethod
+	}
+
+	docResolution, err := resolver.Resolve(controller)
+	if err != nil {
+		return fmt.Errorf("resolve DID %s: %w", controller, err)
+	}
+
+	if docResolution == nil || docResolution.DIDDocument == nil {
+		return fmt.Errorf("DID %s resolved to no DID document", controller)
+	}
+
+	for _, verification := range docResolution.DIDDocument.VerificationMethods(rel)[rel] {
+		id := verification.VerificationMethod.ID
+
+		if id == verificationMethod || controller+id == verificationMethod {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("verification method %s is not found in %s of DID %s", verificationMethod,
+		relationshipName(rel), controller)
+}
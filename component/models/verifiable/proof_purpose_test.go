@@ -0,0 +1,169 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/hyperledger/aries-framework-go/component/models/did"
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+func TestWithCredProofPurpose(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	sigSuite := ed25519signature2018.New(
+		suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vc, err := parseTestCredential(t, []byte(validCredential))
+	require.NoError(t, err)
+
+	verificationMethod := vc.Issuer.ID + "#key1"
+
+	require.NoError(t, vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   sigSuite,
+		VerificationMethod:      verificationMethod,
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t))))
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	opts := []CredentialOpt{
+		WithEmbeddedSignatureSuites(sigSuite),
+		WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+	}
+
+	t.Run("purpose matches", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, append(opts, WithCredProofPurpose("assertionMethod"))...)
+		require.NoError(t, err)
+	})
+
+	t.Run("purpose mismatches", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes, append(opts, WithCredProofPurpose("authentication"))...)
+		require.ErrorContains(t, err, "proof proofPurpose assertionMethod does not match expected one")
+	})
+
+	t.Run("key relationship", func(t *testing.T) {
+		doc := &did.Doc{
+			ID: vc.Issuer.ID,
+			AssertionMethod: []did.Verification{{
+				VerificationMethod: did.VerificationMethod{ID: "#key1"},
+				Relationship:       did.AssertionMethod,
+			}},
+		}
+
+		_, err := parseTestCredential(t, vcBytes, append(opts, WithCredProofPurpose("assertionMethod"),
+			WithCredProofPurposeResolver(&mockResolver{didDoc: doc}))...)
+		require.NoError(t, err)
+
+		doc.Authentication, doc.AssertionMethod = doc.AssertionMethod, nil
+
+		_, err = parseTestCredential(t, vcBytes, append(opts, WithCredProofPurpose("assertionMethod"),
+			WithCredProofPurposeResolver(&mockResolver{didDoc: doc}))...)
+		require.ErrorContains(t, err, "verification method "+verificationMethod+
+			" is not found in assertionMethod of DID "+vc.Issuer.ID)
+	})
+}
+
+func TestWithPresProofPurpose(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	ss := ed25519signature2018.New(suite.WithSigner(signer),
+		suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))
+
+	vp, err := newTestPresentation(t, []byte(validPresentation))
+	require.NoError(t, err)
+
+	require.NoError(t, vp.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           "Ed25519Signature2018",
+		SignatureRepresentation: SignatureJWS,
+		Suite:                   ss,
+		VerificationMethod:      "did:example:123456#key1",
+		Purpose:                 "authentication",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t))))
+
+	vpBytes, err := json.Marshal(vp)
+	require.NoError(t, err)
+
+	opts := []PresentationOpt{
+		WithPresEmbeddedSignatureSuites(ss),
+		WithPresPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+	}
+
+	_, err = newTestPresentation(t, vpBytes, append(opts, WithPresProofPurpose("authentication"))...)
+	require.NoError(t, err)
+
+	_, err = newTestPresentation(t, vpBytes, append(opts, WithPresProofPurpose("assertionMethod"))...)
+	require.EqualError(t, err,
+		"check embedded proof: proof proofPurpose authentication does not match expected one")
+
+	doc := &did.Doc{
+		ID: "did:example:123456",
+		Authentication: []did.Verification{{
+			VerificationMethod: did.VerificationMethod{ID: "did:example:123456#key1"},
+			Relationship:       did.Authentication,
+		}},
+	}
+
+	_, err = newTestPresentation(t, vpBytes, append(opts, WithPresProofPurpose("authentication"),
+		WithPresProofPurposeResolver(&mockResolver{didDoc: doc}))...)
+	require.NoError(t, err)
+}
+
+func TestProofPurposeRelationship(t *testing.T) {
+	rel, ok := proofPurposeRelationship("capabilityInvocation")
+	require.True(t, ok)
+	require.Equal(t, did.CapabilityInvocation, rel)
+
+	_, ok = proofPurposeRelationship("unknown")
+	require.False(t, ok)
+
+	err := checkProofPurpose([]map[string]interface{}{{"proofPurpose": "unknown"}}, "unknown", &mockResolver{}, "")
+	require.EqualError(t, err, "proof purpose unknown has no verification relationship")
+}
+
+func TestCheckProofPurpose_RelativeVerificationMethod(t *testing.T) {
+	const issuer = "did:example:123"
+
+	proofs := []map[string]interface{}{{"proofPurpose": "assertionMethod", "verificationMethod": "#key-1"}}
+
+	resolver := &recordingResolver{didDoc: &did.Doc{
+		ID: issuer,
+		AssertionMethod: []did.Verification{{
+			VerificationMethod: did.VerificationMethod{ID: "#key-1"},
+			Relationship:       did.AssertionMethod,
+		}},
+	}}
+
+	require.NoError(t, checkProofPurpose(proofs, "assertionMethod", resolver, issuer))
+	require.Equal(t, []string{issuer}, resolver.resolved)
+
+	err := checkProofPurpose(proofs, "authentication", resolver, issuer)
+	require.EqualError(t, err, "proof proofPurpose assertionMethod does not match expected one")
+
+	proofs[0]["proofPurpose"] = "authentication"
+
+	err = checkProofPurpose(proofs, "authentication", resolver, issuer)
+	require.EqualError(t, err, "verification method "+issuer+"#key-1 is not found in authentication of DID "+issuer)
+
+	err = checkProofPurpose(proofs, "authentication", resolver, "")
+	require.EqualError(t, err, "no DID to resolve relative verification method #key-1 against")
+
+	err = checkProofPurpose(proofs, "authentication", &recordingResolver{}, issuer)
+	require.EqualError(t, err, "DID "+issuer+" resolved to no DID document")
+}
@@ -0,0 +1,70 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const bitsPerByte = 8
+
+// StatusListIndexError is returned when a status list index is beyond the end of the status list.
+type StatusListIndexError struct {
+	Index int
+}
+
+func (e *StatusListIndexError) Error() string {
+	return fmt.Sprintf("index %d is out of range of the status list", e.Index)
+}
+
+// StatusListBit returns the bit at index of the encodedList of a status list credential, i.e. the GZIP-compressed,
+// base64url encoded bitstring in which the left-most bit has index 0.
+//
+// The bitstring is decoded as a stream and is inflated only up to the byte holding the bit, so checking a status
+// does not require decompressing a whole multi-megabyte list. If index is beyond the end of the list,
+// the error wraps *StatusListIndexError.
+func StatusListBit(encodedList string, index int) (bool, error) {
+	if index < 0 {
+		return false, fmt.Errorf("status list bit: invalid index %d", index)
+	}
+
+	b64 := base64.NewDecoder(base64.RawURLEncoding, strings.NewReader(strings.TrimRight(encodedList, "=")))
+
+	bitstring, err := gzip.NewReader(b64)
+	if err != nil {
+		return false, fmt.Errorf("status list bit: decompress encoded list: %w", err)
+	}
+
+	defer bitstring.Close() //nolint:errcheck
+
+	offset := int64(index / bitsPerByte)
+
+	if _, err = io.CopyN(io.Discard, bitstring, offset); err != nil {
+		return false, statusListReadError(index, err)
+	}
+
+	var b [1]byte
+
+	if _, err = io.ReadFull(bitstring, b[:]); err != nil {
+		return false, statusListReadError(index, err)
+	}
+
+	return b[0]&(1<<(bitsPerByte-1-index%bitsPerByte)) != 0, nil
+}
+
+func statusListReadError(index int, err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("status list bit: %w", &StatusListIndexError{Index: index})
+	}
+
+	return fmt.Errorf("status list bit: decompress encoded list: %w", err)
+}
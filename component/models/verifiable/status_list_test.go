@@ -0,0 +1,104 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodeStatusList(t testing.TB, bitstring []byte) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	w := gzip.NewWriter(&buf)
+
+	_, err := w.Write(bitstring)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestStatusListBit(t *testing.T) {
+	bitstring := make([]byte, 16*1024)
+	bitstring[0] = 0b1000_0001
+	bitstring[1000] = 0b0010_0000
+
+	encoded := encodeStatusList(t, bitstring)
+
+	t.Run("set and unset bits", func(t *testing.T) {
+		for index, expected := range map[int]bool{0: true, 1: false, 7: true, 8002: true, 8003: false} {
+			set, err := StatusListBit(encoded, index)
+			require.NoError(t, err)
+			require.Equal(t, expected, set, "index %d", index)
+		}
+	})
+
+	t.Run("padded encoded list", func(t *testing.T) {
+		compressed, err := base64.RawURLEncoding.DecodeString(encoded)
+		require.NoError(t, err)
+
+		set, err := StatusListBit(base64.URLEncoding.EncodeToString(compressed), 8002)
+		require.NoError(t, err)
+		require.True(t, set)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := StatusListBit(encoded, -1)
+		require.EqualError(t, err, "status list bit: invalid index -1")
+
+		_, err = StatusListBit(encoded, len(bitstring)*8)
+		require.EqualError(t, err, "status list bit: index 131072 is out of range of the status list")
+
+		_, err = StatusListBit("not gzip", 0)
+		require.ErrorContains(t, err, "status list bit: decompress encoded list")
+
+		_, err = StatusListBit(encoded[:len(encoded)/2], 8002)
+		require.ErrorContains(t, err, "status list bit")
+	})
+}
+
+func BenchmarkStatusListBit(b *testing.B) {
+	// 16 MB bitstring, i.e. a list of ~134M entries.
+	encoded := encodeStatusList(b, make([]byte, 16*1024*1024))
+
+	b.Run("streaming decoder", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := StatusListBit(encoded, 42); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("full decoding", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			compressed, err := base64.RawURLEncoding.DecodeString(encoded)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			r, err := gzip.NewReader(bytes.NewReader(compressed))
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			bitstring, err := io.ReadAll(r)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			_ = bitstring[42/8]&(1<<(7-42%8)) != 0
+		}
+	})
+}
@@ -0,0 +1,55 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"sync"
+
+	"github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+)
+
+// SuiteRegistry holds linked data proof signature suites registered by application per proof type, e.g. custom
+// suites with their own canonicalization, digest and signature verification. It is safe for concurrent use.
+//
+// When checking embedded proofs of a credential parsed with WithSuiteRegistry, the registry is consulted only for
+// the proof types which have no built-in suite, and unless one of the suites defined by WithEmbeddedSignatureSuites
+// accepts the type. A suite registered for a built-in proof type, e.g. Ed25519Signature2018, is never used.
+type SuiteRegistry struct {
+	mu     sync.RWMutex
+	suites map[string]verifier.SignatureSuite
+}
+
+// NewSuiteRegistry creates an empty SuiteRegistry.
+func NewSuiteRegistry() *SuiteRegistry {
+	return &SuiteRegistry{suites: make(map[string]verifier.SignatureSuite)}
+}
+
+// Register registers the signature suite for the proof type, replacing the suite registered before, if any.
+func (r *SuiteRegistry) Register(proofType string, suite verifier.SignatureSuite) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.suites[proofType] = suite
+}
+
+// Lookup returns the signature suite registered for the proof type.
+func (r *SuiteRegistry) Lookup(proofType string) (verifier.SignatureSuite, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	suite, ok := r.suites[proofType]
+
+	return suite, ok
+}
+
+// WithSuiteRegistry defines the registry of signature suites consulted for proof types of the embedded linked
+// data proofs of VC.
+func WithSuiteRegistry(registry *SuiteRegistry) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.suiteRegistry = registry
+	}
+}
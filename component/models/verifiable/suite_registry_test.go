@@ -0,0 +1,148 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	jsonldsig "github.com/hyperledger/aries-framework-go/component/models/ld/processor"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite"
+	"github.com/hyperledger/aries-framework-go/component/models/signature/suite/ed25519signature2018"
+	sigverifier "github.com/hyperledger/aries-framework-go/component/models/signature/verifier"
+	"github.com/hyperledger/aries-framework-go/spi/kms"
+)
+
+const (
+	customSignatureType = "CustomEd25519Signature2023"
+
+	//nolint:lll
+	customSuiteCredential = `{
+  "@context": [
+    "https://www.w3.org/2018/credentials/v1",
+    {
+      "CustomEd25519Signature2023": {
+        "@id": "https://example.com/security#CustomEd25519Signature2023",
+        "@context": {
+          "@version": 1.1,
+          "@protected": true,
+          "id": "@id",
+          "type": "@type",
+          "sec": "https://w3id.org/security#",
+          "created": {"@id": "http://purl.org/dc/terms/created", "@type": "http://www.w3.org/2001/XMLSchema#dateTime"},
+          "proofPurpose": {
+            "@id": "sec:proofPurpose",
+            "@type": "@vocab",
+            "@context": {"assertionMethod": {"@id": "sec:assertionMethod", "@type": "@id", "@container": "@set"}}
+          },
+          "proofValue": "sec:proofValue",
+          "verificationMethod": {"@id": "sec:verificationMethod", "@type": "@id"}
+        }
+      }
+    }
+  ],
+  "id": "http://example.edu/credentials/1872",
+  "type": "VerifiableCredential",
+  "credentialSubject": {
+    "id": "did:example:ebfeb1f712ebc6f1c276e12ec21"
+  },
+  "issuer": "did:example:76e12ec712ebc6f1c221ebfeb1f",
+  "issuanceDate": "2010-01-01T19:23:24Z"
+}`
+)
+
+// customSuite is Ed25519Signature2018 suite accepting the custom proof type.
+type customSuite struct {
+	*ed25519signature2018.Suite
+}
+
+func (s *customSuite) Accept(t string) bool {
+	return t == customSignatureType
+}
+
+func TestSuiteRegistry(t *testing.T) {
+	signer, err := newCryptoSigner(kms.ED25519Type)
+	require.NoError(t, err)
+
+	vc, err := parseTestCredential(t, []byte(customSuiteCredential))
+	require.NoError(t, err)
+
+	err = vc.AddLinkedDataProof(&LinkedDataProofContext{
+		SignatureType:           customSignatureType,
+		SignatureRepresentation: SignatureProofValue,
+		Suite:                   &customSuite{ed25519signature2018.New(suite.WithSigner(signer))},
+		VerificationMethod:      vc.Issuer.ID + "#key1",
+	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+	require.NoError(t, err)
+
+	vcBytes, err := json.Marshal(vc)
+	require.NoError(t, err)
+
+	registry := NewSuiteRegistry()
+	registry.Register(customSignatureType,
+		&customSuite{ed25519signature2018.New(suite.WithVerifier(ed25519signature2018.NewPublicKeyVerifier()))})
+
+	t.Run("verify proof with registered suite", func(t *testing.T) {
+		vcParsed, err := parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithSuiteRegistry(registry))
+		require.NoError(t, err)
+		require.Len(t, vcParsed.Proofs, 1)
+		require.Equal(t, customSignatureType, vcParsed.Proofs[0]["type"])
+	})
+
+	t.Run("proof type is not supported without registry", func(t *testing.T) {
+		_, err := parseTestCredential(t, vcBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "unsupported proof type: "+customSignatureType)
+	})
+
+	t.Run("registered suite does not replace built-in suite", func(t *testing.T) {
+		edVC, err := parseTestCredential(t, []byte(validCredential))
+		require.NoError(t, err)
+
+		err = edVC.AddLinkedDataProof(&LinkedDataProofContext{
+			SignatureType:           "Ed25519Signature2018",
+			SignatureRepresentation: SignatureProofValue,
+			Suite:                   ed25519signature2018.New(suite.WithSigner(signer)),
+			VerificationMethod:      "did:example:76e12ec712ebc6f1c221ebfeb1f#key1",
+			AllowIssuerMismatch:     true,
+		}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
+		require.NoError(t, err)
+
+		edVCBytes, err := json.Marshal(edVC)
+		require.NoError(t, err)
+
+		overriding := NewSuiteRegistry()
+		overriding.Register("Ed25519Signature2018",
+			ed25519signature2018.New(suite.WithVerifier(&failingVerifier{})))
+
+		_, err = parseTestCredential(t, edVCBytes,
+			WithPublicKeyFetcher(SingleKey(signer.PublicKeyBytes(), kms.ED25519)),
+			WithSuiteRegistry(overriding))
+		require.NoError(t, err)
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		s, ok := registry.Lookup(customSignatureType)
+		require.True(t, ok)
+		require.True(t, s.Accept(customSignatureType))
+
+		_, ok = registry.Lookup("Ed25519Signature2018")
+		require.False(t, ok)
+	})
+}
+
+type failingVerifier struct{}
+
+func (v *failingVerifier) Verify(*sigverifier.PublicKey, []byte, []byte) error {
+	return errors.New("verify error")
+}
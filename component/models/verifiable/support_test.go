@@ -92,6 +92,7 @@ func createVCWithLinkedDataProof(t *testing.T) (*Credential, PublicKeyFetcher) {
 		SignatureRepresentation: SignatureJWS,
 		Created:                 &created,
 		VerificationMethod:      "did:123#any",
+		AllowIssuerMismatch:     true,
 	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
 
 	require.NoError(t, err)
@@ -121,6 +122,7 @@ func createVCWithTwoLinkedDataProofs(t *testing.T) (*Credential, PublicKeyFetche
 		SignatureRepresentation: SignatureJWS,
 		Created:                 &created,
 		VerificationMethod:      "did:123#key1",
+		AllowIssuerMismatch:     true,
 	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
 
 	require.NoError(t, err)
@@ -136,6 +138,7 @@ func createVCWithTwoLinkedDataProofs(t *testing.T) (*Credential, PublicKeyFetche
 		SignatureRepresentation: SignatureJWS,
 		Created:                 &created,
 		VerificationMethod:      "did:123#key2",
+		AllowIssuerMismatch:     true,
 	}, jsonldsig.WithDocumentLoader(createTestDocumentLoader(t)))
 
 	require.NoError(t, err)
@@ -0,0 +1,100 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package verifiable
+
+import (
+	"errors"
+
+	"github.com/hyperledger/aries-framework-go/component/models/dataintegrity/models"
+)
+
+// UnknownProofPolicy defines how the embedded proofs of a type which is not supported are handled.
+type UnknownProofPolicy int
+
+const (
+	// UnknownProofError fails the proof check if the credential has a proof of unsupported type.
+	// It is the default policy.
+	UnknownProofError UnknownProofPolicy = iota
+
+	// UnknownProofSkip ignores the proofs of unsupported types and verifies the other proofs.
+	UnknownProofSkip
+
+	// UnknownProofWarn is like UnknownProofSkip but logs a warning for every ignored proof.
+	UnknownProofWarn
+)
+
+// WithUnknownProofPolicy sets how the embedded proofs of unsupported types are handled (UnknownProofError
+// by default). Even if unknown proofs are skipped, the credential must have at least one proof of supported type.
+func WithUnknownProofPolicy(policy UnknownProofPolicy) CredentialOpt {
+	return func(opts *credentialOpts) {
+		opts.unknownProofPolicy = policy
+	}
+}
+
+// filterUnknownProofs drops the proofs of types which are supported neither by the suites of options
+// nor by the built-in ones.
+func filterUnknownProofs(proofs []map[string]interface{}, opts *embeddedProofCheckOpts) ([]map[string]interface{},
+	error) {
+	known := make([]map[string]interface{}, 0, len(proofs))
+
+	for _, proof := range proofs {
+		proofType := safeStringValue(proof["type"])
+
+		if isKnownProofType(proofType, opts) {
+			known = append(known, proof)
+
+			continue
+		}
+
+		if opts.unknownProofPolicy == UnknownProofWarn {
+			logger.Warnf("skipping proof of unsupported type %s", proofType)
+		}
+	}
+
+	if len(known) == 0 {
+		return nil, errors.New("no proof of supported type")
+	}
+
+	return known, nil
+}
+
+func isKnownProofType(proofType string, opts *embeddedProofCheckOpts) bool {
+	if proofType == models.DataIntegrityProof {
+		return true
+	}
+
+	if _, ok := registeredSuite(proofType, opts); ok {
+		return true
+	}
+
+	if len(opts.ldpSuites) > 0 {
+		for _, s := range opts.ldpSuites {
+			if s.Accept(proofType) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	_, err := getProofType(map[string]interface{}{"type": proofType})
+
+	return err == nil
+}
+
+func proofsToElement(proofs []map[string]interface{}) interface{} {
+	if len(proofs) == 1 {
+		return proofs[0]
+	}
+
+	elements := make([]interface{}, len(proofs))
+	for i := range proofs {
+		elements[i] = proofs[i]
+	}
+
+	return elements
+}
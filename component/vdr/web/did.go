@@ -35,6 +35,14 @@ func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 		return address, host, fmt.Errorf("error parsing did:web did")
 	}
 
+	// path components are kept percent-encoded in the URL, e.g. did:web:example.com:users:alice%20smith
+	// resolves to https://example.com/users/alice%20smith/did.json
+	for _, component := range pathComponents[1:] {
+		if err = checkPathComponent(component); err != nil {
+			return address, host, fmt.Errorf("error parsing did:web did: %w", err)
+		}
+	}
+
 	host = strings.Split(pathComponents[0], ":")[0]
 
 	protocol := "https://"
@@ -51,3 +59,24 @@ func parseDIDWeb(id string, useHTTP bool) (string, string, error) {
 
 	return address, host, nil
 }
+
+func checkPathComponent(component string) error {
+	if component == "" {
+		return fmt.Errorf("empty path component")
+	}
+
+	decoded, err := url.PathUnescape(component)
+	if err != nil {
+		return fmt.Errorf("invalid path component %s: %w", component, err)
+	}
+
+	if strings.Contains(decoded, "/") {
+		return fmt.Errorf("invalid path component %s: contains '/'", component)
+	}
+
+	if decoded == "." || decoded == ".." {
+		return fmt.Errorf("invalid path component %s: dot segment", component)
+	}
+
+	return nil
+}
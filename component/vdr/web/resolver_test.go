@@ -60,6 +60,31 @@ func TestParseDID(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, "https://localhost:8080/user/example/did.json", address)
 		require.Equal(t, "localhost", host)
+		address, host, err = parseDIDWeb(prefix+"example.com:users:alice%20smith:profile", false)
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com/users/alice%20smith/profile/did.json", address)
+		require.Equal(t, "example.com", host)
+	})
+
+	t.Run("test parse did with invalid path", func(t *testing.T) {
+		_, _, err := parseDIDWeb(prefix+"example.com:users::alice", false)
+		require.EqualError(t, err, "error parsing did:web did: empty path component")
+
+		_, _, err = parseDIDWeb(prefix+"example.com:users%2Falice", false)
+		require.EqualError(t, err, "error parsing did:web did: invalid path component users%2Falice: contains '/'")
+
+		_, _, err = parseDIDWeb(prefix+"example.com:users:..:admin", false)
+		require.EqualError(t, err, "error parsing did:web did: invalid path component ..: dot segment")
+
+		_, _, err = parseDIDWeb(prefix+"example.com:.:alice", false)
+		require.EqualError(t, err, "error parsing did:web did: invalid path component .: dot segment")
+
+		_, _, err = parseDIDWeb(prefix+"example.com:users:%2E%2E", false)
+		require.EqualError(t, err, "error parsing did:web did: invalid path component %2E%2E: dot segment")
+
+		_, _, err = parseDIDWeb(prefix+"example.com:users%zzalice", false)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid path component users%zzalice")
 	})
 
 	t.Run("test parse did failure", func(t *testing.T) {
@@ -197,3 +222,30 @@ func TestResolveWebFixtures(t *testing.T) {
 		require.Equal(t, expectedDoc, docResolution.DIDDocument)
 	})
 }
+
+func TestResolveNestedPath(t *testing.T) {
+	aliceDoc, err := ioutil.ReadFile("testdata/alice/did.json")
+	require.NoError(t, err)
+
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/alice/did.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, err := w.Write(aliceDoc)
+		require.NoError(t, err)
+	}))
+	defer s.Close()
+
+	t.Run("resolve did:web:host:users:alice", func(t *testing.T) {
+		did := fmt.Sprintf("did:web:%s:users:alice", urlapi.QueryEscape(strings.TrimPrefix(s.URL, "https://")))
+
+		v := New()
+		docResolution, err := v.Read(did, vdrspi.WithOption(HTTPClientOpt, s.Client()))
+		require.NoError(t, err)
+		expectedDoc, err := didapi.ParseDocument(aliceDoc)
+		require.NoError(t, err)
+		require.Equal(t, expectedDoc, docResolution.DIDDocument)
+	})
+}
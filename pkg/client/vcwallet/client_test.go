@@ -846,7 +846,8 @@ func TestClient_Issue(t *testing.T) {
 		require.NoError(t, vcWalletClient.Add(wallet.DIDResolutionResponse, testdata.SampleDocResolutionResponse))
 
 		result, err := vcWalletClient.Issue(testdata.SampleUDCVC, &wallet.ProofOptions{
-			Controller: sampleDIDKey,
+			Controller:          sampleDIDKey,
+			AllowIssuerMismatch: true,
 		})
 
 		require.NoError(t, err)
@@ -1423,7 +1423,8 @@ func TestCommand_IssueProveVerify(t *testing.T) {
 			WalletAuth: WalletAuth{UserID: sampleUser1, Auth: token},
 			Credential: testdata.SampleUDCVC,
 			ProofOptions: &wallet.ProofOptions{
-				Controller: sampleDIDKey,
+				Controller:          sampleDIDKey,
+				AllowIssuerMismatch: true,
 			},
 		}))
 		require.NoError(t, cmdErr)
@@ -883,6 +883,7 @@ func (o *Command) addLinkedDataProof(p provable, opts *ProofOptions) error {
 		Domain:                  opts.Domain,
 		Challenge:               opts.Challenge,
 		Purpose:                 opts.proofPurpose,
+		AllowIssuerMismatch:     opts.AllowIssuerMismatch,
 	}
 
 	err = p.AddLinkedDataProof(signingCtx, jsonld.WithDocumentLoader(o.documentLoader))
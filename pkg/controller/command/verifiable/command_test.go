@@ -2150,7 +2150,7 @@ func TestCommand_SignCredential(t *testing.T) {
 		req := SignCredentialRequest{
 			Credential:   []byte(vc),
 			DID:          "did:peer:123456789abcdefghi#inbox",
-			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018, AllowIssuerMismatch: true},
 		}
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
@@ -2167,11 +2167,26 @@ func TestCommand_SignCredential(t *testing.T) {
 		require.NotEmpty(t, response)
 	})
 
+	t.Run("test sign credential - DID does not match issuer", func(t *testing.T) {
+		req := SignCredentialRequest{
+			Credential:   []byte(vc),
+			DID:          "did:peer:123456789abcdefghi#inbox",
+			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+		}
+		reqBytes, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		var b bytes.Buffer
+		err = cmd.SignCredential(&b, bytes.NewBuffer(reqBytes))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match issuer DID")
+	})
+
 	t.Run("test sign auth credential - success", func(t *testing.T) {
 		req := SignCredentialRequest{
 			Credential:   []byte(authVC),
 			DID:          "did:peer:1zQmYEVm9usSN4UdR3bRH2GLzbbcdrzSMEXvgLweekn3yr66",
-			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018, AllowIssuerMismatch: true},
 		}
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
@@ -2192,7 +2207,7 @@ func TestCommand_SignCredential(t *testing.T) {
 		req := SignCredentialRequest{
 			Credential:   []byte(vc),
 			DID:          jwsDID,
-			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018},
+			ProofOptions: &ProofOptions{SignatureType: Ed25519Signature2018, AllowIssuerMismatch: true},
 		}
 		reqBytes, err := json.Marshal(req)
 		require.NoError(t, err)
@@ -2217,11 +2232,12 @@ func TestCommand_SignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:123456789abcdefghi#inbox",
 			ProofOptions: &ProofOptions{
-				VerificationMethod: "did:peer:123456789abcdefghi#keys-1",
-				Domain:             "issuer.example.com",
-				Challenge:          "sample-random-test-value",
-				Created:            &createdTime,
-				SignatureType:      Ed25519Signature2018,
+				VerificationMethod:  "did:peer:123456789abcdefghi#keys-1",
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				SignatureType:       Ed25519Signature2018,
+				AllowIssuerMismatch: true,
 			},
 		}
 
@@ -2257,10 +2273,11 @@ func TestCommand_SignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:123456789abcdefghi#inbox",
 			ProofOptions: &ProofOptions{
-				Domain:        "issuer.example.com",
-				Challenge:     "sample-random-test-value",
-				Created:       &createdTime,
-				SignatureType: Ed25519Signature2018,
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				SignatureType:       Ed25519Signature2018,
+				AllowIssuerMismatch: true,
 			},
 		}
 
@@ -2298,11 +2315,12 @@ func TestCommand_SignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:123456789abcdefghi#inbox",
 			ProofOptions: &ProofOptions{
-				Domain:             "issuer.example.com",
-				Challenge:          "sample-random-test-value",
-				Created:            &createdTime,
-				VerificationMethod: "did:peer:123456789abcdefghi#keys-1",
-				SignatureType:      JSONWebSignature2020,
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				VerificationMethod:  "did:peer:123456789abcdefghi#keys-1",
+				SignatureType:       JSONWebSignature2020,
+				AllowIssuerMismatch: true,
 			},
 		}
 
@@ -2345,6 +2363,7 @@ func TestCommand_SignCredential(t *testing.T) {
 				SignatureRepresentation: &signatureRepresentation,
 				Created:                 &createdTime,
 				SignatureType:           BbsBlsSignature2020,
+				AllowIssuerMismatch:     true,
 			},
 		}
 
@@ -2381,11 +2400,12 @@ func TestCommand_SignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        jwsDID,
 			ProofOptions: &ProofOptions{
-				VerificationMethod: "did:trustbloc:testnet.trustbloc.local:EiBug_0h2oNJj4Vhk7yrC36HvskhngqTJC46VKS-FDM5fA#key-7777",
-				Domain:             "issuer.example.com",
-				Challenge:          "sample-random-test-value",
-				Created:            &createdTime,
-				SignatureType:      JSONWebSignature2020,
+				VerificationMethod:  "did:trustbloc:testnet.trustbloc.local:EiBug_0h2oNJj4Vhk7yrC36HvskhngqTJC46VKS-FDM5fA#key-7777",
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				SignatureType:       JSONWebSignature2020,
+				AllowIssuerMismatch: true,
 			},
 		}
 
@@ -2895,6 +2915,7 @@ func signVCWithBBS(r *require.Assertions, vc *verifiable.Credential) string {
 		SignatureRepresentation: verifiable.SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      keyID,
+		AllowIssuerMismatch:     true,
 	}
 
 	loader, err := ldtestutil.DocumentLoader()
@@ -52,6 +52,8 @@ type ProofOptions struct {
 	Challenge string `json:"challenge,omitempty"`
 	// SignatureType signature type used for signing
 	SignatureType string `json:"signatureType,omitempty"`
+	// AllowIssuerMismatch allows signing a credential with a verification method of another DID than the issuer.
+	AllowIssuerMismatch bool `json:"allowIssuerMismatch,omitempty"`
 	// proofPurpose is purpose of the proof.
 	proofPurpose string
 }
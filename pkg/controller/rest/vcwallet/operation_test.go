@@ -852,7 +852,8 @@ func TestOperation_IssueProveVerify(t *testing.T) {
 			WalletAuth: vcwallet.WalletAuth{UserID: sampleUser1, Auth: token},
 			Credential: testdata.SampleUDCVC,
 			ProofOptions: &wallet.ProofOptions{
-				Controller: sampleDIDKey,
+				Controller:          sampleDIDKey,
+				AllowIssuerMismatch: true,
 			},
 		}
 
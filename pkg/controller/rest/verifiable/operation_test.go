@@ -1032,7 +1032,8 @@ func TestSignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:21tDAKCERh95uGgKbJNHYp",
 			ProofOptions: &verifiable.ProofOptions{
-				SignatureType: verifiable.Ed25519Signature2018,
+				SignatureType:       verifiable.Ed25519Signature2018,
+				AllowIssuerMismatch: true,
 			},
 		}
 
@@ -1058,11 +1059,12 @@ func TestSignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:21tDAKCERh95uGgKbJNHYp",
 			ProofOptions: &verifiable.ProofOptions{
-				VerificationMethod: "did:peer:123456789abcdefghi#keys-1",
-				Domain:             "issuer.example.com",
-				Challenge:          "sample-random-test-value",
-				Created:            &createdTime,
-				SignatureType:      verifiable.Ed25519Signature2018,
+				VerificationMethod:  "did:peer:123456789abcdefghi#keys-1",
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				SignatureType:       verifiable.Ed25519Signature2018,
+				AllowIssuerMismatch: true,
 			},
 		}
 		reqBytes, err := json.Marshal(req)
@@ -1100,11 +1102,12 @@ func TestSignCredential(t *testing.T) {
 			Credential: []byte(vc),
 			DID:        "did:peer:21tDAKCERh95uGgKbJNHYp",
 			ProofOptions: &verifiable.ProofOptions{
-				VerificationMethod: "did:peer:123456789abcdefghi#keys-1",
-				Domain:             "issuer.example.com",
-				Challenge:          "sample-random-test-value",
-				Created:            &createdTime,
-				SignatureType:      verifiable.Ed25519Signature2018,
+				VerificationMethod:  "did:peer:123456789abcdefghi#keys-1",
+				Domain:              "issuer.example.com",
+				Challenge:           "sample-random-test-value",
+				Created:             &createdTime,
+				SignatureType:       verifiable.Ed25519Signature2018,
+				AllowIssuerMismatch: true,
 			},
 		}
 		reqBytes, err := json.Marshal(req)
@@ -1484,6 +1487,7 @@ func signVCWithBBS(r *require.Assertions, vc *verifiableapi.Credential) string {
 		SignatureRepresentation: verifiableapi.SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      keyID,
+		AllowIssuerMismatch:     true,
 	}
 
 	loader, err := ldtestutil.DocumentLoader()
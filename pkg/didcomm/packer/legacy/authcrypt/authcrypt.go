@@ -8,6 +8,7 @@ package authcrypt
 
 import (
 	"crypto/rand"
+	"errors"
 	"io"
 
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
@@ -18,20 +19,57 @@ import (
 type Packer struct {
 	randSource io.Reader
 	kms        kms.KeyManager
+	alphabet   *Base58Alphabet
+
+	paddingBlockSize    int
+	ephemeralSenderKeys bool
+	contentCipher       ContentCipher
+}
+
+// Opt is an option of the legacy authcrypt Packer.
+type Opt func(p *Packer)
+
+// WithBase58Alphabet sets the base58 alphabet used to encode and decode the recipient KIDs and the sender key
+// of legacy envelopes. BitcoinAlphabet is used by default.
+func WithBase58Alphabet(alphabet *Base58Alphabet) Opt {
+	return func(p *Packer) {
+		p.alphabet = alphabet
+	}
+}
+
+// WithRandSource sets the randomness source of nonces, CEKs and ephemeral keys of packed envelopes, including
+// the ones of the CryptoBox operations. It defaults to crypto/rand; a deterministic source makes envelopes
+// reproducible, e.g. for golden-file tests, and must never be used in production.
+func WithRandSource(randSource io.Reader) Opt {
+	return func(p *Packer) {
+		p.randSource = randSource
+	}
 }
 
+// ErrInvalidRecipientKey is returned when packing for a recipient key that is not a valid Ed25519 public key, e.g. a
+// key of the wrong length or a key that is already in Curve25519 format.
+var ErrInvalidRecipientKey = errors.New("invalid recipient key")
+
 // encodingType is the `typ` string identifier in a message that identifies the format as being legacy.
 const encodingType string = "JWM/1.0"
 
 // New will create a Packer that encrypts messages using the legacy Aries format.
-// Note: legacy Packer does not support XChacha20Poly1035 (XC20P), only Chacha20Poly1035 (C20P).
-func New(ctx packer.Provider) *Packer {
+// Note: Chacha20Poly1035 (C20P) is used by default, use WithContentCipher to select XChacha20Poly1035 (XC20P).
+func New(ctx packer.Provider, opts ...Opt) *Packer {
 	k := ctx.KMS()
 
-	return &Packer{
-		randSource: rand.Reader,
-		kms:        k,
+	p := &Packer{
+		randSource:    rand.Reader,
+		kms:           k,
+		alphabet:      BitcoinAlphabet,
+		contentCipher: C20P,
 	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
 }
 
 // legacyEnvelope is the full payload envelope for the JSON message.
@@ -61,6 +99,7 @@ type recipientHeader struct {
 	KID    string `json:"kid,omitempty"`
 	Sender string `json:"sender,omitempty"`
 	IV     string `json:"iv,omitempty"`
+	EPK    string `json:"epk,omitempty"`
 }
 
 // EncodingType returns the type of the encoding, as in the `Typ` field of the envelope header.
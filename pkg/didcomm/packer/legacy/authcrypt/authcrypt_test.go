@@ -7,9 +7,11 @@ SPDX-License-Identifier: Apache-2.0
 package authcrypt
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -18,20 +20,32 @@ import (
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
+	chacha "golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/nacl/box"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
 
 	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
 	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/ecdh"
+	"github.com/hyperledger/aries-framework-go/pkg/crypto/tinkcrypto/primitive/composite/keyio"
+	jweauthcrypt "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/authcrypt"
+	legacyAnoncrypt "github.com/hyperledger/aries-framework-go/pkg/didcomm/packer/legacy/anoncrypt"
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/jose"
+	"github.com/hyperledger/aries-framework-go/pkg/doc/util/kmsdidkey"
 	vdrapi "github.com/hyperledger/aries-framework-go/pkg/framework/aries/api/vdr"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/localkms"
 	"github.com/hyperledger/aries-framework-go/pkg/kms/webkms"
 	mockkms "github.com/hyperledger/aries-framework-go/pkg/mock/kms"
+	mockprovider "github.com/hyperledger/aries-framework-go/pkg/mock/provider"
 	mockStorage "github.com/hyperledger/aries-framework-go/pkg/mock/storage"
+	mockvdr "github.com/hyperledger/aries-framework-go/pkg/mock/vdr"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock"
 	"github.com/hyperledger/aries-framework-go/pkg/secretlock/noop"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 	"github.com/hyperledger/aries-framework-go/spi/storage"
 )
 
@@ -135,14 +149,14 @@ func (p *provider) KMS() kms.KeyManager {
 	return p.kms
 }
 
-func newWithKMSAndCrypto(t *testing.T, k kms.KeyManager) *Packer {
+func newWithKMSAndCrypto(t *testing.T, k kms.KeyManager, opts ...Opt) *Packer {
 	c, err := tinkcrypto.New()
 	require.NoError(t, err)
 
 	return New(&provider{
 		kms:           k,
 		cryptoService: c,
-	})
+	}, opts...)
 }
 
 func (p *provider) SecretLock() secretlock.Service {
@@ -192,7 +206,9 @@ func TestEncrypt(t *testing.T) {
 		badKey := "6ZAQ7QpmR9EqhJdwx1jQsjq6nnpehwVqUbhVxiEiYEV7"
 
 		_, err := packer.Pack("", []byte("Test Message"), senderKey, [][]byte{base58.Decode(badKey)})
-		require.EqualError(t, err, "pack: failed to build recipients: recipients keys are empty")
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+		require.EqualError(t, err, "pack: failed to build recipients: buildRecipient: invalid recipient key at "+
+			"index 0: failed to convert public Ed25519 to Curve25519: error converting public key")
 	})
 
 	recipientKey := createKey(t, testingKMS)
@@ -355,15 +371,15 @@ func TestEncryptComponents(t *testing.T) {
 	packer2 := newWithKMSAndCrypto(t, testKMS)
 
 	t.Run("Failure: generate recipient header with bad sender key", func(t *testing.T) {
-		_, err := packer2.buildRecipient(&[32]byte{}, []byte(""), base58.Decode(rec1Pub))
+		_, err := packer2.buildRecipient(0, &[32]byte{}, nil, []byte(""), base58.Decode(rec1Pub))
 		require.EqualError(t, err, "buildRecipient: failed to create KID for public key: createKID: "+
 			"empty key")
 	})
 
 	t.Run("Failure: generate recipient header with bad recipient key", func(t *testing.T) {
-		_, err := packer2.buildRecipient(&[32]byte{}, base58.Decode(senderPub), base58.Decode("AAAA"))
-		require.EqualError(t, err, "buildRecipient: failed to convert public Ed25519 to Curve25519: "+
-			"3-byte key size is invalid")
+		_, err := packer2.buildRecipient(0, &[32]byte{}, nil, base58.Decode(senderPub), base58.Decode("AAAA"))
+		require.EqualError(t, err, "buildRecipient: invalid recipient key at index 0: failed to convert public "+
+			"Ed25519 to Curve25519: 3-byte key size is invalid")
 	})
 }
 
@@ -682,6 +698,502 @@ func TestUnpackComponents(t *testing.T) {
 	})
 }
 
+func TestRecipientsOrder(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+
+	var (
+		recKeys [][]byte
+		recKIDs []string
+	)
+
+	for i := 0; i < 5; i++ {
+		recKey := createKey(t, testingKMS)
+
+		recKeys = append(recKeys, recKey)
+		recKIDs = append(recKIDs, base58.Encode(recKey))
+	}
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	t.Run("Success: recipients have the order of recipient keys", func(t *testing.T) {
+		enc, err := packer.Pack("", []byte("message"), senderKey, recKeys)
+		require.NoError(t, err)
+
+		kids, err := recipientKIDs(enc)
+		require.NoError(t, err)
+		require.Equal(t, recKIDs, kids)
+	})
+
+	t.Run("Failure: invalid recipient key is reported with its index", func(t *testing.T) {
+		keys := [][]byte{recKeys[0], recKeys[1], base58.Decode("AAAA"), recKeys[2], recKeys[3], recKeys[4]}
+
+		recipients, err := packer.buildRecipients(&[32]byte{}, nil, senderKey, keys)
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+		require.Contains(t, err.Error(), "at index 2")
+		require.Nil(t, recipients)
+	})
+}
+
+func TestPackInvalidRecipientKey(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	recKey := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	t.Run("32-byte key that is not an Ed25519 public key", func(t *testing.T) {
+		// 32 bytes, but not a valid point on the Ed25519 curve
+		nonEdKey := make([]byte, 32)
+		nonEdKey[0], nonEdKey[1] = 2, 1
+
+		enc, err := packer.Pack("", []byte("message"), senderKey, [][]byte{recKey, nonEdKey})
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+		require.EqualError(t, err, "pack: failed to build recipients: buildRecipient: invalid recipient key at "+
+			"index 1: failed to convert public Ed25519 to Curve25519: error converting public key")
+		require.Nil(t, enc)
+	})
+
+	t.Run("wrong-length key", func(t *testing.T) {
+		enc, err := packer.Pack("", []byte("message"), senderKey, [][]byte{recKey[:31], recKey})
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+		require.EqualError(t, err, "pack: failed to build recipients: buildRecipient: invalid recipient key at "+
+			"index 0: failed to convert public Ed25519 to Curve25519: 31-byte key size is invalid")
+		require.Nil(t, enc)
+	})
+}
+
+func TestEncryptAnon(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	rec1Key := createKey(t, testingKMS)
+	rec2Key := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	t.Run("success: legacy anoncrypt envelope", func(t *testing.T) {
+		msg := []byte("Pack my box with five dozen liquor jugs!")
+
+		enc, err := packer.EncryptAnon(msg, [][]byte{rec1Key, rec2Key})
+		require.NoError(t, err)
+
+		var env legacyEnvelope
+		require.NoError(t, json.Unmarshal(enc, &env))
+
+		protectedBytes, err := base64.URLEncoding.DecodeString(env.Protected)
+		require.NoError(t, err)
+
+		var header protected
+		require.NoError(t, json.Unmarshal(protectedBytes, &header))
+		require.Equal(t, "Anoncrypt", header.Alg)
+		require.Len(t, header.Recipients, 2)
+
+		for _, rec := range header.Recipients {
+			require.Empty(t, rec.Header.Sender)
+			require.Empty(t, rec.Header.IV)
+		}
+
+		// the envelope is opened by the legacy anoncrypt packer
+		dec, err := legacyAnoncrypt.New(&provider{kms: testingKMS}).Unpack(enc)
+		require.NoError(t, err)
+		require.Equal(t, msg, dec.Message)
+		require.Contains(t, [][]byte{rec1Key, rec2Key}, dec.ToKey)
+		require.Empty(t, dec.FromKey)
+	})
+
+	t.Run("failure: no recipients", func(t *testing.T) {
+		_, err := packer.EncryptAnon([]byte("message"), nil)
+		require.EqualError(t, err, "encryptAnon: empty recipients keys, must have at least one recipient")
+	})
+
+	t.Run("failure: invalid recipient key", func(t *testing.T) {
+		_, err := packer.EncryptAnon([]byte("message"), [][]byte{rec1Key[:31]})
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+	})
+
+	t.Run("failure: random source", func(t *testing.T) {
+		failPacker := newWithKMSAndCrypto(t, testingKMS)
+		failPacker.randSource = newFailReader(1, rand.Reader)
+
+		_, err := failPacker.EncryptAnon([]byte("message"), [][]byte{rec1Key})
+		require.EqualError(t, err, "encryptAnon: failed to generate cek: mock Reader has failed intentionally")
+	})
+}
+
+func TestValidateProtected(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	recKey := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	withProtected := func(t *testing.T, env []byte, update func(header *protected)) []byte {
+		t.Helper()
+
+		var envelopeData legacyEnvelope
+		require.NoError(t, json.Unmarshal(env, &envelopeData))
+
+		protectedBytes, err := base64.URLEncoding.DecodeString(envelopeData.Protected)
+		require.NoError(t, err)
+
+		var header protected
+		require.NoError(t, json.Unmarshal(protectedBytes, &header))
+
+		update(&header)
+
+		protectedBytes, err = json.Marshal(header)
+		require.NoError(t, err)
+
+		envelopeData.Protected = base64.URLEncoding.EncodeToString(protectedBytes)
+
+		env, err = json.Marshal(envelopeData)
+		require.NoError(t, err)
+
+		return env
+	}
+
+	authEnv, err := packer.Pack("", []byte("message"), senderKey, [][]byte{recKey})
+	require.NoError(t, err)
+
+	anonEnv, err := packer.EncryptAnon([]byte("message"), [][]byte{recKey})
+	require.NoError(t, err)
+
+	t.Run("well-formed protected header", func(t *testing.T) {
+		require.NoError(t, ValidateProtected(authEnv))
+		require.NoError(t, ValidateProtected(anonEnv))
+	})
+
+	t.Run("malformed protected header", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			env    []byte
+			update func(header *protected)
+			err    string
+		}{
+			{
+				name:   "typ",
+				env:    authEnv,
+				update: func(header *protected) { header.Typ = "JWM/2.0" },
+				err:    "message type JWM/2.0 not supported",
+			},
+			{
+				name:   "enc",
+				env:    authEnv,
+				update: func(header *protected) { header.Enc = "A256GCM" },
+				err:    "encryption A256GCM not supported",
+			},
+			{
+				name:   "alg",
+				env:    authEnv,
+				update: func(header *protected) { header.Alg = "ECDH-1PU" },
+				err:    "message format ECDH-1PU not supported",
+			},
+			{
+				name:   "no recipients",
+				env:    authEnv,
+				update: func(header *protected) { header.Recipients = nil },
+				err:    "no recipients",
+			},
+			{
+				name:   "missing kid",
+				env:    authEnv,
+				update: func(header *protected) { header.Recipients[0].Header.KID = "" },
+				err:    "recipient 0: kid is missing",
+			},
+			{
+				name:   "invalid encrypted_key",
+				env:    authEnv,
+				update: func(header *protected) { header.Recipients[0].EncryptedKey = "!" },
+				err:    "recipient 0: encrypted_key: illegal base64 data at input byte 0",
+			},
+			{
+				name:   "missing sender",
+				env:    authEnv,
+				update: func(header *protected) { header.Recipients[0].Header.Sender = "" },
+				err:    "recipient 0: sender: is missing",
+			},
+			{
+				name: "invalid iv size",
+				env:  authEnv,
+				update: func(header *protected) {
+					header.Recipients[0].Header.IV = base64.URLEncoding.EncodeToString(make([]byte, 12))
+				},
+				err: "recipient 0: iv: invalid nonce size 12",
+			},
+			{
+				name:   "anoncrypt recipient with sender",
+				env:    anonEnv,
+				update: func(header *protected) { header.Recipients[0].Header.Sender = "c2VuZGVy" },
+				err:    "recipient 0: anoncrypt recipient must not have sender and iv",
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				err := ValidateProtected(withProtected(t, tc.env, tc.update))
+				require.EqualError(t, err, "validateProtected: "+tc.err)
+			})
+		}
+
+		err := ValidateProtected([]byte(`{"protected":"!"}`))
+		require.ErrorContains(t, err, "validateProtected: failed to decode protected header")
+	})
+}
+
+func TestMatchableRecipients(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	rec1Key := createKey(t, testingKMS)
+	rec2Key := createKey(t, testingKMS)
+	rec3Key := createKey(t, testingKMS)
+	otherKey := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	enc, err := packer.Pack("", []byte("message"), senderKey, [][]byte{rec1Key, rec2Key, rec3Key})
+	require.NoError(t, err)
+
+	t.Run("Success: partial overlap", func(t *testing.T) {
+		kids, err := MatchableRecipients(enc, []string{
+			base58.Encode(otherKey), base58.Encode(rec3Key), base58.Encode(rec1Key),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{base58.Encode(rec1Key), base58.Encode(rec3Key)}, kids)
+	})
+
+	t.Run("Success: no overlap", func(t *testing.T) {
+		kids, err := MatchableRecipients(enc, []string{base58.Encode(otherKey)})
+		require.NoError(t, err)
+		require.Empty(t, kids)
+	})
+
+	t.Run("Failure: invalid envelope", func(t *testing.T) {
+		kids, err := MatchableRecipients([]byte("{"), []string{base58.Encode(rec1Key)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "matchableRecipients: failed to unmarshal envelope")
+		require.Nil(t, kids)
+	})
+
+	t.Run("Failure: invalid protected header", func(t *testing.T) {
+		kids, err := MatchableRecipients([]byte(`{"protected":"!!!"}`), []string{base58.Encode(rec1Key)})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "matchableRecipients: failed to decode protected header")
+		require.Nil(t, kids)
+
+		protectedHeader := base64.URLEncoding.EncodeToString([]byte("not json"))
+
+		kids, err = MatchableRecipients([]byte(`{"protected":"`+protectedHeader+`"}`), nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "matchableRecipients: failed to unmarshal protected header")
+		require.Nil(t, kids)
+	})
+}
+
+func TestVerifyRecipients(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	rec1Key := createKey(t, testingKMS)
+	rec2Key := createKey(t, testingKMS)
+	otherKey := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	enc, err := packer.Pack("", []byte("message"), senderKey, [][]byte{rec1Key, rec2Key})
+	require.NoError(t, err)
+
+	t.Run("Success: exact match in any order", func(t *testing.T) {
+		require.NoError(t, packer.VerifyRecipients(enc, [][]byte{rec1Key, rec2Key}))
+		require.NoError(t, packer.VerifyRecipients(enc, [][]byte{rec2Key, rec1Key}))
+	})
+
+	t.Run("Success: package level check with the default alphabet", func(t *testing.T) {
+		require.NoError(t, VerifyRecipients(enc, [][]byte{rec2Key, rec1Key}))
+
+		err := VerifyRecipients(enc, [][]byte{rec1Key})
+		require.EqualError(t, err, fmt.Sprintf("verifyRecipients: recipients mismatch: unexpected [%s], missing []",
+			base58.Encode(rec2Key)))
+	})
+
+	t.Run("Failure: extra recipient in envelope", func(t *testing.T) {
+		err := packer.VerifyRecipients(enc, [][]byte{rec1Key})
+		require.EqualError(t, err, fmt.Sprintf("verifyRecipients: recipients mismatch: unexpected [%s], missing []",
+			base58.Encode(rec2Key)))
+	})
+
+	t.Run("Failure: missing recipient in envelope", func(t *testing.T) {
+		err := packer.VerifyRecipients(enc, [][]byte{rec1Key, rec2Key, otherKey})
+		require.EqualError(t, err, fmt.Sprintf("verifyRecipients: recipients mismatch: unexpected [], missing [%s]",
+			base58.Encode(otherKey)))
+	})
+
+	t.Run("Success: recipients encoded with the packer alphabet", func(t *testing.T) {
+		ripplePacker := newWithKMSAndCrypto(t, testingKMS, WithBase58Alphabet(RippleAlphabet))
+
+		rippleEnc, err := ripplePacker.Pack("", []byte("message"), senderKey, [][]byte{rec1Key})
+		require.NoError(t, err)
+
+		require.NoError(t, ripplePacker.VerifyRecipients(rippleEnc, [][]byte{rec1Key}))
+
+		err = packer.VerifyRecipients(rippleEnc, [][]byte{rec1Key})
+		require.EqualError(t, err, fmt.Sprintf("verifyRecipients: recipients mismatch: unexpected [%s], missing [%s]",
+			RippleAlphabet.Encode(rec1Key), base58.Encode(rec1Key)))
+	})
+
+	t.Run("Failure: invalid envelope", func(t *testing.T) {
+		err := packer.VerifyRecipients([]byte("{"), [][]byte{rec1Key})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "verifyRecipients: failed to unmarshal envelope")
+	})
+}
+
+func TestSenderMatchesDIDKey(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	recKey := createKey(t, testingKMS)
+	otherKey := createKey(t, testingKMS)
+
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	enc, err := packer.Pack("", []byte("message"), senderKey, [][]byte{recKey})
+	require.NoError(t, err)
+
+	env, err := packer.Unpack(enc)
+	require.NoError(t, err)
+
+	t.Run("Success: sender matches", func(t *testing.T) {
+		senderDID, senderKID := fingerprint.CreateDIDKey(senderKey)
+
+		match, err := SenderMatchesDIDKey(env.FromKey, senderDID)
+		require.NoError(t, err)
+		require.True(t, match)
+
+		match, err = SenderMatchesDIDKey(env.FromKey, senderKID)
+		require.NoError(t, err)
+		require.True(t, match)
+	})
+
+	t.Run("Success: sender does not match", func(t *testing.T) {
+		otherDID, _ := fingerprint.CreateDIDKey(otherKey)
+
+		match, err := SenderMatchesDIDKey(env.FromKey, otherDID)
+		require.NoError(t, err)
+		require.False(t, match)
+	})
+
+	t.Run("Failure: not a did:key", func(t *testing.T) {
+		match, err := SenderMatchesDIDKey(env.FromKey, "did:example:123")
+		require.EqualError(t, err, "senderMatchesDIDKey: did:example:123 is not a did:key")
+		require.False(t, match)
+	})
+
+	t.Run("Failure: not an Ed25519 did:key", func(t *testing.T) {
+		x25519DID, _ := fingerprint.CreateDIDKeyByCode(fingerprint.X25519PubKeyMultiCodec, senderKey)
+
+		match, err := SenderMatchesDIDKey(env.FromKey, x25519DID)
+		require.EqualError(t, err, fmt.Sprintf("senderMatchesDIDKey: %s is not an Ed25519 did:key", x25519DID))
+		require.False(t, match)
+	})
+
+	t.Run("Failure: invalid did:key", func(t *testing.T) {
+		match, err := SenderMatchesDIDKey(env.FromKey, "did:key:invalid")
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "senderMatchesDIDKey")
+		require.False(t, match)
+	})
+}
+
+func TestToJWE(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+
+	recPub, recPriv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	require.NoError(t, persistKey(t, base58.Encode(recPub), base58.Encode(recPriv), testingKMS))
+
+	legacyPacker := newWithKMSAndCrypto(t, testingKMS)
+
+	legacyEnv, err := legacyPacker.Pack("", []byte("legacy message"), senderKey, [][]byte{recPub})
+	require.NoError(t, err)
+
+	decrypted, err := legacyPacker.Unpack(legacyEnv)
+	require.NoError(t, err)
+
+	c, err := tinkcrypto.New()
+	require.NoError(t, err)
+
+	// JWE authcrypt sender key and its skid ("kmsKID.didKey").
+	senderKID, senderPubKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	senderDIDKey, err := kmsdidkey.BuildDIDKeyByKeyType(senderPubKey, kms.X25519ECDHKWType)
+	require.NoError(t, err)
+
+	jwePacker, err := jweauthcrypt.New(&mockprovider.Provider{
+		KMSValue:        testingKMS,
+		CryptoValue:     c,
+		VDRegistryValue: &mockvdr.MockVDRegistry{},
+	}, jose.XC20P)
+	require.NoError(t, err)
+
+	t.Run("success: recipient opens the JWE with the X25519 counterpart of its legacy key", func(t *testing.T) {
+		jwe, err := ToJWE(jwePacker, transport.MediaTypeV1PlaintextPayload, decrypted.Message,
+			[]byte(senderKID+"."+senderDIDKey), [][]byte{recPub})
+		require.NoError(t, err)
+
+		recEncPub, err := cryptoutil.PublicEd25519toCurve25519(recPub)
+		require.NoError(t, err)
+
+		recEncPriv, err := cryptoutil.SecretEd25519toCurve25519(recPriv)
+		require.NoError(t, err)
+
+		recKH, err := keyio.PrivateKeyToKeysetHandle(&cryptoapi.PrivateKey{
+			PublicKey: cryptoapi.PublicKey{
+				X:     recEncPub,
+				Curve: "X25519",
+				Type:  "OKP",
+			},
+			D: recEncPriv,
+		}, ecdh.XC20P)
+		require.NoError(t, err)
+
+		recipientPacker, err := jweauthcrypt.New(&mockprovider.Provider{
+			KMSValue:        &mockkms.KeyManager{GetKeyValue: recKH},
+			CryptoValue:     c,
+			VDRegistryValue: &mockvdr.MockVDRegistry{},
+		}, jose.XC20P)
+		require.NoError(t, err)
+
+		env, err := recipientPacker.Unpack(jwe)
+		require.NoError(t, err)
+		require.Equal(t, []byte("legacy message"), env.Message)
+	})
+
+	t.Run("failure: invalid recipient key", func(t *testing.T) {
+		_, err := ToJWE(jwePacker, "", decrypted.Message, []byte(senderKID+"."+senderDIDKey),
+			[][]byte{recPub, []byte("invalid")})
+		require.ErrorIs(t, err, ErrInvalidRecipientKey)
+		require.Contains(t, err.Error(), "toJWE: recipient 2")
+	})
+
+	t.Run("failure: empty recipients", func(t *testing.T) {
+		_, err := ToJWE(jwePacker, "", decrypted.Message, []byte(senderKID+"."+senderDIDKey), nil)
+		require.EqualError(t, err, "toJWE: empty recipientPubKeys")
+	})
+
+	t.Run("failure: JWE packer is not defined", func(t *testing.T) {
+		_, err := ToJWE(nil, "", decrypted.Message, nil, [][]byte{recPub})
+		require.EqualError(t, err, "toJWE: JWE packer is not defined")
+	})
+
+	t.Run("failure: JWE packer error", func(t *testing.T) {
+		_, err := ToJWE(jwePacker, "", decrypted.Message, []byte("unknown-kid"), [][]byte{recPub})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "toJWE: authcrypt Pack")
+	})
+}
+
 func Test_getCEK(t *testing.T) {
 	k := mockkms.KeyManager{
 		GetKeyValue: nil,
@@ -697,7 +1209,7 @@ func Test_getCEK(t *testing.T) {
 		},
 	}
 
-	_, err := getCEK(recs, &k)
+	_, err := getCEK(recs, &k, BitcoinAlphabet)
 	require.EqualError(t, err, "getCEK: no key accessible none of the recipient keys were found in kms: "+
 		"[mock error]")
 }
@@ -709,3 +1221,417 @@ func Test_newCryptoBox(t *testing.T) {
 	_, err = newCryptoBox(&webkms.RemoteKMS{})
 	require.NoError(t, err)
 }
+
+func TestBase58Alphabet(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	_, senderKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, recKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	t.Run("Success: pack then unpack with Ripple alphabet", func(t *testing.T) {
+		packer := newWithKMSAndCrypto(t, testingKMS, WithBase58Alphabet(RippleAlphabet))
+		msgIn := []byte("Junky qoph-flags vext crwd zimb.")
+
+		enc, e := packer.Pack("", msgIn, senderKey, [][]byte{recKey})
+		require.NoError(t, e)
+
+		kids, e := recipientKIDs(enc)
+		require.NoError(t, e)
+		require.Equal(t, []string{RippleAlphabet.Encode(recKey)}, kids)
+		require.NotEqual(t, base58.Encode(recKey), kids[0])
+
+		env, e := packer.Unpack(enc)
+		require.NoError(t, e)
+		require.Equal(t, msgIn, env.Message)
+		require.Equal(t, senderKey, env.FromKey)
+		require.Equal(t, recKey, env.ToKey)
+
+		// a packer using the default Bitcoin alphabet can't find the recipient key
+		_, e = newWithKMSAndCrypto(t, testingKMS).Unpack(enc)
+		require.Error(t, e)
+	})
+
+	t.Run("Success: encode and decode with alternate alphabets", func(t *testing.T) {
+		for _, alphabet := range []*Base58Alphabet{BitcoinAlphabet, RippleAlphabet, FlickrAlphabet} {
+			require.Equal(t, recKey, alphabet.Decode(alphabet.Encode(recKey)))
+		}
+
+		require.Equal(t, base58.Encode(recKey), BitcoinAlphabet.Encode(recKey))
+		require.Empty(t, RippleAlphabet.Decode("0OIl"))
+	})
+
+	t.Run("Failure: invalid alphabets", func(t *testing.T) {
+		_, e := NewBase58Alphabet("123")
+		require.EqualError(t, e, "invalid base58 alphabet: expected 58 characters, got 3")
+
+		_, e = NewBase58Alphabet("1" + bitcoinAlphabet[:57])
+		require.EqualError(t, e, "invalid base58 alphabet: duplicate character '1'")
+	})
+}
+
+func TestEncryptFields(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	packer := newWithKMSAndCrypto(t, testingKMS)
+
+	key := make([]byte, chacha.KeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	payload := []byte(`{"type":"https://didcomm.org/basicmessage/1.0/message","to":"did:example:bob",` +
+		`"body":{"content":"secret","sent_time":"2019-01-15 18:42:01Z"}}`)
+
+	t.Run("Success: encrypt one field, leave another readable", func(t *testing.T) {
+		enc, e := packer.EncryptFields(payload, key, "body.content", "body.missing")
+		require.NoError(t, e)
+		require.NotContains(t, string(enc), "secret")
+
+		var msg map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(enc, &msg))
+		require.Equal(t, "did:example:bob", msg["to"])
+
+		body, ok := msg["body"].(map[string]interface{})
+		require.True(t, ok)
+		require.Equal(t, "2019-01-15 18:42:01Z", body["sent_time"])
+		require.Contains(t, body["content"], "ciphertext")
+
+		dec, e := packer.DecryptFields(enc, key, "body.content")
+		require.NoError(t, e)
+		require.JSONEq(t, string(payload), string(dec))
+	})
+
+	t.Run("Success: numbers keep their precision", func(t *testing.T) {
+		numPayload := []byte(`{"id":9007199254740993,"body":{"amount":12345678901234567890.123456789}}`)
+
+		enc, e := packer.EncryptFields(numPayload, key, "body.amount")
+		require.NoError(t, e)
+		require.Contains(t, string(enc), `"id":9007199254740993`)
+
+		dec, e := packer.DecryptFields(enc, key, "body.amount")
+		require.NoError(t, e)
+		require.Equal(t, `{"body":{"amount":12345678901234567890.123456789},"id":9007199254740993}`, string(dec))
+	})
+
+	t.Run("Failure: encrypted field moved to another path", func(t *testing.T) {
+		enc, e := packer.EncryptFields(payload, key, "body.content")
+		require.NoError(t, e)
+
+		var msg map[string]interface{}
+
+		require.NoError(t, json.Unmarshal(enc, &msg))
+
+		msg["to"] = msg["body"].(map[string]interface{})["content"]
+		moved, e := json.Marshal(msg)
+		require.NoError(t, e)
+
+		_, e = packer.DecryptFields(moved, key, "to")
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "fields: field to: failed to decrypt")
+	})
+
+	t.Run("Failure: decrypt with another key or a cleartext field", func(t *testing.T) {
+		enc, e := packer.EncryptFields(payload, key, "body.content")
+		require.NoError(t, e)
+
+		_, e = packer.DecryptFields(enc, make([]byte, chacha.KeySize), "body.content")
+		require.Error(t, e)
+
+		_, e = packer.DecryptFields(enc, key, "to")
+		require.EqualError(t, e, "fields: field to: field is not encrypted")
+	})
+
+	t.Run("Failure: invalid input", func(t *testing.T) {
+		_, e := packer.EncryptFields(payload, []byte("short key"), "to")
+		require.Error(t, e)
+
+		_, e = packer.EncryptFields([]byte(`["not an object"]`), key, "to")
+		require.Error(t, e)
+		require.Contains(t, e.Error(), "fields: payload is not a JSON object")
+
+		failPacker := newWithKMSAndCrypto(t, testingKMS)
+		failPacker.randSource = newFailReader(0, rand.Reader)
+
+		_, e = failPacker.EncryptFields(payload, key, "to")
+		require.EqualError(t, e, "fields: field to: failed to generate random nonce: "+
+			"mock Reader has failed intentionally")
+	})
+}
+
+func TestWithPadding(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	_, senderKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, recKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	const blockSize = 64
+
+	packer := newWithKMSAndCrypto(t, testingKMS, WithPadding(blockSize))
+
+	t.Run("Success: ciphertext length is a multiple of the block size", func(t *testing.T) {
+		for _, msgIn := range [][]byte{
+			{},
+			[]byte("Junky qoph-flags vext crwd zimb."),
+			bytes.Repeat([]byte{'a'}, blockSize-paddingLengthSize),
+			bytes.Repeat([]byte{'b'}, blockSize),
+			bytes.Repeat([]byte{0}, 3*blockSize+1),
+		} {
+			enc, e := packer.Pack("", msgIn, senderKey, [][]byte{recKey})
+			require.NoError(t, e)
+
+			var env legacyEnvelope
+
+			require.NoError(t, json.Unmarshal(enc, &env))
+
+			cipherText, e := base64.URLEncoding.DecodeString(env.CipherText)
+			require.NoError(t, e)
+			require.NotEmpty(t, cipherText)
+			require.Zero(t, len(cipherText)%blockSize)
+			require.Greater(t, len(cipherText), len(msgIn))
+
+			dec, e := packer.Unpack(enc)
+			require.NoError(t, e)
+			require.Equal(t, msgIn, dec.Message)
+		}
+	})
+
+	t.Run("Success: messages of close sizes have the same envelope size", func(t *testing.T) {
+		enc1, e := packer.Pack("", []byte("short"), senderKey, [][]byte{recKey})
+		require.NoError(t, e)
+
+		enc2, e := packer.Pack("", []byte("a bit longer"), senderKey, [][]byte{recKey})
+		require.NoError(t, e)
+
+		require.Equal(t, len(enc1), len(enc2))
+	})
+
+	t.Run("Failure: invalid block size", func(t *testing.T) {
+		_, e := newWithKMSAndCrypto(t, testingKMS, WithPadding(-1)).Pack("", []byte("msg"), senderKey,
+			[][]byte{recKey})
+		require.EqualError(t, e, "pack: invalid padding block size -1")
+	})
+
+	t.Run("Success: padding is removed as marked in the envelope", func(t *testing.T) {
+		notPaddingPacker := newWithKMSAndCrypto(t, testingKMS)
+
+		enc, e := packer.Pack("", []byte("msg"), senderKey, [][]byte{recKey})
+		require.NoError(t, e)
+
+		var env legacyEnvelope
+
+		require.NoError(t, json.Unmarshal(enc, &env))
+
+		protectedBytes, e := base64.URLEncoding.DecodeString(env.Protected)
+		require.NoError(t, e)
+		require.Contains(t, string(protectedBytes), `"enc":"chacha20poly1305_ietf+pad"`)
+		require.NoError(t, ValidateProtected(enc))
+
+		dec, e := notPaddingPacker.Unpack(enc)
+		require.NoError(t, e)
+		require.Equal(t, []byte("msg"), dec.Message)
+
+		// not padded envelope is unpacked as is by the packer with padding option
+		enc, e = notPaddingPacker.Pack("", []byte("msg"), senderKey, [][]byte{recKey})
+		require.NoError(t, e)
+
+		dec, e = packer.Unpack(enc)
+		require.NoError(t, e)
+		require.Equal(t, []byte("msg"), dec.Message)
+	})
+}
+
+func TestWithEphemeralSenderKeys(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	_, senderKey, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, rec1Key, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	_, rec2Key, err := testingKMS.CreateAndExportPubKeyBytes(kms.ED25519Type)
+	require.NoError(t, err)
+
+	packer := newWithKMSAndCrypto(t, testingKMS, WithEphemeralSenderKeys())
+
+	recipientsOf := func(t *testing.T, enc []byte) (*legacyEnvelope, *protected) {
+		t.Helper()
+
+		var env legacyEnvelope
+
+		require.NoError(t, json.Unmarshal(enc, &env))
+
+		protectedBytes, err := base64.URLEncoding.DecodeString(env.Protected)
+		require.NoError(t, err)
+
+		var header protected
+
+		require.NoError(t, json.Unmarshal(protectedBytes, &header))
+
+		return &env, &header
+	}
+
+	msgIn := []byte("Junky qoph-flags vext crwd zimb.")
+
+	t.Run("Success: round trip with ephemeral sender keys", func(t *testing.T) {
+		enc, err := packer.Pack("", msgIn, senderKey, [][]byte{rec1Key, rec2Key})
+		require.NoError(t, err)
+
+		_, header := recipientsOf(t, enc)
+		require.Len(t, header.Recipients, 2)
+
+		epk := header.Recipients[0].Header.EPK
+		require.NotEmpty(t, epk)
+		require.Len(t, base58.Decode(epk), 32)
+		require.Equal(t, epk, header.Recipients[1].Header.EPK)
+
+		// a Packer without the option unpacks the envelope
+		dec, err := newWithKMSAndCrypto(t, testingKMS).Unpack(enc)
+		require.NoError(t, err)
+		require.Equal(t, msgIn, dec.Message)
+		require.Equal(t, senderKey, dec.FromKey)
+
+		// each envelope has its own ephemeral key
+		enc2, err := packer.Pack("", msgIn, senderKey, [][]byte{rec1Key})
+		require.NoError(t, err)
+
+		_, header2 := recipientsOf(t, enc2)
+		require.NotEqual(t, epk, header2.Recipients[0].Header.EPK)
+
+		dec, err = packer.Unpack(enc2)
+		require.NoError(t, err)
+		require.Equal(t, msgIn, dec.Message)
+	})
+
+	t.Run("Success: no ephemeral key by default", func(t *testing.T) {
+		enc, err := newWithKMSAndCrypto(t, testingKMS).Pack("", msgIn, senderKey, [][]byte{rec1Key})
+		require.NoError(t, err)
+
+		_, header := recipientsOf(t, enc)
+		require.Empty(t, header.Recipients[0].Header.EPK)
+	})
+
+	t.Run("Failure: the static sender key alone does not reveal the CEK", func(t *testing.T) {
+		enc, err := packer.Pack("", msgIn, senderKey, [][]byte{rec1Key})
+		require.NoError(t, err)
+
+		env, header := recipientsOf(t, enc)
+
+		_, otherEPK, err := box.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		for epk, expectedErr := range map[string]string{
+			"":                                 "chacha20poly1305: message authentication failed",
+			base58.Encode(otherEPK[:]):         "failed to decrypt CEK with ephemeral key: failed to unpack",
+			base58.Encode([]byte("too short")): "invalid ephemeral key size 9",
+		} {
+			header.Recipients[0].Header.EPK = epk
+
+			protectedBytes, err := json.Marshal(header)
+			require.NoError(t, err)
+
+			env.Protected = base64.URLEncoding.EncodeToString(protectedBytes)
+
+			tampered, err := json.Marshal(env)
+			require.NoError(t, err)
+
+			_, err = packer.Unpack(tampered)
+			require.EqualError(t, err, expectedErr)
+		}
+	})
+}
+
+func TestContentCipher(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	recKey := createKey(t, testingKMS)
+
+	msgIn := []byte("Sphinx of black quartz, judge my vow.")
+
+	for _, tc := range []struct {
+		contentCipher ContentCipher
+		nonceSize     int
+	}{
+		{C20P, chacha.NonceSize},
+		{XC20P, chacha.NonceSizeX},
+	} {
+		t.Run(string(tc.contentCipher), func(t *testing.T) {
+			packer := newWithKMSAndCrypto(t, testingKMS, WithContentCipher(tc.contentCipher))
+
+			enc, err := packer.Pack("", msgIn, senderKey, [][]byte{recKey})
+			require.NoError(t, err)
+
+			var env legacyEnvelope
+			require.NoError(t, json.Unmarshal(enc, &env))
+
+			nonce, err := base64.URLEncoding.DecodeString(env.IV)
+			require.NoError(t, err)
+			require.Len(t, nonce, tc.nonceSize)
+
+			protectedBytes, err := base64.URLEncoding.DecodeString(env.Protected)
+			require.NoError(t, err)
+
+			var header protected
+			require.NoError(t, json.Unmarshal(protectedBytes, &header))
+			require.Equal(t, string(tc.contentCipher), header.Enc)
+
+			// the cipher is picked from the envelope
+			dec, err := newWithKMSAndCrypto(t, testingKMS).Unpack(enc)
+			require.NoError(t, err)
+			require.Equal(t, msgIn, dec.Message)
+		})
+	}
+
+	t.Run("unsupported content encryption", func(t *testing.T) {
+		packer := newWithKMSAndCrypto(t, testingKMS, WithContentCipher("A256GCM"))
+
+		_, err := packer.Pack("", msgIn, senderKey, [][]byte{recKey})
+		require.EqualError(t, err, "content encryption A256GCM not supported")
+	})
+}
+
+// constReader is a deterministic randomness source.
+type constReader byte
+
+func (r constReader) Read(out []byte) (int, error) {
+	for i := range out {
+		out[i] = byte(r)
+	}
+
+	return len(out), nil
+}
+
+func TestWithRandSource(t *testing.T) {
+	testingKMS, _ := newKMS(t)
+	senderKey := createKey(t, testingKMS)
+	recKey := createKey(t, testingKMS)
+
+	msgIn := []byte("How vexingly quick daft zebras jump!")
+
+	pack := func(opts ...Opt) []byte {
+		packer := newWithKMSAndCrypto(t, testingKMS, append([]Opt{WithRandSource(constReader(7))}, opts...)...)
+
+		enc, err := packer.Pack("", msgIn, senderKey, [][]byte{recKey})
+		require.NoError(t, err)
+
+		return enc
+	}
+
+	for name, opts := range map[string][]Opt{
+		"default":                   nil,
+		"XC20P":                     {WithContentCipher(XC20P)},
+		"ephemeral sender keys":     {WithEphemeralSenderKeys()},
+		"padding and ephemeral key": {WithPadding(64), WithEphemeralSenderKeys()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			enc := pack(opts...)
+			require.Equal(t, string(enc), string(pack(opts...)))
+
+			dec, err := newWithKMSAndCrypto(t, testingKMS, opts...).Unpack(enc)
+			require.NoError(t, err)
+			require.Equal(t, msgIn, dec.Message)
+		})
+	}
+}
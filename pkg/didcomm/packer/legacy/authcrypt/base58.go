@@ -0,0 +1,98 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+)
+
+const (
+	bitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	rippleAlphabet  = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+	flickrAlphabet  = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+
+	base58AlphabetSize = 58
+)
+
+var (
+	// BitcoinAlphabet is the base58 alphabet used by Bitcoin, the default alphabet of KIDs in legacy envelopes.
+	BitcoinAlphabet = mustBase58Alphabet(bitcoinAlphabet)
+	// RippleAlphabet is the base58 alphabet used by Ripple.
+	RippleAlphabet = mustBase58Alphabet(rippleAlphabet)
+	// FlickrAlphabet is the base58 alphabet used by Flickr short URLs.
+	FlickrAlphabet = mustBase58Alphabet(flickrAlphabet)
+)
+
+// Base58Alphabet is a base58 alphabet used to encode and decode the keys in legacy envelopes.
+type Base58Alphabet struct {
+	chars       string
+	fromBitcoin *strings.Replacer
+	toBitcoin   *strings.Replacer
+}
+
+// NewBase58Alphabet creates a Base58Alphabet from the given 58 distinct ASCII characters,
+// ordered by the digit value they encode.
+func NewBase58Alphabet(alphabet string) (*Base58Alphabet, error) {
+	if len(alphabet) != base58AlphabetSize {
+		return nil, fmt.Errorf("invalid base58 alphabet: expected %d characters, got %d",
+			base58AlphabetSize, len(alphabet))
+	}
+
+	fromBitcoin := make([]string, 0, 2*base58AlphabetSize)
+	toBitcoin := make([]string, 0, 2*base58AlphabetSize)
+	seen := make(map[rune]struct{}, base58AlphabetSize)
+
+	for i, c := range alphabet {
+		if c > 0x7f {
+			return nil, fmt.Errorf("invalid base58 alphabet: non-ASCII character %q", c)
+		}
+
+		if _, ok := seen[c]; ok {
+			return nil, fmt.Errorf("invalid base58 alphabet: duplicate character %q", c)
+		}
+
+		seen[c] = struct{}{}
+
+		fromBitcoin = append(fromBitcoin, bitcoinAlphabet[i:i+1], string(c))
+		toBitcoin = append(toBitcoin, string(c), bitcoinAlphabet[i:i+1])
+	}
+
+	return &Base58Alphabet{
+		chars:       alphabet,
+		fromBitcoin: strings.NewReplacer(fromBitcoin...),
+		toBitcoin:   strings.NewReplacer(toBitcoin...),
+	}, nil
+}
+
+func mustBase58Alphabet(alphabet string) *Base58Alphabet {
+	a, err := NewBase58Alphabet(alphabet)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// Encode encodes b to a base58 string using the alphabet.
+func (a *Base58Alphabet) Encode(b []byte) string {
+	return a.fromBitcoin.Replace(base58.Encode(b))
+}
+
+// Decode decodes the base58 string s using the alphabet. As with btcutil base58, an invalid string decodes
+// to an empty slice.
+func (a *Base58Alphabet) Decode(s string) []byte {
+	for _, c := range s {
+		if !strings.ContainsRune(a.chars, c) {
+			return []byte("")
+		}
+	}
+
+	return base58.Decode(a.toBitcoin.Replace(s))
+}
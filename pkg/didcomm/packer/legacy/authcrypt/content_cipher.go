@@ -0,0 +1,61 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	chacha "golang.org/x/crypto/chacha20poly1305"
+)
+
+// ContentCipher is the payload encryption algorithm of legacy envelopes, as in the `enc` field of the protected header.
+type ContentCipher string
+
+const (
+	// C20P is ChaCha20-Poly1305 with a 12 bytes nonce, used by default.
+	C20P ContentCipher = "chacha20poly1305_ietf"
+
+	// XC20P is XChaCha20-Poly1305 with a 24 bytes nonce, which is safe to generate at random for large volumes of
+	// messages encrypted with the same key.
+	XC20P ContentCipher = "xchacha20poly1305_ietf"
+)
+
+// WithContentCipher sets the payload encryption algorithm of packed envelopes, C20P by default.
+// Unpack picks the algorithm from the `enc` field of the envelope, so it handles envelopes of both algorithms
+// without the option. Envelopes packed by EncryptAnon always use C20P, as expected by legacy anoncrypt.
+func WithContentCipher(contentCipher ContentCipher) Opt {
+	return func(p *Packer) {
+		p.contentCipher = contentCipher
+	}
+}
+
+// nonceSize returns the payload nonce size of the content cipher.
+func (c ContentCipher) nonceSize() int {
+	if c == XC20P {
+		return chacha.NonceSizeX
+	}
+
+	return chacha.NonceSize
+}
+
+// newContentAEAD returns the payload AEAD for the `enc` field of the envelope and the size of the envelope nonce.
+func newContentAEAD(enc string, nonceSize int, cek []byte) (cipher.AEAD, error) {
+	switch ContentCipher(enc) {
+	case C20P:
+		return chacha.New(cek)
+	case XC20P:
+		// early legacy envelopes are labelled xchacha20poly1305_ietf while encrypted with a 12 bytes nonce
+		if nonceSize == chacha.NonceSize {
+			return chacha.New(cek)
+		}
+
+		return chacha.NewX(cek)
+	default:
+		return nil, fmt.Errorf("content encryption %s not supported", enc)
+	}
+}
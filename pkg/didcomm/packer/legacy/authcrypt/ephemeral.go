@@ -0,0 +1,84 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/nacl/box"
+
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/kms"
+)
+
+// WithEphemeralSenderKeys makes Pack wrap the CEK of each envelope with a fresh ephemeral X25519 sender key pair in
+// addition to the static sender key, giving forward secrecy to envelopes of long-lived channels: once the ephemeral
+// private key is discarded, compromise of the static sender key does not reveal the CEK of past envelopes.
+//
+// The CEK is first encrypted (crypto_box) from the ephemeral key to the recipient key, then the result is encrypted
+// from the static sender key to the recipient key as usual, so the sender is still authenticated. The ephemeral
+// public key is put into the "epk" field of each recipient header; Unpack handles such envelopes without the option.
+func WithEphemeralSenderKeys() Opt {
+	return func(p *Packer) {
+		p.ephemeralSenderKeys = true
+	}
+}
+
+// ephemeralKey is an X25519 key pair generated for a single envelope.
+type ephemeralKey struct {
+	pub  *[cryptoutil.Curve25519KeySize]byte
+	priv *[cryptoutil.Curve25519KeySize]byte
+}
+
+func newEphemeralKey(randSource io.Reader) (*ephemeralKey, error) {
+	pub, priv, err := box.GenerateKey(randSource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ephemeral key: %w", err)
+	}
+
+	return &ephemeralKey{pub: pub, priv: priv}, nil
+}
+
+// wipe zeroes the private key, so that the CEK wrapped with it can't be recovered afterwards.
+func (k *ephemeralKey) wipe() {
+	for i := range k.priv {
+		k.priv[i] = 0
+	}
+}
+
+// seal encrypts the CEK from the ephemeral key to the recipient Curve25519 key recEncKey.
+func (k *ephemeralKey) seal(cek, nonce, recEncKey []byte) ([]byte, error) {
+	var (
+		n      [cryptoutil.NonceSize]byte
+		recPub [cryptoutil.Curve25519KeySize]byte
+	)
+
+	if len(nonce) != len(n) || len(recEncKey) != len(recPub) {
+		return nil, errors.New("invalid nonce or recipient key size")
+	}
+
+	copy(n[:], nonce)
+	copy(recPub[:], recEncKey)
+
+	return box.Seal(nil, cek, &n, &recPub, k.priv), nil
+}
+
+// openEphemeralCEK decrypts the CEK encrypted from the ephemeral key epk to the recipient key recKey held by the KMS.
+func openEphemeralCEK(b kms.CryptoBox, encCEK, nonce, epk, recKey []byte) ([]byte, error) {
+	if len(epk) != cryptoutil.Curve25519KeySize {
+		return nil, fmt.Errorf("invalid ephemeral key size %d", len(epk))
+	}
+
+	cek, err := b.EasyOpen(encCEK, nonce, epk, recKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt CEK with ephemeral key: %w", err)
+	}
+
+	return cek, nil
+}
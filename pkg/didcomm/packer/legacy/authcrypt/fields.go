@@ -0,0 +1,160 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	chacha "golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/poly1305"
+)
+
+// encryptedField is the value of a payload field encrypted by EncryptFields.
+type encryptedField struct {
+	IV         string `json:"iv"`
+	CipherText string `json:"ciphertext"`
+	Tag        string `json:"tag"`
+}
+
+// EncryptFields encrypts the values of the given fields of the JSON object payload with key (a chacha20poly1305
+// key), leaving the other fields in cleartext, e.g. to keep routing information of a structured message readable.
+// Field paths are dot separated names of nested fields, e.g. "body.ssn". Each field is encrypted with its own
+// nonce and its path as additional data, so that an encrypted value can't be moved to another field.
+// Paths which are not found in payload are skipped.
+func (p *Packer) EncryptFields(payload, key []byte, paths ...string) ([]byte, error) {
+	return transformFields(payload, key, paths, p.encryptField)
+}
+
+// DecryptFields decrypts the fields of the JSON object payload encrypted by EncryptFields with the same key and paths.
+func (p *Packer) DecryptFields(payload, key []byte, paths ...string) ([]byte, error) {
+	return transformFields(payload, key, paths, decryptField)
+}
+
+type fieldTransform func(aead cipher.AEAD, path string, value interface{}) (interface{}, error)
+
+func transformFields(payload, key []byte, paths []string, transform fieldTransform) ([]byte, error) {
+	aead, err := chacha.New(key)
+	if err != nil {
+		return nil, fmt.Errorf("fields: %w", err)
+	}
+
+	var msg map[string]interface{}
+
+	// numbers are kept as json.Number not to lose the precision of the cleartext fields.
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&msg); err != nil {
+		return nil, fmt.Errorf("fields: payload is not a JSON object: %w", err)
+	}
+
+	for _, path := range paths {
+		parent, name, found := lookupField(msg, path)
+		if !found {
+			continue
+		}
+
+		parent[name], err = transform(aead, path, parent[name])
+		if err != nil {
+			return nil, fmt.Errorf("fields: field %s: %w", path, err)
+		}
+	}
+
+	return json.Marshal(msg)
+}
+
+// lookupField returns the object containing the field at the dot separated path, and the name of the field.
+func lookupField(msg map[string]interface{}, path string) (map[string]interface{}, string, bool) {
+	names := strings.Split(path, ".")
+	parent := msg
+
+	for _, name := range names[:len(names)-1] {
+		child, ok := parent[name].(map[string]interface{})
+		if !ok {
+			return nil, "", false
+		}
+
+		parent = child
+	}
+
+	name := names[len(names)-1]
+
+	_, ok := parent[name]
+
+	return parent, name, ok
+}
+
+func (p *Packer) encryptField(aead cipher.AEAD, path string, value interface{}) (interface{}, error) {
+	plainText, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, chacha.NonceSize)
+
+	if _, err = p.randSource.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate random nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nil, nonce, plainText, []byte(path))
+
+	return &encryptedField{
+		IV:         base64.URLEncoding.EncodeToString(nonce),
+		CipherText: base64.URLEncoding.EncodeToString(sealed[:len(sealed)-poly1305.TagSize]),
+		Tag:        base64.URLEncoding.EncodeToString(sealed[len(sealed)-poly1305.TagSize:]),
+	}, nil
+}
+
+func decryptField(aead cipher.AEAD, path string, value interface{}) (interface{}, error) {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var field encryptedField
+
+	if err = json.Unmarshal(valueBytes, &field); err != nil || field.IV == "" || field.Tag == "" {
+		return nil, errors.New("field is not encrypted")
+	}
+
+	nonce, err := base64.URLEncoding.DecodeString(field.IV)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText, err := base64.URLEncoding.DecodeString(field.CipherText)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := base64.URLEncoding.DecodeString(field.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nonce) != chacha.NonceSize {
+		return nil, fmt.Errorf("invalid nonce size %d", len(nonce))
+	}
+
+	plainText, err := aead.Open(nil, nonce, append(cipherText, tag...), []byte(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	if !json.Valid(plainText) {
+		return nil, errors.New("decrypted value is not valid JSON")
+	}
+
+	// the decrypted value is kept as is, not to lose the precision of its numbers.
+	return json.RawMessage(plainText), nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cryptoapi "github.com/hyperledger/aries-framework-go/pkg/crypto"
+	"github.com/hyperledger/aries-framework-go/pkg/didcomm/packer"
+	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
+)
+
+// ToJWE re-encodes payload, as decrypted from a legacy (RFC 0019) envelope, into a JWE envelope packed by jwePacker
+// (e.g. a DIDComm V2 authcrypt or anoncrypt Packer) for the given legacy recipient keys. It is meant to migrate
+// legacy messages to JWE envelopes.
+//
+// The Ed25519 recipient keys are converted to X25519 keys the same way legacy Pack does, and referenced in the JWE by
+// their X25519 did:key, so that each recipient opens the JWE with the X25519 counterpart of its legacy key.
+// sender is the sender key ID as expected by jwePacker.Pack.
+func ToJWE(jwePacker packer.Packer, contentType string, payload, sender []byte,
+	recipientPubKeys [][]byte) ([]byte, error) {
+	if jwePacker == nil {
+		return nil, errors.New("toJWE: JWE packer is not defined")
+	}
+
+	if len(recipientPubKeys) == 0 {
+		return nil, errors.New("toJWE: empty recipientPubKeys")
+	}
+
+	recKeys := make([][]byte, 0, len(recipientPubKeys))
+
+	for i, recKey := range recipientPubKeys {
+		mRecKey, err := x25519RecipientKey(recKey)
+		if err != nil {
+			return nil, fmt.Errorf("toJWE: recipient %d: %w", i+1, err)
+		}
+
+		recKeys = append(recKeys, mRecKey)
+	}
+
+	jwe, err := jwePacker.Pack(contentType, payload, sender, recKeys)
+	if err != nil {
+		return nil, fmt.Errorf("toJWE: %w", err)
+	}
+
+	return jwe, nil
+}
+
+// x25519RecipientKey converts an Ed25519 recipient key into a marshalled X25519 cryptoapi.PublicKey, as expected by
+// JWE packers, with the X25519 did:key of the key as KID.
+func x25519RecipientKey(recKey []byte) ([]byte, error) {
+	recEncKey, err := cryptoutil.PublicEd25519toCurve25519(recKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to convert public Ed25519 to Curve25519: %v", ErrInvalidRecipientKey, err)
+	}
+
+	didKey, _ := fingerprint.CreateDIDKeyByCode(fingerprint.X25519PubKeyMultiCodec, recEncKey)
+
+	return json.Marshal(&cryptoapi.PublicKey{
+		KID:   didKey,
+		X:     recEncKey,
+		Curve: "X25519",
+		Type:  "OKP",
+	})
+}
@@ -12,7 +12,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/btcsuite/btcutil/base58"
 	chacha "golang.org/x/crypto/chacha20poly1305"
 	"golang.org/x/crypto/poly1305"
 
@@ -36,7 +35,14 @@ func (p *Packer) Pack(_ string, payload, sender []byte, recipientPubKeys [][]byt
 		return nil, errors.New("empty recipients keys, must have at least one recipient")
 	}
 
-	nonce := make([]byte, chacha.NonceSize)
+	if p.paddingBlockSize != 0 {
+		payload, err = pad(payload, p.paddingBlockSize)
+		if err != nil {
+			return nil, fmt.Errorf("pack: %w", err)
+		}
+	}
+
+	nonce := make([]byte, p.contentCipher.nonceSize())
 
 	_, err = p.randSource.Read(nonce)
 	if err != nil {
@@ -51,20 +57,78 @@ func (p *Packer) Pack(_ string, payload, sender []byte, recipientPubKeys [][]byt
 		return nil, fmt.Errorf("pack: failed to generate cek: %w", err)
 	}
 
+	var eph *ephemeralKey
+
+	if p.ephemeralSenderKeys {
+		eph, err = newEphemeralKey(p.randSource)
+		if err != nil {
+			return nil, fmt.Errorf("pack: %w", err)
+		}
+
+		defer eph.wipe()
+	}
+
 	var recipients []recipient
 
-	recipients, err = p.buildRecipients(cek, sender, recipientPubKeys)
+	recipients, err = p.buildRecipients(cek, eph, sender, recipientPubKeys)
 	if err != nil {
 		return nil, fmt.Errorf("pack: failed to build recipients: %w", err)
 	}
 
 	header := protected{
-		Enc:        "chacha20poly1305_ietf",
+		Enc:        string(p.contentCipher),
 		Typ:        encodingType,
 		Alg:        "Authcrypt",
 		Recipients: recipients,
 	}
 
+	if p.paddingBlockSize != 0 {
+		header.Enc += paddedEncSuffix
+	}
+
+	return p.buildEnvelope(nonce, payload, cek[:], &header)
+}
+
+// EncryptAnon encodes payload for recipientPubKeys without an authenticated sender (Anoncrypt of Aries RFC 0019).
+// The CEK is wrapped for each recipient in a sealed box, and recipient headers have no sender and iv fields, as in
+// envelopes of the legacy anoncrypt Packer. Padding and ephemeral sender keys options don't apply to such envelopes.
+func (p *Packer) EncryptAnon(payload []byte, recipientPubKeys [][]byte) ([]byte, error) {
+	if len(recipientPubKeys) == 0 {
+		return nil, errors.New("encryptAnon: empty recipients keys, must have at least one recipient")
+	}
+
+	nonce := make([]byte, chacha.NonceSize)
+
+	_, err := p.randSource.Read(nonce)
+	if err != nil {
+		return nil, fmt.Errorf("encryptAnon: failed to generate random nonce: %w", err)
+	}
+
+	cek := &[chacha.KeySize]byte{}
+
+	_, err = p.randSource.Read(cek[:])
+	if err != nil {
+		return nil, fmt.Errorf("encryptAnon: failed to generate cek: %w", err)
+	}
+
+	recipients := make([]recipient, 0, len(recipientPubKeys))
+
+	for i, recKey := range recipientPubKeys {
+		rec, e := p.buildAnonRecipient(i, cek, recKey)
+		if e != nil {
+			return nil, fmt.Errorf("encryptAnon: %w", e)
+		}
+
+		recipients = append(recipients, *rec)
+	}
+
+	header := protected{
+		Enc:        string(C20P),
+		Typ:        encodingType,
+		Alg:        "Anoncrypt",
+		Recipients: recipients,
+	}
+
 	return p.buildEnvelope(nonce, payload, cek[:], &header)
 }
 
@@ -76,7 +140,9 @@ func (p *Packer) buildEnvelope(nonce, payload, cek []byte, header *protected) ([
 
 	protectedB64 := base64.URLEncoding.EncodeToString(protectedBytes)
 
-	chachaCipher, err := chacha.New(cek)
+	enc, _ := contentEncryption(header.Enc)
+
+	chachaCipher, err := newContentAEAD(enc, len(nonce), cek)
 	if err != nil {
 		return nil, err
 	}
@@ -105,13 +171,24 @@ func (p *Packer) buildEnvelope(nonce, payload, cek []byte, header *protected) ([
 	return out, nil
 }
 
-func (p *Packer) buildRecipients(cek *[chacha.KeySize]byte, senderKey []byte, recPubKeys [][]byte) ([]recipient, error) { // nolint: lll
-	encodedRecipients := make([]recipient, 0)
-
-	for _, recKey := range recPubKeys {
-		rec, err := p.buildRecipient(cek, senderKey, recKey)
+// buildRecipients encodes recipients of the envelope, in the order of recPubKeys. Decryptors may rely on this
+// order for indexing of recipients. A recipient key that is not a valid Ed25519 public key fails the whole call
+// with ErrInvalidRecipientKey. Any other error of a recipient (failure to generate a nonce, to create the sender
+// KID or the CryptoBox, or to encrypt the CEK or the sender key) is logged and the recipient is skipped, without
+// reordering the others. The call fails if all the recipients are skipped.
+// If eph is not nil, the CEK is also wrapped with the ephemeral sender key.
+func (p *Packer) buildRecipients(cek *[chacha.KeySize]byte, eph *ephemeralKey, senderKey []byte,
+	recPubKeys [][]byte) ([]recipient, error) {
+	encodedRecipients := make([]recipient, 0, len(recPubKeys))
+
+	for i, recKey := range recPubKeys {
+		rec, err := p.buildRecipient(i, cek, eph, senderKey, recKey)
 		if err != nil {
-			logger.Warnf("buildRecipients: failed to build recipient: %w", err)
+			if errors.Is(err, ErrInvalidRecipientKey) {
+				return nil, err
+			}
+
+			logger.Warnf("buildRecipients: failed to build recipient: %v", err)
 
 			continue
 		}
@@ -128,7 +205,8 @@ func (p *Packer) buildRecipients(cek *[chacha.KeySize]byte, senderKey []byte, re
 
 // buildRecipient encodes the necessary data for the recipient to decrypt the message
 // encrypting the CEK and sender Pub key.
-func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []byte) (*recipient, error) { // nolint: lll
+func (p *Packer) buildRecipient(idx int, cek *[chacha.KeySize]byte, eph *ephemeralKey,
+	senderKey, recKey []byte) (*recipient, error) {
 	var nonce [24]byte
 
 	_, err := p.randSource.Read(nonce[:])
@@ -143,7 +221,8 @@ func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []b
 
 	recEncKey, err := cryptoutil.PublicEd25519toCurve25519(recKey)
 	if err != nil {
-		return nil, fmt.Errorf("buildRecipient: failed to convert public Ed25519 to Curve25519: %w", err)
+		return nil, fmt.Errorf("buildRecipient: %w at index %d: failed to convert public Ed25519 to Curve25519: %v",
+			ErrInvalidRecipientKey, idx, err)
 	}
 
 	box, err := newCryptoBox(p.kms)
@@ -151,24 +230,65 @@ func (p *Packer) buildRecipient(cek *[chacha.KeySize]byte, senderKey, recKey []b
 		return nil, fmt.Errorf("buildRecipient: failed to create new CryptoBox: %w", err)
 	}
 
-	encCEK, err := box.Easy(cek[:], nonce[:], recEncKey, senderKID)
+	cekData := cek[:]
+
+	if eph != nil {
+		cekData, err = eph.seal(cekData, nonce[:], recEncKey)
+		if err != nil {
+			return nil, fmt.Errorf("buildRecipient: failed to encrypt cek with ephemeral key: %w", err)
+		}
+	}
+
+	encCEK, err := box.Easy(cekData, nonce[:], recEncKey, senderKID)
 	if err != nil {
 		return nil, fmt.Errorf("buildRecipient: failed to encrypt cek: %w", err)
 	}
 
 	// assumption: senderKey is ed25519
-	encSender, err := box.Seal([]byte(base58.Encode(senderKey)), recEncKey, p.randSource)
+	encSender, err := box.Seal([]byte(p.alphabet.Encode(senderKey)), recEncKey, p.randSource)
 	if err != nil {
 		return nil, fmt.Errorf("buildRecipient: failed to encrypt sender key: %w", err)
 	}
 
-	return &recipient{
+	rec := &recipient{
 		EncryptedKey: base64.URLEncoding.EncodeToString(encCEK),
 		Header: recipientHeader{
-			KID:    base58.Encode(recKey), // recKey is the Ed25519 recipient pk in b58 encoding
+			KID:    p.alphabet.Encode(recKey), // recKey is the Ed25519 recipient pk in b58 encoding
 			Sender: base64.URLEncoding.EncodeToString(encSender),
 			IV:     base64.URLEncoding.EncodeToString(nonce[:]),
 		},
+	}
+
+	if eph != nil {
+		rec.Header.EPK = p.alphabet.Encode(eph.pub[:])
+	}
+
+	return rec, nil
+}
+
+// buildAnonRecipient encrypts the CEK for the recipient in a sealed box, which doesn't reveal the sender.
+func (p *Packer) buildAnonRecipient(idx int, cek *[chacha.KeySize]byte, recKey []byte) (*recipient, error) {
+	recEncKey, err := cryptoutil.PublicEd25519toCurve25519(recKey)
+	if err != nil {
+		return nil, fmt.Errorf("buildAnonRecipient: %w at index %d: failed to convert public Ed25519 to Curve25519: %v",
+			ErrInvalidRecipientKey, idx, err)
+	}
+
+	box, err := newCryptoBox(p.kms)
+	if err != nil {
+		return nil, fmt.Errorf("buildAnonRecipient: failed to create new CryptoBox: %w", err)
+	}
+
+	encCEK, err := box.Seal(cek[:], recEncKey, p.randSource)
+	if err != nil {
+		return nil, fmt.Errorf("buildAnonRecipient: failed to encrypt cek: %w", err)
+	}
+
+	return &recipient{
+		EncryptedKey: base64.URLEncoding.EncodeToString(encCEK),
+		Header: recipientHeader{
+			KID: p.alphabet.Encode(recKey),
+		},
 	}, nil
 }
 
@@ -0,0 +1,78 @@
+/*
+Copyright Gen Digital Inc. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package authcrypt
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// paddingLengthSize is the size of the big-endian length prefix of a padded plaintext.
+	paddingLengthSize = 4
+
+	// paddedEncSuffix marks the `enc` field of the protected header of envelopes with padded plaintext,
+	// e.g. "chacha20poly1305_ietf+pad". Unpackers unaware of padding reject such envelopes as of unsupported
+	// encryption rather than return the padded plaintext.
+	paddedEncSuffix = "+pad"
+)
+
+// WithPadding pads the plaintext to a multiple of blockSize before encryption, so that the envelope size does not
+// reveal the exact length of the payload. The padded plaintext is the payload prefixed by its 4 bytes big-endian
+// length and followed by zero bytes. Padding is marked in the `enc` field of the protected header, so Unpack
+// removes it whatever the options of the unpacking Packer are.
+func WithPadding(blockSize int) Opt {
+	return func(p *Packer) {
+		p.paddingBlockSize = blockSize
+	}
+}
+
+func pad(payload []byte, blockSize int) ([]byte, error) {
+	if blockSize <= 0 {
+		return nil, fmt.Errorf("invalid padding block size %d", blockSize)
+	}
+
+	if uint64(len(payload)) > uint64(^uint32(0)) {
+		return nil, errors.New("payload is too large to be padded")
+	}
+
+	size := paddingLengthSize + len(payload)
+	if rem := size % blockSize; rem != 0 {
+		size += blockSize - rem
+	}
+
+	padded := make([]byte, size)
+
+	binary.BigEndian.PutUint32(padded, uint32(len(payload)))
+	copy(padded[paddingLengthSize:], payload)
+
+	return padded, nil
+}
+
+// contentEncryption splits the `enc` field of the protected header into the content cipher and the padding marker.
+func contentEncryption(enc string) (string, bool) {
+	if strings.HasSuffix(enc, paddedEncSuffix) {
+		return strings.TrimSuffix(enc, paddedEncSuffix), true
+	}
+
+	return enc, false
+}
+
+func unpad(padded []byte) ([]byte, error) {
+	if len(padded) < paddingLengthSize {
+		return nil, errors.New("padded message is too short")
+	}
+
+	length := uint64(binary.BigEndian.Uint32(padded))
+	if length > uint64(len(padded)-paddingLengthSize) {
+		return nil, errors.New("invalid padded message length")
+	}
+
+	return padded[paddingLengthSize : paddingLengthSize+int(length)], nil
+}
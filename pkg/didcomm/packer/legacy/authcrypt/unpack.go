@@ -7,11 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package authcrypt
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/btcsuite/btcutil/base58"
 	chacha "golang.org/x/crypto/chacha20poly1305"
 
 	"github.com/hyperledger/aries-framework-go/pkg/doc/util/jwkkid"
@@ -19,10 +21,17 @@ import (
 	"github.com/hyperledger/aries-framework-go/pkg/didcomm/transport"
 	"github.com/hyperledger/aries-framework-go/pkg/internal/cryptoutil"
 	"github.com/hyperledger/aries-framework-go/pkg/kms"
+	"github.com/hyperledger/aries-framework-go/pkg/vdr/fingerprint"
 )
 
+const didKeyPrefix = "did:key:"
+
 // Unpack will decode the envelope using the legacy format
 // Using (X)Chacha20 encryption algorithm and Poly1035 authenticator.
+//
+// It is the counterpart of Pack: the CEK is opened for the first recipient whose key is held by the KMS, the sender
+// key is decrypted from the sealed box, and the payload is opened with the protected header as additional data.
+// A tampered envelope fails with the "chacha20poly1305: message authentication failed" error.
 func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	var envelopeData legacyEnvelope
 
@@ -52,14 +61,19 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 		return nil, fmt.Errorf("message format %s not supported", protectedData.Alg)
 	}
 
-	keys, err := getCEK(protectedData.Recipients, p.kms)
+	keys, err := getCEK(protectedData.Recipients, p.kms, p.alphabet)
 	if err != nil {
 		return nil, err
 	}
 
 	cek, senderKey, recKey := keys.cek, keys.theirKey, keys.myKey
 
-	data, err := p.decodeCipherText(cek, &envelopeData)
+	enc, padded := contentEncryption(protectedData.Enc)
+
+	data, err := p.decodeCipherText(cek, enc, &envelopeData)
+	if err == nil && padded {
+		data, err = unpad(data)
+	}
 
 	return &transport.Envelope{
 		Message: data,
@@ -68,28 +82,253 @@ func (p *Packer) Unpack(envelope []byte) (*transport.Envelope, error) {
 	}, err
 }
 
+// MatchableRecipients returns the recipient KIDs (base58 encoded verification keys) of the legacy envelope env
+// which are also found in myKIDs, i.e. the keys the agent can use to open the envelope.
+// KIDs are returned in the order they appear in the envelope.
+func MatchableRecipients(env []byte, myKIDs []string) ([]string, error) {
+	envKIDs, err := recipientKIDs(env)
+	if err != nil {
+		return nil, fmt.Errorf("matchableRecipients: %w", err)
+	}
+
+	mine := make(map[string]struct{}, len(myKIDs))
+
+	for _, kid := range myKIDs {
+		mine[kid] = struct{}{}
+	}
+
+	var matched []string
+
+	for _, kid := range envKIDs {
+		if _, ok := mine[kid]; ok {
+			matched = append(matched, kid)
+		}
+	}
+
+	return matched, nil
+}
+
+// VerifyRecipients checks that the legacy envelope env is addressed to exactly the expected recipient keys
+// (raw Ed25519 verification keys), in any order. The keys are encoded with the default (Bitcoin) base58 alphabet,
+// use Packer.VerifyRecipients for the envelopes packed with another alphabet.
+// It returns an error listing the unexpected and the missing recipients if the sets differ.
+func VerifyRecipients(env []byte, expected [][]byte) error {
+	return verifyRecipients(env, expected, BitcoinAlphabet)
+}
+
+// VerifyRecipients checks that the legacy envelope env is addressed to exactly the expected recipient keys
+// as the package level VerifyRecipients does, with the keys encoded with the base58 alphabet of the Packer.
+func (p *Packer) VerifyRecipients(env []byte, expected [][]byte) error {
+	return verifyRecipients(env, expected, p.alphabet)
+}
+
+func verifyRecipients(env []byte, expected [][]byte, alphabet *Base58Alphabet) error {
+	envKIDs, err := recipientKIDs(env)
+	if err != nil {
+		return fmt.Errorf("verifyRecipients: %w", err)
+	}
+
+	want := make(map[string]struct{}, len(expected))
+
+	for _, key := range expected {
+		want[alphabet.Encode(key)] = struct{}{}
+	}
+
+	got := make(map[string]struct{}, len(envKIDs))
+
+	var unexpected, missing []string
+
+	for _, kid := range envKIDs {
+		got[kid] = struct{}{}
+
+		if _, ok := want[kid]; !ok {
+			unexpected = append(unexpected, kid)
+		}
+	}
+
+	for _, key := range expected {
+		kid := alphabet.Encode(key)
+
+		if _, ok := got[kid]; !ok {
+			missing = append(missing, kid)
+		}
+	}
+
+	if len(unexpected) > 0 || len(missing) > 0 {
+		return fmt.Errorf("verifyRecipients: recipients mismatch: unexpected %v, missing %v", unexpected, missing)
+	}
+
+	return nil
+}
+
+// SenderMatchesDIDKey reports whether the sender key of an unpacked legacy envelope (transport.Envelope FromKey,
+// a raw Ed25519 verification key) is the key of the expected sender DID, a did:key DID or DID URL. It returns an
+// error if expectedSender is not a valid Ed25519 did:key.
+func SenderMatchesDIDKey(fromKey []byte, expectedSender string) (bool, error) {
+	didKey, _, _ := strings.Cut(expectedSender, "#")
+
+	if !strings.HasPrefix(didKey, didKeyPrefix) {
+		return false, fmt.Errorf("senderMatchesDIDKey: %s is not a did:key", expectedSender)
+	}
+
+	pubKey, code, err := fingerprint.PubKeyFromFingerprint(strings.TrimPrefix(didKey, didKeyPrefix))
+	if err != nil {
+		return false, fmt.Errorf("senderMatchesDIDKey: %w", err)
+	}
+
+	if code != fingerprint.ED25519PubKeyMultiCodec {
+		return false, fmt.Errorf("senderMatchesDIDKey: %s is not an Ed25519 did:key", expectedSender)
+	}
+
+	return bytes.Equal(fromKey, pubKey), nil
+}
+
+// ValidateProtected checks the structure of the protected header of the legacy envelope env without decrypting it:
+// typ, enc and alg must be the ones of legacy envelopes, and every recipient must have a kid and a base64 encoded
+// encrypted_key. Authcrypt recipients must also have a sender and a 24 bytes iv, while Anoncrypt recipients must not.
+// Integrity of the header is only verified on decryption, as the header is the additional data of the AEAD.
+func ValidateProtected(env []byte) error {
+	protectedData, err := parseProtected(env)
+	if err != nil {
+		return fmt.Errorf("validateProtected: %w", err)
+	}
+
+	if err = validateProtected(protectedData); err != nil {
+		return fmt.Errorf("validateProtected: %w", err)
+	}
+
+	return nil
+}
+
+func validateProtected(protectedData *protected) error {
+	if protectedData.Typ != encodingType {
+		return fmt.Errorf("message type %s not supported", protectedData.Typ)
+	}
+
+	if enc, _ := contentEncryption(protectedData.Enc); ContentCipher(enc) != C20P && ContentCipher(enc) != XC20P {
+		return fmt.Errorf("encryption %s not supported", protectedData.Enc)
+	}
+
+	if protectedData.Alg != "Authcrypt" && protectedData.Alg != "Anoncrypt" {
+		return fmt.Errorf("message format %s not supported", protectedData.Alg)
+	}
+
+	if len(protectedData.Recipients) == 0 {
+		return errors.New("no recipients")
+	}
+
+	for i := range protectedData.Recipients {
+		if err := validateRecipient(protectedData.Alg == "Authcrypt", &protectedData.Recipients[i]); err != nil {
+			return fmt.Errorf("recipient %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func validateRecipient(authcrypt bool, rec *recipient) error {
+	if rec.Header.KID == "" {
+		return errors.New("kid is missing")
+	}
+
+	if _, err := decodeRequired(rec.EncryptedKey); err != nil {
+		return fmt.Errorf("encrypted_key: %w", err)
+	}
+
+	if !authcrypt {
+		if rec.Header.Sender != "" || rec.Header.IV != "" {
+			return errors.New("anoncrypt recipient must not have sender and iv")
+		}
+
+		return nil
+	}
+
+	if _, err := decodeRequired(rec.Header.Sender); err != nil {
+		return fmt.Errorf("sender: %w", err)
+	}
+
+	nonce, err := decodeRequired(rec.Header.IV)
+	if err != nil {
+		return fmt.Errorf("iv: %w", err)
+	}
+
+	if len(nonce) != 24 {
+		return fmt.Errorf("iv: invalid nonce size %d", len(nonce))
+	}
+
+	return nil
+}
+
+func decodeRequired(b64 string) ([]byte, error) {
+	if b64 == "" {
+		return nil, errors.New("is missing")
+	}
+
+	return base64.URLEncoding.DecodeString(b64)
+}
+
+// recipientKIDs parses the protected header of the legacy envelope env and returns its recipient KIDs.
+func recipientKIDs(env []byte) ([]string, error) {
+	protectedData, err := parseProtected(env)
+	if err != nil {
+		return nil, err
+	}
+
+	kids := make([]string, 0, len(protectedData.Recipients))
+
+	for _, rec := range protectedData.Recipients {
+		kids = append(kids, rec.Header.KID)
+	}
+
+	return kids, nil
+}
+
+// parseProtected parses the protected header of the legacy envelope env.
+func parseProtected(env []byte) (*protected, error) {
+	var envelopeData legacyEnvelope
+
+	err := json.Unmarshal(env, &envelopeData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal envelope: %w", err)
+	}
+
+	protectedBytes, err := base64.URLEncoding.DecodeString(envelopeData.Protected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode protected header: %w", err)
+	}
+
+	var protectedData protected
+
+	err = json.Unmarshal(protectedBytes, &protectedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal protected header: %w", err)
+	}
+
+	return &protectedData, nil
+}
+
 type keys struct {
 	cek      *[chacha.KeySize]byte
 	theirKey []byte
 	myKey    []byte
 }
 
-func getCEK(recipients []recipient, km kms.KeyManager) (*keys, error) {
+func getCEK(recipients []recipient, km kms.KeyManager, alphabet *Base58Alphabet) (*keys, error) {
 	var candidateKeys []string
 
 	for _, candidate := range recipients {
 		candidateKeys = append(candidateKeys, candidate.Header.KID)
 	}
 
-	recKeyIdx, err := findVerKey(km, candidateKeys)
+	recKeyIdx, err := findVerKey(km, candidateKeys, alphabet)
 	if err != nil {
 		return nil, fmt.Errorf("getCEK: no key accessible %w", err)
 	}
 
 	recip := recipients[recKeyIdx]
-	recKey := base58.Decode(recip.Header.KID)
+	recKey := alphabet.Decode(recip.Header.KID)
 
-	senderPub, senderPubCurve, err := decodeSender(recip.Header.Sender, recKey, km)
+	senderPub, senderPubCurve, err := decodeSender(recip.Header.Sender, recKey, km, alphabet)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +353,13 @@ func getCEK(recipients []recipient, km kms.KeyManager) (*keys, error) {
 		return nil, fmt.Errorf("failed to decrypt CEK: %w", err)
 	}
 
+	if recip.Header.EPK != "" {
+		cekSlice, err = openEphemeralCEK(b, cekSlice, nonceSlice, alphabet.Decode(recip.Header.EPK), recKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var cek [chacha.KeySize]byte
 
 	copy(cek[:], cekSlice)
@@ -125,11 +371,11 @@ func getCEK(recipients []recipient, km kms.KeyManager) (*keys, error) {
 	}, nil
 }
 
-func findVerKey(km kms.KeyManager, candidateKeys []string) (int, error) {
+func findVerKey(km kms.KeyManager, candidateKeys []string, alphabet *Base58Alphabet) (int, error) {
 	var errs []error
 
 	for i, key := range candidateKeys {
-		recKID, err := jwkkid.CreateKID(base58.Decode(key), kms.ED25519Type)
+		recKID, err := jwkkid.CreateKID(alphabet.Decode(key), kms.ED25519Type)
 		if err != nil {
 			return -1, err
 		}
@@ -145,7 +391,7 @@ func findVerKey(km kms.KeyManager, candidateKeys []string) (int, error) {
 	return -1, fmt.Errorf("none of the recipient keys were found in kms: %v", errs)
 }
 
-func decodeSender(b64Sender string, pk []byte, km kms.KeyManager) ([]byte, []byte, error) {
+func decodeSender(b64Sender string, pk []byte, km kms.KeyManager, alphabet *Base58Alphabet) ([]byte, []byte, error) {
 	encSender, err := base64.URLEncoding.DecodeString(b64Sender)
 	if err != nil {
 		return nil, nil, err
@@ -161,7 +407,7 @@ func decodeSender(b64Sender string, pk []byte, km kms.KeyManager) ([]byte, []byt
 		return nil, nil, err
 	}
 
-	senderData := base58.Decode(string(senderPub))
+	senderData := alphabet.Decode(string(senderPub))
 
 	senderPubCurve, err := cryptoutil.PublicEd25519toCurve25519(senderData)
 	if err != nil {
@@ -172,7 +418,7 @@ func decodeSender(b64Sender string, pk []byte, km kms.KeyManager) ([]byte, []byt
 }
 
 // decodeCipherText decodes (from base64) and decrypts the ciphertext using chacha20poly1305.
-func (p *Packer) decodeCipherText(cek *[chacha.KeySize]byte, envelope *legacyEnvelope) ([]byte, error) {
+func (p *Packer) decodeCipherText(cek *[chacha.KeySize]byte, enc string, envelope *legacyEnvelope) ([]byte, error) {
 	var cipherText, nonce, tag, aad, message []byte
 	aad = []byte(envelope.Protected)
 
@@ -191,7 +437,7 @@ func (p *Packer) decodeCipherText(cek *[chacha.KeySize]byte, envelope *legacyEnv
 		return nil, err
 	}
 
-	chachaCipher, err := chacha.New(cek[:])
+	chachaCipher, err := newContentAEAD(enc, len(nonce), cek[:])
 	if err != nil {
 		return nil, err
 	}
@@ -148,7 +148,8 @@ func WithBaseContextExtendedValidation(customContexts, customTypes []string) Cre
 	return verifiable.WithBaseContextExtendedValidation(customContexts, customTypes)
 }
 
-// WithJSONLDDocumentLoader defines a JSON-LD document loader.
+// WithJSONLDDocumentLoader defines a JSON-LD document loader used to resolve @context documents when validating
+// JSON-LD and checking linked data proofs of VC. If not defined, @context documents are fetched from the network.
 func WithJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) CredentialOpt {
 	return verifiable.WithJSONLDDocumentLoader(documentLoader)
 }
@@ -255,6 +256,15 @@ const (
 
 	// ECDSASecp521r1 JWT Algorithm.
 	ECDSASecp521r1 = verifiable.ECDSASecp521r1
+
+	// ES256 JWT Algorithm (ECDSA using P-256 and SHA-256).
+	ES256 = verifiable.ES256
+
+	// ES384 JWT Algorithm (ECDSA using P-384 and SHA-384).
+	ES384 = verifiable.ES384
+
+	// ES512 JWT Algorithm (ECDSA using P-521 and SHA-512).
+	ES512 = verifiable.ES512
 )
 
 // KeyTypeToJWSAlgo returns the JWSAlgorithm based on keyType.
@@ -262,6 +272,11 @@ func KeyTypeToJWSAlgo(keyType kms.KeyType) (JWSAlgorithm, error) {
 	return verifiable.KeyTypeToJWSAlgo(keyType)
 }
 
+// ParseJWSAlgorithm returns the JWSAlgorithm of the JOSE "alg" name, e.g. "ES256" or "RS256".
+func ParseJWSAlgorithm(name string) (JWSAlgorithm, error) {
+	return verifiable.ParseJWSAlgorithm(name)
+}
+
 // PublicKeyFetcher fetches public key for JWT signing verification based on Issuer ID (possibly DID)
 // and Key ID.
 // If not defined, JWT encoding is not tested.
@@ -281,9 +296,18 @@ type didResolver interface {
 	Resolve(did string, opts ...vdr.DIDMethodOption) (*did.DocResolution, error)
 }
 
+// VDRKeyResolverOpt configures VDRKeyResolver.
+type VDRKeyResolverOpt = verifiable.VDRKeyResolverOpt
+
 // NewVDRKeyResolver creates VDRKeyResolver.
-func NewVDRKeyResolver(vdr didResolver) *VDRKeyResolver {
-	return verifiable.NewVDRKeyResolver(vdr)
+func NewVDRKeyResolver(vdr didResolver, opts ...VDRKeyResolverOpt) *VDRKeyResolver {
+	return verifiable.NewVDRKeyResolver(vdr, opts...)
+}
+
+// WithRequiredKeyRelationship makes VDRKeyResolver accept only the keys listed under the given verification
+// relationship of the DID document, e.g. did.AssertionMethod for strict verification of credentials.
+func WithRequiredKeyRelationship(relationship did.VerificationRelationship) VDRKeyResolverOpt {
+	return verifiable.WithRequiredKeyRelationship(relationship)
 }
 
 // Proof defines embedded proof of Verifiable Credential.
@@ -407,6 +431,15 @@ func GetJWTSigner(signer Signer, algorithm string) *JwtSigner {
 	return verifiable.GetJWTSigner(signer, algorithm)
 }
 
+// MarshalJWSOpt is an option of MarshalJWS.
+type MarshalJWSOpt = verifiable.MarshalJWSOpt
+
+// WithOmitEmptyKID omits the "kid" JWS header when MarshalJWS is given an empty key ID,
+// instead of emitting an empty "kid" which is rejected by some verifiers.
+func WithOmitEmptyKID() MarshalJWSOpt {
+	return verifiable.WithOmitEmptyKID()
+}
+
 // SignatureRepresentation is a signature value holder type (e.g. "proofValue" or "jws").
 type SignatureRepresentation = verifiable.SignatureRepresentation
 
@@ -472,8 +505,8 @@ func WithPresStrictValidation() PresentationOpt {
 	return verifiable.WithPresStrictValidation()
 }
 
-// WithPresJSONLDDocumentLoader defines custom JSON-LD document loader. If not defined, when decoding VP
-// a new document loader will be created using CachingJSONLDLoader() if JSON-LD validation is made.
+// WithPresJSONLDDocumentLoader defines custom JSON-LD document loader used to resolve @context documents when
+// decoding VP and its credentials. If not defined, @context documents are fetched from the network.
 func WithPresJSONLDDocumentLoader(documentLoader jsonld.DocumentLoader) PresentationOpt {
 	return verifiable.WithPresJSONLDDocumentLoader(documentLoader)
 }
@@ -47,6 +47,7 @@ func Test_LDProofs_Compatibility(t *testing.T) {
 
 		// alice self-issues a VC
 		expectedVC := universityDegreeVC()
+		expectedVC.Issuer.ID = alicePeerDID.ID
 
 		now := time.Now()
 
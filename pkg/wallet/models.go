@@ -66,6 +66,9 @@ type ProofOptions struct {
 	// ProofRepresentation is type of proof data expected, (Refer verifiable.SignatureProofValue)
 	// Optional, by default proof will be represented as 'verifiable.SignatureProofValue'.
 	ProofRepresentation *verifiable.SignatureRepresentation `json:"proofRepresentation,omitempty"`
+	// AllowIssuerMismatch allows issuing a credential whose issuer is another DID than Controller.
+	// Optional, by default the credential issuer must be the Controller.
+	AllowIssuerMismatch bool `json:"allowIssuerMismatch,omitempty"`
 }
 
 // DeriveOptions model containing options for deriving a credential.
@@ -77,7 +77,8 @@ type provable interface {
 }
 
 type jwtClaims interface {
-	MarshalJWS(signatureAlg verifiable.JWSAlgorithm, signer verifiable.Signer, keyID string) (string, error)
+	MarshalJWS(signatureAlg verifiable.JWSAlgorithm, signer verifiable.Signer, keyID string,
+		opts ...verifiable.MarshalJWSOpt) (string, error)
 }
 
 // Wallet enables access to verifiable credential wallet features.
@@ -855,6 +856,7 @@ func (c *Wallet) addLinkedDataProof(authToken string, p provable, opts *ProofOpt
 		Domain:                  opts.Domain,
 		Challenge:               opts.Challenge,
 		Purpose:                 supportedRelationships[relationship],
+		AllowIssuerMismatch:     opts.AllowIssuerMismatch,
 	}
 
 	err = p.AddLinkedDataProof(signingCtx, jsonld.WithDocumentLoader(c.jsonldDocumentLoader))
@@ -1177,10 +1177,19 @@ func TestWallet_Issue(t *testing.T) {
 		// nolint: errcheck, gosec
 		kmgr.ImportPrivateKey(edPriv, kms.ED25519, kms.WithKeyID(kid))
 
-		// sign with just controller
+		// credential issuer is not the controller
 		result, err := walletInstance.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
 			Controller: didKey,
 		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match issuer DID")
+		require.Empty(t, result)
+
+		// sign with just controller
+		result, err = walletInstance.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
+			Controller:          didKey,
+			AllowIssuerMismatch: true,
+		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
 		require.Len(t, result.Proofs, 1)
@@ -1208,8 +1217,9 @@ func TestWallet_Issue(t *testing.T) {
 
 		// sign with just controller
 		result, err := walletInstance.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
-			Controller: didKey,
-			ProofType:  JSONWebSignature2020,
+			Controller:          didKey,
+			ProofType:           JSONWebSignature2020,
+			AllowIssuerMismatch: true,
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
@@ -1379,8 +1389,9 @@ func TestWallet_Issue(t *testing.T) {
 
 		// issue
 		result, err := walletInstance.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
-			Controller:         didKey,
-			VerificationMethod: sampleVerificationMethod,
+			Controller:          didKey,
+			VerificationMethod:  sampleVerificationMethod,
+			AllowIssuerMismatch: true,
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
@@ -1425,6 +1436,7 @@ func TestWallet_Issue(t *testing.T) {
 			Domain:              sampleDomain,
 			Created:             &created,
 			ProofRepresentation: &proofRepr,
+			AllowIssuerMismatch: true,
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
@@ -1470,6 +1482,7 @@ func TestWallet_Issue(t *testing.T) {
 			Controller:          didKeyBBS,
 			ProofType:           BbsBlsSignature2020,
 			ProofRepresentation: &proofRepr,
+			AllowIssuerMismatch: true,
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
@@ -1513,7 +1526,8 @@ func TestWallet_Issue(t *testing.T) {
 
 		// sign with just controller
 		result, err := walletInstance.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
-			Controller: didKey,
+			Controller:          didKey,
+			AllowIssuerMismatch: true,
 		})
 		require.NoError(t, err)
 		require.NotEmpty(t, result)
@@ -1686,7 +1700,8 @@ func TestWallet_Prove(t *testing.T) {
 
 	// issue a credential with Ed25519Signature2018
 	result, err := walletForIssue.Issue(issuerToken, testdata.SampleUDCVC, &ProofOptions{
-		Controller: didKey,
+		Controller:          didKey,
+		AllowIssuerMismatch: true,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, result)
@@ -1699,6 +1714,7 @@ func TestWallet_Prove(t *testing.T) {
 		Controller:          didKeyBBS,
 		ProofType:           BbsBlsSignature2020,
 		ProofRepresentation: &proofRepr,
+		AllowIssuerMismatch: true,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, result)
@@ -2334,7 +2350,8 @@ func TestWallet_Verify(t *testing.T) {
 
 	// issue a credential
 	sampleVC, err := walletForIssue.Issue(tkn, testdata.SampleUDCVC, &ProofOptions{
-		Controller: didKey,
+		Controller:          didKey,
+		AllowIssuerMismatch: true,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, sampleVC)
@@ -2615,7 +2632,8 @@ func TestWallet_Derive(t *testing.T) {
 
 	// issue a credential with Ed25519Signature2018
 	result, err := walletForIssue.Issue(authToken, testdata.SampleUDCVC, &ProofOptions{
-		Controller: didKey,
+		Controller:          didKey,
+		AllowIssuerMismatch: true,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, result)
@@ -2628,6 +2646,7 @@ func TestWallet_Derive(t *testing.T) {
 		Controller:          didKeyBBS,
 		ProofType:           BbsBlsSignature2020,
 		ProofRepresentation: &proofRepr,
+		AllowIssuerMismatch: true,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, result)
@@ -121,6 +121,7 @@ func signVC(privKeyB64, vcJSON, verificationMethod string) ([]byte, error) {
 		SignatureRepresentation: verifiable.SignatureProofValue,
 		Suite:                   sigSuite,
 		VerificationMethod:      verificationMethod,
+		AllowIssuerMismatch:     true,
 	}
 
 	jsonldDocLoader, err := createJSONLDDocumentLoader()
@@ -316,6 +316,7 @@ func sendPresentationMsg(url, piid string, msg []byte) error {
 				VerificationMethod:      didKey,
 				SignatureRepresentation: &signatureRepresentation,
 				SignatureType:           "BbsBlsSignature2020",
+				AllowIssuerMismatch:     true,
 			},
 		}, vRes)
 		if err != nil {
@@ -377,6 +378,7 @@ func sendPresentationMsgV3(url, piid string, msg []byte) error {
 				VerificationMethod:      didKey,
 				SignatureRepresentation: &signatureRepresentation,
 				SignatureType:           "BbsBlsSignature2020",
+				AllowIssuerMismatch:     true,
 			},
 		}, vRes)
 		if err != nil {
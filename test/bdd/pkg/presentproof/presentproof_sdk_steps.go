@@ -679,6 +679,7 @@ func (a *SDKSteps) acceptRequestPresentationBBS(prover, _, proof string) error {
 		SignatureRepresentation: verifiable.SignatureProofValue,
 		Suite:                   bbsblssignature2020.New(suite.WithSigner(newBBSSigner(km, cr, kid))),
 		VerificationMethod:      didKey,
+		AllowIssuerMismatch:     true,
 	}, jsonld.WithDocumentLoader(loader))
 
 	if err != nil {
@@ -779,6 +780,7 @@ func (a *SDKSteps) acceptRequestPresentationBBSV3(prover, _, proof string) error
 		SignatureRepresentation: verifiable.SignatureProofValue,
 		Suite:                   bbsblssignature2020.New(suite.WithSigner(newBBSSigner(km, cr, kid))),
 		VerificationMethod:      didKey,
+		AllowIssuerMismatch:     true,
 	}, jsonld.WithDocumentLoader(loader))
 
 	if err != nil {